@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// argumentFlags collects the repeated -arg flags.
+type argumentFlags []string
+
+func (a *argumentFlags) String() string {
+	return strings.Join(*a, " ")
+}
+
+func (a *argumentFlags) Set(value string) error {
+	*a = append(*a, value)
+	return nil
+}
+
+// setArguments sets the key=value pairs in the tool arguments. The keys are dot
+// paths into nested objects and arrays (e.g. "filter.tag_ids.0"), and the
+// values are coerced to the types declared by the input schema. Setting an
+// array more than once appends the values.
+func setArguments(schema *jsonschema.Schema, arguments map[string]any, pairs []string) (map[string]any, error) {
+	if arguments == nil {
+		arguments = make(map[string]any)
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected key=value", pair)
+		}
+		updated, err := setArgument(schema, schema, arguments, strings.Split(key, "."), value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %q: %w", key, err)
+		}
+		arguments = updated.(map[string]any)
+	}
+	return arguments, nil
+}
+
+// setArgument sets the value at the given path of current, returning the
+// updated value. The root schema is used to resolve the references.
+func setArgument(root, schema *jsonschema.Schema, current any, path []string, value string) (any, error) {
+	schema = resolveSchema(root, schema)
+	if len(path) == 0 {
+		coerced, err := coerceArgument(root, schema, value)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := current.([]any); ok {
+			if values, ok := coerced.([]any); ok {
+				return append(existing, values...), nil
+			}
+		}
+		return coerced, nil
+	}
+
+	segment := path[0]
+	if index, err := strconv.Atoi(segment); err == nil && schemaIs(schema, "array") {
+		list, _ := current.([]any)
+		if index < 0 || index > len(list) {
+			return nil, fmt.Errorf("index %d out of range, the next item is %d", index, len(list))
+		}
+		var items *jsonschema.Schema
+		if schema != nil {
+			items = schema.Items
+		}
+		if index == len(list) {
+			list = append(list, nil)
+		}
+		item, err := setArgument(root, items, list[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		list[index] = item
+		return list, nil
+	}
+
+	object, ok := current.(map[string]any)
+	if !ok {
+		if current != nil {
+			return nil, fmt.Errorf("%q isn't an object", segment)
+		}
+		object = make(map[string]any)
+	}
+	var property *jsonschema.Schema
+	if schema != nil {
+		property, ok = schema.Properties[segment]
+		if !ok && len(schema.Properties) > 0 {
+			if !allowsAdditionalProperties(schema) {
+				return nil, fmt.Errorf("unknown property %q, expected one of: %s",
+					segment, strings.Join(propertyNames(schema), ", "))
+			}
+			property = schema.AdditionalProperties
+		}
+	}
+	updated, err := setArgument(root, property, object[segment], path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	object[segment] = updated
+	return object, nil
+}
+
+// coerceArgument converts a flag value to the type declared by the schema.
+// Without a declared type, the value is decoded as JSON when possible.
+func coerceArgument(root, schema *jsonschema.Schema, value string) (any, error) {
+	if value == "null" && schemaIs(schema, "null") {
+		return nil, nil
+	}
+	switch primaryType(schema) {
+	case "integer":
+		integer, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return integer, nil
+	case "number":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return number, nil
+	case "boolean":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", value)
+		}
+		return boolean, nil
+	case "array":
+		if strings.HasPrefix(value, "[") {
+			var list []any
+			if err := json.Unmarshal([]byte(value), &list); err != nil {
+				return nil, fmt.Errorf("invalid JSON array: %w", err)
+			}
+			return list, nil
+		}
+		// comma separated items, e.g. "1,2,3"
+		list := []any{}
+		if value == "" {
+			return list, nil
+		}
+		for item := range strings.SplitSeq(value, ",") {
+			coerced, err := coerceArgument(root, resolveSchema(root, schema.Items), strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, coerced)
+		}
+		return list, nil
+	case "object":
+		var object map[string]any
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return nil, fmt.Errorf("invalid JSON object: %w", err)
+		}
+		return object, nil
+	case "string":
+		switch schema.Format {
+		case "date":
+			date, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			return date.Format(time.DateOnly), nil
+		case "date-time":
+			date, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			return date.Format(time.RFC3339), nil
+		}
+		return value, nil
+	default:
+		var decoded any
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return value, nil
+		}
+		return decoded, nil
+	}
+}
+
+// parseDate parses a date in the formats YYYY-MM-DD or RFC3339, or one of the
+// relative dates "now", "today", "yesterday" and "tomorrow".
+func parseDate(value string) (time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch value {
+	case "now":
+		return now.UTC().Truncate(time.Second), nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD, RFC3339, now, today, yesterday "+
+		"or tomorrow", value)
+}
+
+// resolveSchema follows the local reference of a schema (e.g.
+// "#/$defs/Project"), as the input schemas can share definitions.
+func resolveSchema(root, schema *jsonschema.Schema) *jsonschema.Schema {
+	for range 10 { // references can point to references, but not indefinitely
+		if schema == nil || schema.Ref == "" {
+			return schema
+		}
+		var definitions map[string]*jsonschema.Schema
+		name, ok := strings.CutPrefix(schema.Ref, "#/$defs/")
+		if ok {
+			definitions = root.Defs
+		} else if name, ok = strings.CutPrefix(schema.Ref, "#/definitions/"); ok {
+			definitions = root.Definitions
+		}
+		resolved, ok := definitions[name]
+		if !ok {
+			return schema
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+// primaryType returns the type of a schema, ignoring "null" when the schema
+// declares several types (e.g. the pointers inferred from Go types).
+func primaryType(schema *jsonschema.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Type != "" {
+		return schema.Type
+	}
+	for _, kind := range schema.Types {
+		if kind != "null" {
+			return kind
+		}
+	}
+	return ""
+}
+
+// schemaIs returns true if the schema declares the given type.
+func schemaIs(schema *jsonschema.Schema, kind string) bool {
+	return schema != nil && (schema.Type == kind || slices.Contains(schema.Types, kind))
+}
+
+// allowsAdditionalProperties returns true if the schema accepts properties
+// other than the declared ones. The schemas of the tools inferred from Go
+// types forbid them with "additionalProperties": false, decoded as {"not": {}}.
+func allowsAdditionalProperties(schema *jsonschema.Schema) bool {
+	return schema.AdditionalProperties == nil || schema.AdditionalProperties.Not == nil
+}
+
+func propertyNames(schema *jsonschema.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// usageError is an invalid command line, as opposed to a failure running the
+// command.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// parseCommandFlags parses the flags of a command, which can be placed after
+// its positional arguments (e.g. "read-resource <uri> -output json"). It
+// returns the positional arguments, before and after the flags.
+func parseCommandFlags(flags *flag.FlagSet, args []string, output *string) ([]string, error) {
+	var positional []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		positional, args = append(positional, args[0]), args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, usageError{err: err}
+	}
+	if output != nil && !slices.Contains(outputFormats, *output) {
+		return nil, usageError{err: fmt.Errorf("unknown output format %q, expected one of: %s",
+			*output, strings.Join(outputFormats, ", "))}
+	}
+	return append(positional, flags.Args()...), nil
+}
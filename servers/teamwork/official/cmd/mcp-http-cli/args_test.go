@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+type taskFilter struct {
+	TagIDs    []int64 `json:"tag_ids,omitempty"`
+	Completed *bool   `json:"completed,omitempty"`
+}
+
+type taskAssignee struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type taskDate string
+
+type taskInput struct {
+	Name      string         `json:"name"`
+	ListID    int64          `json:"tasklist_id"`
+	Progress  *float64       `json:"progress,omitempty"`
+	TagIDs    []int64        `json:"tag_ids,omitempty"`
+	DueDate   *taskDate      `json:"due_date,omitempty"`
+	StartAt   time.Time      `json:"start_at,omitzero"`
+	Filter    *taskFilter    `json:"filter,omitempty"`
+	Assignees []taskAssignee `json:"assignees,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+func taskInputSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	schema, err := jsonschema.For[taskInput](&jsonschema.ForOptions{
+		TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+			reflect.TypeFor[taskDate]():  {Type: "string", Format: "date"},
+			reflect.TypeFor[time.Time](): {Type: "string", Format: "date-time"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the clients receive the schema as JSON
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeInputSchema(json.RawMessage(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestSetArguments(t *testing.T) {
+	schema := taskInputSchema(t)
+	today := time.Now().Format(time.DateOnly)
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		pairs     []string
+		want      string
+		wantErr   string
+	}{{
+		name:  "scalars",
+		pairs: []string{"name=123", "tasklist_id=42", "progress=0.5"},
+		want:  `{"name":"123","progress":0.5,"tasklist_id":42}`,
+	}, {
+		name:  "comma separated array",
+		pairs: []string{"tag_ids=1, 2,3"},
+		want:  `{"tag_ids":[1,2,3]}`,
+	}, {
+		name:  "repeated array",
+		pairs: []string{"tag_ids=1", "tag_ids=[2,3]"},
+		want:  `{"tag_ids":[1,2,3]}`,
+	}, {
+		name:  "empty array",
+		pairs: []string{"tag_ids="},
+		want:  `{"tag_ids":[]}`,
+	}, {
+		name:  "dates",
+		pairs: []string{"due_date=2025-03-04T10:00:00Z", "start_at=2025-03-04"},
+		want:  `{"due_date":"2025-03-04","start_at":"2025-03-04T00:00:00Z"}`,
+	}, {
+		name:  "relative date",
+		pairs: []string{"due_date=today"},
+		want:  `{"due_date":"` + today + `"}`,
+	}, {
+		name:  "nested object",
+		pairs: []string{"filter.completed=true", "filter.tag_ids=1,2"},
+		want:  `{"filter":{"completed":true,"tag_ids":[1,2]}}`,
+	}, {
+		name:  "array items",
+		pairs: []string{"assignees.0.id=1", "assignees.0.type=users", "assignees.1.id=2"},
+		want:  `{"assignees":[{"id":1,"type":"users"},{"id":2}]}`,
+	}, {
+		name:  "JSON object",
+		pairs: []string{`filter={"completed":false}`},
+		want:  `{"filter":{"completed":false}}`,
+	}, {
+		name:  "additional properties",
+		pairs: []string{"metadata.priority=1", "metadata.label=high"},
+		want:  `{"metadata":{"label":"high","priority":1}}`,
+	}, {
+		name:      "merged with JSON arguments",
+		arguments: map[string]any{"name": "task", "tasklist_id": float64(1)},
+		pairs:     []string{"tasklist_id=2"},
+		want:      `{"name":"task","tasklist_id":2}`,
+	}, {
+		name:    "invalid integer",
+		pairs:   []string{"tasklist_id=abc"},
+		wantErr: `invalid argument "tasklist_id": invalid integer "abc"`,
+	}, {
+		name:    "invalid boolean",
+		pairs:   []string{"filter.completed=maybe"},
+		wantErr: `invalid argument "filter.completed": invalid boolean "maybe"`,
+	}, {
+		name:    "invalid date",
+		pairs:   []string{"due_date=04/03/2025"},
+		wantErr: `invalid date "04/03/2025"`,
+	}, {
+		name:    "unknown property",
+		pairs:   []string{"filter.status=late"},
+		wantErr: `unknown property "status", expected one of: completed, tag_ids`,
+	}, {
+		name:    "index out of range",
+		pairs:   []string{"assignees.1.id=1"},
+		wantErr: "index 1 out of range",
+	}, {
+		name:    "not an object",
+		pairs:   []string{"name=task", "name.first=task"},
+		wantErr: `"first" isn't an object`,
+	}, {
+		name:    "missing value",
+		pairs:   []string{"name"},
+		wantErr: `invalid argument "name", expected key=value`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setArguments(schema, tt.arguments, tt.pairs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("setArguments() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("setArguments() unexpected error = %v", err)
+			}
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(encoded) != tt.want {
+				t.Errorf("setArguments() = %s, want %s", encoded, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/teamwork/mcp/internal/config"
@@ -17,12 +22,23 @@ var (
 		"The URL of the MCP server to connect to")
 	mcpToken = flag.String("mcp-token", os.Getenv("TW_MCP_BEARER_TOKEN"),
 		"The token to use for authentication with the MCP server")
+	mcpTransportName = flag.String("transport", transportStreamable,
+		"The transport used to connect to the MCP server: streamable, sse or stdio")
+	mcpCommand = flag.String("stdio-command", "tw-mcp-stdio",
+		"The command line of the MCP server launched by the stdio transport")
 )
 
 func main() {
 	defer handleExit()
 
-	resources, teardown := config.Load(os.Stdout)
+	// the logs are written to stderr, so the results can be piped
+	resources, teardown, err := config.Load(os.Stderr, nil)
+	if err != nil {
+		resources.Logger().Error("failed to load configuration",
+			slog.String("error", err.Error()),
+		)
+		exit(exitCodeSetupFailure)
+	}
 	defer teardown()
 
 	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
@@ -32,23 +48,41 @@ func main() {
 		exit(exitCodeSetupFailure)
 	}
 
-	if *mcpURL == "" {
-		resources.Logger().Error("MCP URL is required")
+	mcpTransport, err := newTransport(*mcpTransportName, transportOptions{
+		url:        *mcpURL,
+		token:      *mcpToken,
+		command:    *mcpCommand,
+		httpClient: resources.TeamworkHTTPClient(),
+	})
+	if err != nil {
+		resources.Logger().Error("failed to create MCP transport",
+			slog.String("error", err.Error()),
+		)
 		exit(exitCodeSetupFailure)
 	}
 
-	httpClient := resources.TeamworkHTTPClient()
-	if *mcpToken != "" {
-		httpClient.Transport = newAuthRoundTripper(*mcpToken, httpClient.Transport)
-	}
-
-	mcpTransport := &mcp.SSEClientTransport{
-		Endpoint:   *mcpURL,
-		HTTPClient: httpClient,
+	// the resource updates are consumed by the subscribe command, dropping the
+	// ones that don't fit in the buffer instead of blocking the session
+	resourceUpdates := make(chan *mcp.ResourceUpdatedNotificationParams, 64)
+	// a change of the tool list is only flagged, the REPL lists the tools again
+	toolsChanged := make(chan struct{}, 1)
+	clientOptions := &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case toolsChanged <- struct{}{}:
+			default:
+			}
+		},
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			select {
+			case resourceUpdates <- req.Params:
+			default:
+			}
+		},
 	}
 
 	ctx := context.Background()
-	_, mcpClientSession, err := config.NewMCPClient(ctx, resources, mcpTransport, &mcp.ClientOptions{})
+	_, mcpClientSession, err := config.NewMCPClient(ctx, resources, mcpTransport, clientOptions)
 	if err != nil {
 		resources.Logger().Error("failed to create MCP client",
 			slog.String("error", err.Error()),
@@ -99,14 +133,71 @@ func main() {
 		}
 		toolName := args[1]
 
+		callToolFlags := flag.NewFlagSet("call-tool", flag.ContinueOnError)
+		var toolArgs argumentFlags
+		callToolFlags.Var(&toolArgs, "arg", "A tool argument as key=value, coerced to the type of the input "+
+			"schema. The key can be a dot path into nested objects and arrays. Can be repeated")
+		output := callToolFlags.String("output", outputText, "The output format: "+strings.Join(outputFormats, ", "))
+
+		// the JSON arguments are optional, and the flags are merged into them
+		callToolArgs := args[2:]
 		var toolParams map[string]any
-		if len(args) > 2 {
-			if err := json.Unmarshal([]byte(args[2]), &toolParams); err != nil {
+		if len(callToolArgs) > 0 && !strings.HasPrefix(callToolArgs[0], "-") {
+			if err := json.Unmarshal([]byte(callToolArgs[0]), &toolParams); err != nil {
 				resources.Logger().Error("failed to parse tool arguments",
 					slog.String("error", err.Error()),
 				)
 				exit(exitCodeSetupFailure)
 			}
+			callToolArgs = callToolArgs[1:]
+		}
+		if err := callToolFlags.Parse(callToolArgs); err != nil {
+			resources.Logger().Error("failed to parse call-tool flags",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		if callToolFlags.NArg() > 0 {
+			resources.Logger().Error("unexpected call-tool arguments",
+				slog.Any("arguments", callToolFlags.Args()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		if !slices.Contains(outputFormats, *output) {
+			resources.Logger().Error("unknown output format",
+				slog.String("output", *output),
+				slog.String("available_outputs", strings.Join(outputFormats, ", ")),
+			)
+			exit(exitCodeSetupFailure)
+		}
+
+		tool, err := findTool(ctx, mcpClientSession, toolName)
+		if err != nil {
+			resources.Logger().Error("failed to find tool",
+				slog.String("tool_name", toolName),
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		schema, err := decodeInputSchema(tool.InputSchema)
+		if err != nil {
+			resources.Logger().Error("failed to decode tool input schema",
+				slog.String("tool_name", toolName),
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		if toolParams, err = setArguments(schema, toolParams, toolArgs); err != nil {
+			resources.Logger().Error("failed to parse tool arguments",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		if err := config.ValidateToolArguments(tool, toolParams); err != nil {
+			resources.Logger().Error("invalid tool arguments",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
 		}
 
 		toolResult, err := mcpClientSession.CallTool(ctx, &mcp.CallToolParams{
@@ -129,26 +220,170 @@ func main() {
 			exit(exitCodeRunFailure)
 		}
 
-		resources.Logger().Info("tool executed successfully",
-			slog.String("tool_name", toolName),
-			slog.Any("result", toolResult.Content),
-		)
+		if err := writeResult(os.Stdout, *output, toolResult); err != nil {
+			resources.Logger().Error("failed to write tool result",
+				slog.String("tool_name", toolName),
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeRunFailure)
+		}
+
+	case "repl":
+		if err := runREPL(ctx, mcpClientSession, toolsChanged); err != nil {
+			resources.Logger().Error("failed to run REPL",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeRunFailure)
+		}
+
+	case "run":
+		runFlags := flag.NewFlagSet("run", flag.ContinueOnError)
+		var runVars argumentFlags
+		runFlags.Var(&runVars, "var", "A scenario variable as key=value, overriding the one declared by the "+
+			"scenarios. Can be repeated")
+		junitReportPath := runFlags.String("junit", "", "The path of the JUnit XML report")
+		jsonReportPath := runFlags.String("json", "", "The path of the JSON report")
+		if err := runFlags.Parse(args[1:]); err != nil {
+			resources.Logger().Error("failed to parse run flags",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		if runFlags.NArg() == 0 {
+			resources.Logger().Error("no scenario provided")
+			exit(exitCodeSetupFailure)
+		}
+		overrides := make(map[string]any, len(runVars))
+		for _, runVar := range runVars {
+			name, value, err := parseScenarioVariable(runVar)
+			if err != nil {
+				resources.Logger().Error("invalid scenario variable",
+					slog.String("error", err.Error()),
+				)
+				exit(exitCodeSetupFailure)
+			}
+			overrides[name] = value
+		}
+
+		scenarios := make([]*scenario, 0, runFlags.NArg())
+		for _, path := range runFlags.Args() {
+			s, err := loadScenario(path)
+			if err != nil {
+				resources.Logger().Error("failed to load scenario",
+					slog.String("error", err.Error()),
+				)
+				exit(exitCodeSetupFailure)
+			}
+			scenarios = append(scenarios, s)
+		}
+
+		runner, err := newScenarioRunner(ctx, mcpClientSession, os.Stdout)
+		if err != nil {
+			resources.Logger().Error("failed to create scenario runner",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		results := make([]scenarioResult, 0, len(scenarios))
+		passed := true
+		for _, s := range scenarios {
+			result := runner.run(ctx, s, overrides)
+			passed = passed && result.Passed
+			results = append(results, result)
+		}
+
+		reports := []struct {
+			path  string
+			write func(io.Writer, []scenarioResult) error
+		}{
+			{path: *junitReportPath, write: writeJUnitReport},
+			{path: *jsonReportPath, write: writeJSONReport},
+		}
+		for _, report := range reports {
+			if report.path == "" {
+				continue
+			}
+			if err := writeReportFile(report.path, results, report.write); err != nil {
+				resources.Logger().Error("failed to write report",
+					slog.String("path", report.path),
+					slog.String("error", err.Error()),
+				)
+				exit(exitCodeRunFailure)
+			}
+		}
+
+		if !passed {
+			resources.Logger().Error("scenarios failed")
+			exit(exitCodeRunFailure)
+		}
+
+	case "list-resources":
+		handleCommandError(resources.Logger(), args[0], listResources(ctx, mcpClientSession, os.Stdout, args[1:]))
+
+	case "list-resource-templates":
+		handleCommandError(resources.Logger(), args[0],
+			listResourceTemplates(ctx, mcpClientSession, os.Stdout, args[1:]))
+
+	case "read-resource":
+		handleCommandError(resources.Logger(), args[0], readResource(ctx, mcpClientSession, os.Stdout, args[1:]))
+
+	case "subscribe":
+		handleCommandError(resources.Logger(), args[0],
+			subscribe(ctx, mcpClientSession, resourceUpdates, os.Stdout, args[1:]))
+
+	case "list-prompts":
+		handleCommandError(resources.Logger(), args[0], listPrompts(ctx, mcpClientSession, os.Stdout, args[1:]))
+
+	case "get-prompt":
+		handleCommandError(resources.Logger(), args[0], getPrompt(ctx, mcpClientSession, os.Stdout, args[1:]))
 
 	default:
 		resources.Logger().Error("unknown command",
 			slog.String("command", args[0]),
-			slog.String("available_commands", "list-tools, call-tool"),
+			slog.String("available_commands", "list-tools, call-tool, repl, run, list-resources, "+
+				"list-resource-templates, read-resource, subscribe, list-prompts, get-prompt"),
 		)
 		exit(exitCodeSetupFailure)
 	}
 }
 
+// handleCommandError logs the error of a command and exits. Invalid command
+// lines exit with the setup failure code.
+func handleCommandError(logger *slog.Logger, command string, err error) {
+	if err == nil {
+		return
+	}
+	logger.Error("failed to run "+command,
+		slog.String("error", err.Error()),
+	)
+	if errors.As(err, new(usageError)) {
+		exit(exitCodeSetupFailure)
+	}
+	exit(exitCodeRunFailure)
+}
+
+// findTool returns the tool with the given name.
+func findTool(ctx context.Context, session *mcp.ClientSession, name string) (*mcp.Tool, error) {
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		if tool.Name == name {
+			return tool, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
+
 type authRoundTripper struct {
 	token string
 	next  http.RoundTripper
 }
 
 func newAuthRoundTripper(token string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
 	return &authRoundTripper{
 		token: token,
 		next:  next,
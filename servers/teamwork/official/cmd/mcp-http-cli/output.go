@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the tool results.
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputYAML, outputTable}
+
+// tableCellWidth is the maximum width of a table cell, longer values are
+// truncated.
+const tableCellWidth = 60
+
+// writeResult writes a tool result in the given format:
+//
+//   - text: the text contents, as returned by the tool.
+//   - json: the structured content (or the text content when it's JSON),
+//     indented.
+//   - yaml: the same value as json, encoded as YAML.
+//   - table: the first list of objects found in the value, one row per item
+//     and one column per property. Other values are rendered as key/value rows.
+func writeResult(w io.Writer, format string, result *mcp.CallToolResult) error {
+	if format == outputText {
+		writeContent(w, result.Content, false)
+		return nil
+	}
+
+	value := resultValue(result)
+	if value == nil {
+		if format == outputTable {
+			return errors.New("the result has no JSON value to render as a table, use the text output")
+		}
+		// the contents are encoded instead, e.g. text or images
+		var err error
+		if value, err = jsonValue(result.Content); err != nil {
+			return err
+		}
+	}
+
+	return writeValue(w, format, value)
+}
+
+// writeValue writes a generic JSON value in the json, yaml or table format.
+func writeValue(w io.Writer, format string, value any) error {
+	switch format {
+	case outputJSON:
+		encoded, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", encoded)
+		return err
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(integerValues(value)); err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		return encoder.Close()
+	case outputTable:
+		return writeTable(w, value)
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of: %s", format, strings.Join(outputFormats, ", "))
+	}
+}
+
+// writeContent writes the contents of a tool result. When indent is true, the
+// JSON text contents are indented.
+func writeContent(w io.Writer, contents []mcp.Content, indent bool) {
+	for _, content := range contents {
+		switch c := content.(type) {
+		case *mcp.TextContent:
+			var indented bytes.Buffer
+			if indent && json.Indent(&indented, []byte(c.Text), "", "  ") == nil {
+				_, _ = fmt.Fprintln(w, indented.String())
+			} else {
+				_, _ = fmt.Fprintln(w, c.Text)
+			}
+		case *mcp.ImageContent:
+			_, _ = fmt.Fprintf(w, "[image %s, %d bytes]\n", c.MIMEType, len(c.Data))
+		case *mcp.AudioContent:
+			_, _ = fmt.Fprintf(w, "[audio %s, %d bytes]\n", c.MIMEType, len(c.Data))
+		case *mcp.ResourceLink:
+			_, _ = fmt.Fprintf(w, "[resource %s]\n", c.URI)
+		case *mcp.EmbeddedResource:
+			if c.Resource != nil {
+				_, _ = fmt.Fprintf(w, "[resource %s]\n", c.Resource.URI)
+			}
+		}
+	}
+}
+
+// writeTable writes a list of objects as columns, or an object as key/value
+// rows.
+func writeTable(w io.Writer, value any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if rows, ok := findRows(value); ok {
+		columns := tableColumns(rows)
+		_, _ = fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, row := range rows {
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				cells[i] = tableCell(row[column])
+			}
+			_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		_, _ = fmt.Fprintln(tw, tableCell(value))
+		return tw.Flush()
+	}
+	for _, key := range sortedColumns(object) {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", key, tableCell(object[key]))
+	}
+	return tw.Flush()
+}
+
+// findRows returns the value when it's a list of objects, or the first list of
+// objects in its properties, sorted by name (e.g. "projects" in a response
+// with "meta" and "projects").
+func findRows(value any) ([]map[string]any, bool) {
+	if rows, ok := objectList(value); ok {
+		return rows, true
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if rows, ok := objectList(object[key]); ok {
+			return rows, true
+		}
+	}
+	return nil, false
+}
+
+func objectList(value any) ([]map[string]any, bool) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	rows := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		row, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		rows = append(rows, row)
+	}
+	return rows, true
+}
+
+// tableColumns returns the properties of all the rows.
+func tableColumns(rows []map[string]any) []string {
+	properties := make(map[string]any)
+	for _, row := range rows {
+		for key, value := range row {
+			properties[key] = value
+		}
+	}
+	return sortedColumns(properties)
+}
+
+// sortedColumns returns the keys of an object sorted by name, with the
+// identifying "id" and "name" first.
+func sortedColumns(object map[string]any) []string {
+	columns := make([]string, 0, len(object))
+	for key := range object {
+		columns = append(columns, key)
+	}
+	rank := func(column string) int {
+		switch column {
+		case "id":
+			return 0
+		case "name":
+			return 1
+		default:
+			return 2
+		}
+	}
+	slices.SortFunc(columns, func(a, b string) int {
+		if rankA, rankB := rank(a), rank(b); rankA != rankB {
+			return rankA - rankB
+		}
+		return strings.Compare(a, b)
+	})
+	return columns
+}
+
+// tableCell renders a value in a single line: the scalars as they are, and the
+// lists and objects as compact JSON.
+func tableCell(value any) string {
+	var cell string
+	switch v := value.(type) {
+	case nil:
+		cell = ""
+	case string:
+		cell = v
+	case float64:
+		// avoid the exponent notation of the large IDs
+		cell = strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any, []any:
+		encoded, _ := json.Marshal(v)
+		cell = string(encoded)
+	default:
+		cell = fmt.Sprint(v)
+	}
+	cell = strings.Join(strings.Fields(cell), " ")
+	if runes := []rune(cell); len(runes) > tableCellWidth {
+		cell = string(runes[:tableCellWidth-1]) + "…"
+	}
+	return cell
+}
+
+// jsonValue converts a Go value to its generic JSON representation.
+func jsonValue(v any) (any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+	return value, nil
+}
+
+// integerValues converts the integral numbers of a generic JSON value to
+// integers, so they aren't encoded in exponent notation (e.g. the IDs).
+func integerValues(value any) any {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			converted[key] = integerValues(item)
+		}
+		return converted
+	case []any:
+		converted := make([]any, len(v))
+		for i, item := range v {
+			converted[i] = integerValues(item)
+		}
+		return converted
+	default:
+		return value
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWriteResult(t *testing.T) {
+	listResult := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: `{"projects":[{"id":1234567,"name":"Website"},` +
+			`{"id":2,"name":"Mobile app","tags":["a"],"description":"A long\ndescription"}],"meta":{"page":1}}`}},
+	}
+	objectResult := &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: "Project 1234567"}},
+		StructuredContent: map[string]any{"name": "Website", "id": 1234567},
+	}
+	textResult := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: "Project created successfully with ID 1"}},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		result  *mcp.CallToolResult
+		want    string
+		wantErr string
+	}{{
+		name:   "text",
+		format: outputText,
+		result: objectResult,
+		want:   "Project 1234567\n",
+	}, {
+		name:   "json structured content",
+		format: outputJSON,
+		result: objectResult,
+		want:   "{\n  \"id\": 1234567,\n  \"name\": \"Website\"\n}\n",
+	}, {
+		name:   "json text content",
+		format: outputJSON,
+		result: textResult,
+		want: "[\n  {\n    \"text\": \"Project created successfully with ID 1\",\n" +
+			"    \"type\": \"text\"\n  }\n]\n",
+	}, {
+		name:   "yaml",
+		format: outputYAML,
+		result: objectResult,
+		want:   "id: 1234567\nname: Website\n",
+	}, {
+		name:   "table list",
+		format: outputTable,
+		result: listResult,
+		want: "ID       NAME        DESCRIPTION         TAGS\n" +
+			"1234567  Website                         \n" +
+			"2        Mobile app  A long description  [\"a\"]\n",
+	}, {
+		name:   "table object",
+		format: outputTable,
+		result: objectResult,
+		want:   "id    1234567\nname  Website\n",
+	}, {
+		name:    "table without JSON value",
+		format:  outputTable,
+		result:  textResult,
+		wantErr: "the result has no JSON value",
+	}, {
+		name:    "unknown format",
+		format:  "xml",
+		result:  objectResult,
+		wantErr: `unknown output format "xml"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := writeResult(&out, tt.format, tt.result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("writeResult() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("writeResult() unexpected error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("writeResult() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
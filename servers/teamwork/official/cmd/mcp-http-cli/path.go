@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// indexPattern matches the array indexes of a path, e.g. "[0]".
+var indexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// lookupPath returns the value at the given path of a decoded JSON value. The
+// path starts with "$", and its segments are object keys or array indexes,
+// e.g. "$.projects[0].id" or "$.projects.0.id".
+func lookupPath(value any, path string) (any, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("invalid path %q: must start with $", path)
+	}
+	rest = indexPattern.ReplaceAllString(rest, ".$1")
+	if rest == "" {
+		return value, nil
+	}
+	if !strings.HasPrefix(rest, ".") {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+
+	current := value
+	for _, segment := range strings.Split(rest[1:], ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[segment]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found: no key %q", path, segment)
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("path %q not found: invalid index %q", path, segment)
+			}
+			current = v[index]
+		default:
+			return nil, fmt.Errorf("path %q not found: %q isn't an object or an array", path, segment)
+		}
+	}
+	return current, nil
+}
+
+// resultValue returns the decoded JSON value of a tool result: the structured
+// content, or the text content when it's JSON. It returns nil when the result
+// has no JSON value.
+func resultValue(result *mcp.CallToolResult) any {
+	if result.StructuredContent != nil {
+		// normalize the value, as the structured content may be any Go value
+		value, err := jsonValue(result.StructuredContent)
+		if err != nil {
+			return nil
+		}
+		return value
+	}
+	if len(result.Content) != 1 {
+		return nil
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal([]byte(text.Text), &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// resultText returns the text contents of a tool result, one per line.
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listPrompts writes the prompts of the MCP server. The text output has a name
+// per line.
+func listPrompts(ctx context.Context, session *mcp.ClientSession, w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("list-prompts", flag.ContinueOnError)
+	output := flags.String("output", outputTable, "The output format: "+strings.Join(outputFormats, ", "))
+	if positional, err := parseCommandFlags(flags, args, output); err != nil {
+		return err
+	} else if len(positional) > 0 {
+		return usageError{err: fmt.Errorf("unexpected arguments: %v", positional)}
+	}
+
+	prompts := []*mcp.Prompt{}
+	for prompt, err := range session.Prompts(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		prompts = append(prompts, prompt)
+	}
+	return writeList(w, *output, prompts, func(prompt *mcp.Prompt) string {
+		return prompt.Name
+	})
+}
+
+// getPrompt writes the messages of a prompt. The arguments are checked against
+// the ones declared by the prompt before the request.
+func getPrompt(ctx context.Context, session *mcp.ClientSession, w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("get-prompt", flag.ContinueOnError)
+	var promptArgs argumentFlags
+	flags.Var(&promptArgs, "arg", "A prompt argument as key=value. Can be repeated")
+	output := flags.String("output", outputText, "The output format: "+strings.Join(outputFormats, ", "))
+	positional, err := parseCommandFlags(flags, args, output)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError{err: errors.New("expected a prompt name")}
+	}
+	name := positional[0]
+
+	var prompt *mcp.Prompt
+	for p, err := range session.Prompts(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		if p.Name == name {
+			prompt = p
+			break
+		}
+	}
+	if prompt == nil {
+		return usageError{err: fmt.Errorf("unknown prompt %q", name)}
+	}
+	arguments, err := promptArguments(prompt, promptArgs)
+	if err != nil {
+		return usageError{err: err}
+	}
+
+	result, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      name,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+
+	if *output != outputText {
+		value, err := jsonValue(result)
+		if err != nil {
+			return err
+		}
+		return writeValue(w, *output, value)
+	}
+	if result.Description != "" {
+		_, _ = fmt.Fprintf(w, "%s\n\n", result.Description)
+	}
+	for i, message := range result.Messages {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "[%s]\n", message.Role)
+		writeContent(w, []mcp.Content{message.Content}, false)
+	}
+	return nil
+}
+
+// promptArguments parses the key=value arguments of a prompt, reporting the
+// unknown and the missing required ones.
+func promptArguments(prompt *mcp.Prompt, pairs []string) (map[string]string, error) {
+	declared := make([]string, 0, len(prompt.Arguments))
+	for _, argument := range prompt.Arguments {
+		declared = append(declared, argument.Name)
+	}
+
+	arguments := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected key=value", pair)
+		}
+		if !slices.Contains(declared, key) {
+			return nil, fmt.Errorf("unknown argument %q for prompt %q, expected one of: %s",
+				key, prompt.Name, strings.Join(declared, ", "))
+		}
+		arguments[key] = value
+	}
+
+	var missing []string
+	for _, argument := range prompt.Arguments {
+		if _, ok := arguments[argument.Name]; argument.Required && !ok {
+			missing = append(missing, argument.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required arguments for prompt %q: %s", prompt.Name, strings.Join(missing, ", "))
+	}
+	return arguments, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPromptCommands(t *testing.T) {
+	tests := []struct {
+		name      string
+		get       bool
+		args      []string
+		want      string
+		wantErr   string
+		wantUsage bool
+	}{{
+		name: "list prompts",
+		args: []string{"-output", outputText},
+		want: "summarize_project\n",
+	}, {
+		name: "get prompt",
+		get:  true,
+		args: []string{"summarize_project", "-arg", "project_id=42", "--arg", "tone=friendly"},
+		want: "Project summary\n\n[user]\nSummarize project 42 with a friendly tone.\n\n[assistant]\nSure.\n",
+	}, {
+		name: "get prompt json",
+		get:  true,
+		args: []string{"summarize_project", "-arg", "project_id=42", "-output", outputJSON},
+		want: "{\n  \"description\": \"Project summary\",\n  \"messages\": [\n    {\n      \"content\": {\n" +
+			"        \"text\": \"Summarize project 42 with a neutral tone.\",\n        \"type\": \"text\"\n" +
+			"      },\n      \"role\": \"user\"\n    },\n    {\n      \"content\": {\n" +
+			"        \"text\": \"Sure.\",\n        \"type\": \"text\"\n      },\n      \"role\": \"assistant\"\n" +
+			"    }\n  ]\n}\n",
+	}, {
+		name:      "missing required argument",
+		get:       true,
+		args:      []string{"summarize_project", "-arg", "tone=friendly"},
+		wantErr:   `missing required arguments for prompt "summarize_project": project_id`,
+		wantUsage: true,
+	}, {
+		name:      "unknown argument",
+		get:       true,
+		args:      []string{"summarize_project", "-arg", "project_id=42", "-arg", "id=1"},
+		wantErr:   `unknown argument "id" for prompt "summarize_project", expected one of: project_id, tone`,
+		wantUsage: true,
+	}, {
+		name:      "unknown prompt",
+		get:       true,
+		args:      []string{"summarize_task"},
+		wantErr:   `unknown prompt "summarize_task"`,
+		wantUsage: true,
+	}}
+
+	session := newTestProtocolServer(t).clientSession
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			command := listPrompts
+			if tt.get {
+				command = getPrompt
+			}
+			err := command(context.Background(), session, &out, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("unexpected error %v, want %q", err, tt.wantErr)
+				}
+				if isUsage := errors.As(err, new(usageError)); isUsage != tt.wantUsage {
+					t.Errorf("unexpected usage error %t, want %t", isUsage, tt.wantUsage)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("unexpected output %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,518 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/peterh/liner"
+	"github.com/teamwork/mcp/internal/config"
+)
+
+const replPrompt = "mcp> "
+
+const replHelp = `Commands:
+  <tool> [key=value ...]   call a tool, the values are coerced to the argument types
+  <tool> {"key": "value"}  call a tool with JSON arguments
+  :tools                   list the tools
+  :describe <tool>         show the description and the arguments of a tool
+  :set                     list the variables
+  :set <name> <value>      set a variable, used as $name or ${name} in the commands
+  :set <name> $.<path>     set a variable from the last result, e.g. $.projects[0].id
+  :unset <name>            remove a variable
+  :help                    show this help
+  :quit                    exit, also Ctrl-D
+`
+
+// replCommands are the REPL commands, for the completion.
+var replCommands = []string{":tools", ":describe", ":set", ":unset", ":help", ":quit"}
+
+// variablePattern matches the variable references, e.g. "$project" or
+// "${project}".
+var variablePattern = regexp.MustCompile(`\$(\w+)|\$\{(\w+)\}`)
+
+// variableNamePattern matches the valid variable names.
+var variableNamePattern = regexp.MustCompile(`^\w+$`)
+
+// repl is an interactive session with the MCP server. The session is kept open
+// between the commands, and the variables can carry values from one result to
+// the next commands.
+type repl struct {
+	session *mcp.ClientSession
+	out     io.Writer
+
+	tools map[string]*replTool
+	names []string
+	vars  map[string]string
+	// last is the JSON value of the last tool result.
+	last any
+}
+
+// replTool is a tool of the MCP server, with its decoded input schema.
+type replTool struct {
+	tool   *mcp.Tool
+	schema *jsonschema.Schema
+	// keys are the sorted names of the arguments.
+	keys []string
+}
+
+func newREPL(ctx context.Context, session *mcp.ClientSession, out io.Writer) (*repl, error) {
+	r := &repl{
+		session: session,
+		out:     out,
+		vars:    make(map[string]string),
+	}
+	if err := r.loadTools(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// loadTools lists the tools of the MCP server, replacing the known ones. The
+// list changes when toolsets are enabled (e.g. a server with dynamic toolsets
+// only has the meta-tools at start).
+func (r *repl) loadTools(ctx context.Context) error {
+	tools := make(map[string]*replTool)
+	var names []string
+	for tool, err := range r.session.Tools(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		schema, err := decodeInputSchema(tool.InputSchema)
+		if err != nil {
+			return fmt.Errorf("invalid input schema of tool %q: %w", tool.Name, err)
+		}
+		t := &replTool{tool: tool, schema: schema}
+		for key := range schema.Properties {
+			t.keys = append(t.keys, key)
+		}
+		slices.Sort(t.keys)
+		tools[tool.Name] = t
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	r.tools, r.names = tools, names
+	return nil
+}
+
+// tool returns the tool of the given name. The tools are listed again when it
+// isn't known, in case the list changed since it was loaded.
+func (r *repl) tool(ctx context.Context, name string) (*replTool, error) {
+	if tool, ok := r.tools[name]; ok {
+		return tool, nil
+	}
+	if err := r.loadTools(ctx); err != nil {
+		return nil, err
+	}
+	if tool, ok := r.tools[name]; ok {
+		return tool, nil
+	}
+	return nil, fmt.Errorf("unknown tool %q, type :tools to list the tools", name)
+}
+
+// runREPL reads commands from the terminal until the user quits. The history is
+// stored in the user configuration directory. The tools are listed again when
+// the server notifies a change on toolsChanged, so the completion is up to
+// date.
+func runREPL(ctx context.Context, session *mcp.ClientSession, toolsChanged <-chan struct{}) error {
+	r, err := newREPL(ctx, session, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	line := liner.NewLiner()
+	defer line.Close() //nolint:errcheck
+	line.SetCtrlCAborts(true)
+	line.SetWordCompleter(r.complete)
+
+	historyFile := replHistoryFile()
+	if historyFile != "" {
+		if file, err := os.Open(historyFile); err == nil {
+			_, _ = line.ReadHistory(file)
+			_ = file.Close()
+		}
+	}
+
+	_, _ = fmt.Fprintf(r.out, "%d tools available, type :help for the commands\n", len(r.names))
+	for {
+		select {
+		case <-toolsChanged:
+			if err := r.loadTools(ctx); err != nil {
+				_, _ = fmt.Fprintf(r.out, "error: %s\n", err)
+			}
+		default:
+		}
+
+		input, err := line.Prompt(replPrompt)
+		if errors.Is(err, liner.ErrPromptAborted) {
+			continue
+		} else if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read command: %w", err)
+		}
+		if strings.TrimSpace(input) != "" {
+			line.AppendHistory(input)
+		}
+
+		quit, err := r.execute(ctx, input)
+		if err != nil {
+			_, _ = fmt.Fprintf(r.out, "error: %s\n", err)
+		}
+		if quit {
+			break
+		}
+	}
+
+	if historyFile != "" {
+		if err := os.MkdirAll(filepath.Dir(historyFile), 0o700); err != nil {
+			return fmt.Errorf("failed to create history directory: %w", err)
+		}
+		file, err := os.OpenFile(historyFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+		defer file.Close() //nolint:errcheck
+		if _, err := line.WriteHistory(file); err != nil {
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+	}
+	return nil
+}
+
+// replHistoryFile returns the path of the history file, or an empty string
+// when the user configuration directory is unknown.
+func replHistoryFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "teamwork-mcp", "cli_history")
+}
+
+// execute runs a command line. It returns true when the user quits.
+func (r *repl) execute(ctx context.Context, line string) (bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false, nil
+	}
+	command, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+
+	switch command {
+	case ":quit", ":exit":
+		return true, nil
+	case ":help":
+		_, _ = fmt.Fprint(r.out, replHelp)
+	case ":tools":
+		if err := r.loadTools(ctx); err != nil {
+			return false, err
+		}
+		r.listTools()
+	case ":describe":
+		return false, r.describe(ctx, rest)
+	case ":set":
+		return false, r.set(rest)
+	case ":unset":
+		if rest == "" {
+			return false, errors.New("usage: :unset <name>")
+		}
+		delete(r.vars, rest)
+	default:
+		if strings.HasPrefix(command, ":") {
+			return false, fmt.Errorf("unknown command %q, type :help for the commands", command)
+		}
+		return false, r.callTool(ctx, command, rest)
+	}
+	return false, nil
+}
+
+func (r *repl) listTools() {
+	w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+	for _, name := range r.names {
+		description, _, _ := strings.Cut(r.tools[name].tool.Description, "\n")
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", name, description)
+	}
+	_ = w.Flush()
+}
+
+func (r *repl) describe(ctx context.Context, name string) error {
+	tool, err := r.tool(ctx, name)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(r.out, tool.tool.Name)
+	if tool.tool.Description != "" {
+		_, _ = fmt.Fprintf(r.out, "\n%s\n", strings.TrimSpace(tool.tool.Description))
+	}
+	if annotations := tool.tool.Annotations; annotations != nil && annotations.ReadOnlyHint {
+		_, _ = fmt.Fprintln(r.out, "\nRead-only.")
+	}
+	if len(tool.keys) == 0 {
+		_, _ = fmt.Fprintln(r.out, "\nNo arguments.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(r.out, "\nArguments:")
+	w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+	for _, key := range tool.keys {
+		property := tool.schema.Properties[key]
+		kind := schemaType(property)
+		if slices.Contains(tool.schema.Required, key) {
+			kind += ", required"
+		}
+		description, _, _ := strings.Cut(property.Description, "\n")
+		_, _ = fmt.Fprintf(w, "  %s\t(%s)\t%s\n", key, kind, description)
+	}
+	return w.Flush()
+}
+
+// set sets a variable, or lists them when no name is given.
+func (r *repl) set(args string) error {
+	if args == "" {
+		names := make([]string, 0, len(r.vars))
+		for name := range r.vars {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			_, _ = fmt.Fprintf(r.out, "%s = %s\n", name, r.vars[name])
+		}
+		return nil
+	}
+
+	name, value, _ := strings.Cut(args, " ")
+	value = strings.TrimSpace(value)
+	if !variableNamePattern.MatchString(name) || value == "" {
+		return errors.New("usage: :set <name> <value> or :set <name> $.<path>")
+	}
+
+	if strings.HasPrefix(value, "$.") || value == "$" {
+		if r.last == nil {
+			return errors.New("the last result has no JSON value")
+		}
+		found, err := lookupPath(r.last, value)
+		if err != nil {
+			return err
+		}
+		if s, ok := found.(string); ok {
+			value = s
+		} else {
+			encoded, err := json.Marshal(found)
+			if err != nil {
+				return fmt.Errorf("failed to encode value: %w", err)
+			}
+			value = string(encoded)
+		}
+	} else {
+		var err error
+		if value, err = r.expand(value); err != nil {
+			return err
+		}
+	}
+	r.vars[name] = value
+	_, _ = fmt.Fprintf(r.out, "%s = %s\n", name, value)
+	return nil
+}
+
+// expand replaces the variable references of the given text.
+func (r *repl) expand(text string) (string, error) {
+	var missing []string
+	expanded := variablePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		match := variablePattern.FindStringSubmatch(reference)
+		name := match[1] + match[2]
+		value, ok := r.vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variables: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+func (r *repl) callTool(ctx context.Context, name, args string) error {
+	tool, err := r.tool(ctx, name)
+	if err != nil {
+		return err
+	}
+	args, err = r.expand(args)
+	if err != nil {
+		return err
+	}
+	arguments, err := tool.parseArguments(args)
+	if err != nil {
+		return err
+	}
+	if err := config.ValidateToolArguments(tool.tool, arguments); err != nil {
+		return err
+	}
+
+	result, err := r.session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      name,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run tool: %w", err)
+	}
+	if !result.IsError {
+		r.last = resultValue(result)
+	}
+	printResult(r.out, result)
+	return nil
+}
+
+// parseArguments parses the arguments of a tool call, either a JSON object or
+// key=value pairs coerced to the types of the input schema.
+func (t *replTool) parseArguments(args string) (map[string]any, error) {
+	if strings.HasPrefix(args, "{") {
+		var arguments map[string]any
+		if err := json.Unmarshal([]byte(args), &arguments); err != nil {
+			return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+		}
+		return arguments, nil
+	}
+	fields, err := splitFields(args)
+	if err != nil {
+		return nil, err
+	}
+	return setArguments(t.schema, nil, fields)
+}
+
+// splitFields splits a command line on the spaces, keeping the quoted text
+// together. The quotes are removed.
+func splitFields(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	inField := false
+	for _, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			field.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote, inField = c, true
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(c)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// complete completes the word under the cursor: the commands and the tool
+// names, the arguments of a tool, or the variables.
+func (r *repl) complete(line string, pos int) (head string, completions []string, tail string) {
+	head, tail = line[:pos], line[pos:]
+	start := strings.LastIndexAny(head, " \t") + 1
+	head, word := head[:start], head[start:]
+	words := strings.Fields(head)
+
+	var candidates []string
+	key, value, isArgument := strings.Cut(word, "=")
+	switch {
+	case strings.HasPrefix(word, "$"):
+		for name := range r.vars {
+			candidates = append(candidates, "$"+name)
+		}
+	case isArgument && strings.HasPrefix(value, "$"):
+		for name := range r.vars {
+			candidates = append(candidates, key+"=$"+name)
+		}
+	case len(words) == 0:
+		candidates = append(slices.Clone(replCommands), r.names...)
+	case len(words) == 1 && words[0] == ":describe":
+		candidates = r.names
+	case len(words) == 1 && (words[0] == ":set" || words[0] == ":unset"):
+		for name := range r.vars {
+			candidates = append(candidates, name)
+		}
+	case !isArgument:
+		if tool, ok := r.tools[words[0]]; ok {
+			for _, key := range tool.keys {
+				// the arguments already given aren't suggested again
+				if !strings.Contains(" "+head, " "+key+"=") {
+					candidates = append(candidates, key+"=")
+				}
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			completions = append(completions, candidate)
+		}
+	}
+	slices.Sort(completions)
+	return head, completions, tail
+}
+
+// printResult prints a tool result, with the JSON values indented.
+func printResult(w io.Writer, result *mcp.CallToolResult) {
+	if result.IsError {
+		_, _ = fmt.Fprint(w, "tool error: ")
+	} else if result.StructuredContent != nil {
+		if encoded, err := json.MarshalIndent(result.StructuredContent, "", "  "); err == nil {
+			_, _ = fmt.Fprintf(w, "%s\n", encoded)
+			return
+		}
+	}
+	writeContent(w, result.Content, true)
+}
+
+// decodeInputSchema decodes the input schema of a tool, received as a generic
+// JSON value.
+func decodeInputSchema(inputSchema any) (*jsonschema.Schema, error) {
+	schema := &jsonschema.Schema{}
+	if inputSchema == nil {
+		return schema, nil
+	}
+	encoded, err := json.Marshal(inputSchema)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// schemaType returns the type of a schema, or "any" when it's not declared.
+func schemaType(schema *jsonschema.Schema) string {
+	switch {
+	case schema == nil:
+		return "any"
+	case schema.Type != "":
+		return schema.Type
+	case len(schema.Types) > 0:
+		return strings.Join(schema.Types, "|")
+	default:
+		return "any"
+	}
+}
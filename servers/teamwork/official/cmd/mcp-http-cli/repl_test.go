@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type createProjectInput struct {
+	Name string   `json:"name" jsonschema:"the name of the project"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+type createProjectOutput struct {
+	Project struct {
+		ID   int64    `json:"id"`
+		Name string   `json:"name"`
+		Tags []string `json:"tags,omitempty"`
+	} `json:"project"`
+}
+
+type getProjectInput struct {
+	ID int64 `json:"id"`
+}
+
+type deleteProjectInput struct {
+	ID int64 `json:"id"`
+}
+
+// newTestSession creates a client session connected to a server with project
+// tools. The archive_project tool is only added by the enable_archive tool, like
+// the toolsets of a server with dynamic toolsets.
+func newTestSession(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "create_project", Description: "Create a project.\nMore details."},
+		func(_ context.Context, _ *mcp.CallToolRequest, input createProjectInput) (
+			*mcp.CallToolResult, createProjectOutput, error) {
+			var output createProjectOutput
+			output.Project.ID = 42
+			output.Project.Name = input.Name
+			output.Project.Tags = input.Tags
+			return nil, output, nil
+		})
+	mcp.AddTool(server, &mcp.Tool{Name: "get_project"},
+		func(_ context.Context, _ *mcp.CallToolRequest, input getProjectInput) (*mcp.CallToolResult, any, error) {
+			if input.ID != 42 {
+				return nil, nil, fmt.Errorf("project %d not found", input.ID)
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: `{"project":{"id":42}}`}},
+			}, nil, nil
+		})
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_project"},
+		func(_ context.Context, _ *mcp.CallToolRequest, input deleteProjectInput) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Project %d deleted", input.ID)}},
+			}, nil, nil
+		})
+	mcp.AddTool(server, &mcp.Tool{Name: "enable_archive"},
+		func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, any, error) {
+			mcp.AddTool(server, &mcp.Tool{Name: "archive_project"},
+				func(_ context.Context, _ *mcp.CallToolRequest, input getProjectInput) (*mcp.CallToolResult, any, error) {
+					return &mcp.CallToolResult{
+						Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Project %d archived", input.ID)}},
+					}, nil, nil
+				})
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Archive enabled"}}}, nil, nil
+		})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// newTestREPL creates a REPL connected to a server with project tools.
+func newTestREPL(t *testing.T) (*repl, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	r, err := newREPL(context.Background(), newTestSession(t), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, &out
+}
+
+func TestREPLExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		wantQuit bool
+		wantErr  string
+		// want is the output of the last line
+		want []string
+		// wantVars are the variables after the last line
+		wantVars map[string]string
+	}{{
+		name:  "key value arguments",
+		lines: []string{`create_project name="My project" tags='["a","b"]'`},
+		want:  []string{`"name": "My project"`, `"tags": [`, `"a"`},
+	}, {
+		name:  "JSON arguments",
+		lines: []string{`create_project {"name": "My project"}`},
+		want:  []string{`"id": 42`},
+	}, {
+		name:  "string argument isn't decoded",
+		lines: []string{`create_project name=123`},
+		want:  []string{`"name": "123"`},
+	}, {
+		name:     "set from last result",
+		lines:    []string{"create_project name=test", ":set project $.project.id"},
+		want:     []string{"project = 42"},
+		wantVars: map[string]string{"project": "42"},
+	}, {
+		name:  "variable in arguments",
+		lines: []string{"create_project name=test", ":set project $.project.id", "get_project id=$project"},
+		want:  []string{`"id": 42`},
+	}, {
+		name:     "set from JSON text result",
+		lines:    []string{":set id 42", "get_project id=${id}", ":set copy $.project"},
+		wantVars: map[string]string{"id": "42", "copy": `{"id":42}`},
+	}, {
+		name:     "set literal with variables",
+		lines:    []string{":set name world", ":set greeting hello-$name"},
+		wantVars: map[string]string{"name": "world", "greeting": "hello-world"},
+	}, {
+		name:     "unset",
+		lines:    []string{":set name world", ":unset name"},
+		wantVars: map[string]string{},
+	}, {
+		name:    "undefined variable",
+		lines:   []string{"get_project id=$missing"},
+		wantErr: "undefined variables: missing",
+	}, {
+		name:    "set without result",
+		lines:   []string{":set project $.project.id"},
+		wantErr: "the last result has no JSON value",
+	}, {
+		name:    "set missing path",
+		lines:   []string{"create_project name=test", ":set project $.project.missing"},
+		wantErr: `no key "missing"`,
+	}, {
+		name:  "tool error",
+		lines: []string{"get_project id=1"},
+		want:  []string{"tool error: project 1 not found"},
+	}, {
+		name:    "unknown tool",
+		lines:   []string{"archive_project id=1"},
+		wantErr: `unknown tool "archive_project"`,
+	}, {
+		name:  "tool enabled after the start",
+		lines: []string{"enable_archive", "archive_project id=42"},
+		want:  []string{"Project 42 archived"},
+	}, {
+		name:  "describe tool enabled after the start",
+		lines: []string{"enable_archive", ":describe archive_project"},
+		want:  []string{"archive_project", "id"},
+	}, {
+		name:    "unknown command",
+		lines:   []string{":unknown"},
+		wantErr: `unknown command ":unknown"`,
+	}, {
+		name:    "invalid argument",
+		lines:   []string{"create_project name"},
+		wantErr: `invalid argument "name"`,
+	}, {
+		name:    "unterminated quote",
+		lines:   []string{`create_project name="My project`},
+		wantErr: "unterminated quote",
+	}, {
+		name:  "describe",
+		lines: []string{":describe create_project"},
+		want:  []string{"Create a project.\nMore details.", "name", "(string, required)", "the name of the project", "tags"},
+	}, {
+		name:  "tools",
+		lines: []string{":tools"},
+		want:  []string{"create_project  Create a project.\n", "get_project"},
+	}, {
+		name:     "quit",
+		lines:    []string{":quit"},
+		wantQuit: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, out := newTestREPL(t)
+
+			var quit bool
+			var err error
+			for _, line := range tt.lines {
+				out.Reset()
+				if quit, err = r.execute(context.Background(), line); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("execute() unexpected error = %v", err)
+			}
+			if quit != tt.wantQuit {
+				t.Errorf("execute() quit = %t, want %t", quit, tt.wantQuit)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("execute() output = %q, want %q", out.String(), want)
+				}
+			}
+			if tt.wantVars != nil && !maps.Equal(r.vars, tt.wantVars) {
+				t.Errorf("execute() variables = %v, want %v", r.vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestREPLComplete(t *testing.T) {
+	r, _ := newTestREPL(t)
+	r.vars["project"] = "42"
+
+	tests := []struct {
+		name     string
+		line     string
+		wantHead string
+		want     []string
+	}{{
+		name: "commands and tools",
+		line: "",
+		want: append(slices.Sorted(slices.Values(replCommands)), "create_project", "delete_project", "enable_archive",
+			"get_project"),
+	}, {
+		name: "tool prefix",
+		line: "get",
+		want: []string{"get_project"},
+	}, {
+		name: "command prefix",
+		line: ":d",
+		want: []string{":describe"},
+	}, {
+		name:     "describe",
+		line:     ":describe cr",
+		wantHead: ":describe ",
+		want:     []string{"create_project"},
+	}, {
+		name:     "arguments",
+		line:     "create_project ",
+		wantHead: "create_project ",
+		want:     []string{"name=", "tags="},
+	}, {
+		name:     "arguments already given",
+		line:     "create_project name=test ",
+		wantHead: "create_project name=test ",
+		want:     []string{"tags="},
+	}, {
+		name:     "variable value",
+		line:     "get_project id=$p",
+		wantHead: "get_project ",
+		want:     []string{"id=$project"},
+	}, {
+		name:     "set variable",
+		line:     ":set p",
+		wantHead: ":set ",
+		want:     []string{"project"},
+	}, {
+		name:     "unknown tool",
+		line:     "archive_project ",
+		wantHead: "archive_project ",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, got, tail := r.complete(tt.line, len(tt.line))
+			if head != tt.wantHead {
+				t.Errorf("complete() head = %q, want %q", head, tt.wantHead)
+			}
+			if tail != "" {
+				t.Errorf("complete() tail = %q, want empty", tail)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	value := map[string]any{
+		"projects": []any{
+			map[string]any{"id": float64(1)},
+			map[string]any{"id": float64(2)},
+		},
+	}
+
+	tests := []struct {
+		path    string
+		want    any
+		wantErr bool
+	}{
+		{path: "$.projects[1].id", want: float64(2)},
+		{path: "$.projects.0.id", want: float64(1)},
+		{path: "$.projects[2].id", wantErr: true},
+		{path: "$.projects.id", wantErr: true},
+		{path: "$.projects[0].id.value", wantErr: true},
+		{path: "projects", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := lookupPath(value, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookupPath() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("lookupPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// writeReportFile writes a report of the scenarios in the given path.
+func writeReportFile(path string, results []scenarioResult,
+	write func(io.Writer, []scenarioResult) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := write(file, results); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// jsonReport is the JSON report of the scenario runs.
+type jsonReport struct {
+	Passed    bool                 `json:"passed"`
+	Scenarios []jsonScenarioReport `json:"scenarios"`
+}
+
+type jsonScenarioReport struct {
+	Name     string           `json:"name"`
+	File     string           `json:"file"`
+	Passed   bool             `json:"passed"`
+	Started  time.Time        `json:"started"`
+	Duration float64          `json:"duration_seconds"`
+	Steps    []jsonStepReport `json:"steps"`
+}
+
+type jsonStepReport struct {
+	Name     string         `json:"name"`
+	Tool     string         `json:"tool"`
+	Status   string         `json:"status"`
+	Duration float64        `json:"duration_seconds"`
+	Error    string         `json:"error,omitempty"`
+	Captured map[string]any `json:"captured,omitempty"`
+}
+
+// writeJSONReport writes the results of the scenarios as JSON.
+func writeJSONReport(w io.Writer, results []scenarioResult) error {
+	report := jsonReport{Passed: true}
+	for _, result := range results {
+		report.Passed = report.Passed && result.Passed
+		scenarioReport := jsonScenarioReport{
+			Name:     result.Name,
+			File:     result.File,
+			Passed:   result.Passed,
+			Started:  result.Started,
+			Duration: result.Duration.Seconds(),
+		}
+		for _, step := range result.Steps {
+			scenarioReport.Steps = append(scenarioReport.Steps, jsonStepReport{
+				Name:     step.Name,
+				Tool:     step.Tool,
+				Status:   step.Status,
+				Duration: step.Duration.Seconds(),
+				Error:    step.Error,
+				Captured: step.Captured,
+			})
+		}
+		report.Scenarios = append(report.Scenarios, scenarioReport)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+	return nil
+}
+
+// junitTestSuites is the JUnit XML report of the scenario runs, with a test
+// suite per scenario and a test case per step, as understood by the CI
+// servers.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	File      string          `xml:"file,attr,omitempty"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnitReport writes the results of the scenarios as JUnit XML.
+func writeJUnitReport(w io.Writer, results []scenarioResult) error {
+	report := junitTestSuites{Name: "mcp-http-cli"}
+	var total time.Duration
+	for _, result := range results {
+		suite := junitTestSuite{
+			Name:      result.Name,
+			File:      result.File,
+			Tests:     len(result.Steps),
+			Time:      junitTime(result.Duration),
+			Timestamp: result.Started.UTC().Format(time.RFC3339),
+		}
+		for _, step := range result.Steps {
+			testCase := junitTestCase{
+				Name:      step.Name,
+				ClassName: result.Name,
+				Time:      junitTime(step.Duration),
+			}
+			switch step.Status {
+			case stepFailed:
+				suite.Failures++
+				// the message is a summary, the text has every failed assertion
+				message, _, _ := strings.Cut(step.Error, "\n")
+				testCase.Failure = &junitFailure{Message: message, Text: step.Error}
+			case stepSkipped:
+				suite.Skipped++
+				testCase.Skipped = &junitSkipped{Message: "a previous step failed"}
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		total += result.Duration
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitTime(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
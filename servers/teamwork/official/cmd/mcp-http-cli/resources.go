@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listResources writes the resources of the MCP server. The text output has a
+// URI per line.
+func listResources(ctx context.Context, session *mcp.ClientSession, w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("list-resources", flag.ContinueOnError)
+	output := flags.String("output", outputTable, "The output format: "+strings.Join(outputFormats, ", "))
+	if positional, err := parseCommandFlags(flags, args, output); err != nil {
+		return err
+	} else if len(positional) > 0 {
+		return usageError{err: fmt.Errorf("unexpected arguments: %v", positional)}
+	}
+
+	resources := []*mcp.Resource{}
+	for resource, err := range session.Resources(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resources: %w", err)
+		}
+		resources = append(resources, resource)
+	}
+	return writeList(w, *output, resources, func(resource *mcp.Resource) string {
+		return resource.URI
+	})
+}
+
+// listResourceTemplates writes the resource templates of the MCP server. The
+// text output has a URI template per line.
+func listResourceTemplates(ctx context.Context, session *mcp.ClientSession, w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("list-resource-templates", flag.ContinueOnError)
+	output := flags.String("output", outputTable, "The output format: "+strings.Join(outputFormats, ", "))
+	if positional, err := parseCommandFlags(flags, args, output); err != nil {
+		return err
+	} else if len(positional) > 0 {
+		return usageError{err: fmt.Errorf("unexpected arguments: %v", positional)}
+	}
+
+	templates := []*mcp.ResourceTemplate{}
+	for template, err := range session.ResourceTemplates(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list resource templates: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return writeList(w, *output, templates, func(template *mcp.ResourceTemplate) string {
+		return template.URITemplate
+	})
+}
+
+// readResource writes the contents of a resource. The text output writes the
+// text contents, and the binary contents as they are.
+func readResource(ctx context.Context, session *mcp.ClientSession, w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("read-resource", flag.ContinueOnError)
+	output := flags.String("output", outputText, "The output format: "+strings.Join(outputFormats, ", "))
+	positional, err := parseCommandFlags(flags, args, output)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageError{err: errors.New("expected a resource URI")}
+	}
+
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: positional[0]})
+	if err != nil {
+		return fmt.Errorf("failed to read resource: %w", err)
+	}
+	return writeResourceContents(w, *output, result.Contents)
+}
+
+func writeResourceContents(w io.Writer, format string, contents []*mcp.ResourceContents) error {
+	if format != outputText {
+		value, err := jsonValue(contents)
+		if err != nil {
+			return err
+		}
+		return writeValue(w, format, value)
+	}
+	for _, content := range contents {
+		if content.Blob != nil {
+			if _, err := w.Write(content.Blob); err != nil {
+				return err
+			}
+			continue
+		}
+		text := content.Text
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribe subscribes to resources and writes a line for each update
+// notification, until interrupted. With the -read flag, the updated resource
+// is read and its contents written after the notification.
+func subscribe(
+	ctx context.Context,
+	session *mcp.ClientSession,
+	updates <-chan *mcp.ResourceUpdatedNotificationParams,
+	w io.Writer,
+	args []string,
+) error {
+	flags := flag.NewFlagSet("subscribe", flag.ContinueOnError)
+	read := flags.Bool("read", false, "Read the resource after each update notification")
+	output := flags.String("output", outputText, "The output format of the contents read: "+
+		strings.Join(outputFormats, ", "))
+	uris, err := parseCommandFlags(flags, args, output)
+	if err != nil {
+		return err
+	}
+	if len(uris) == 0 {
+		return usageError{err: errors.New("expected at least one resource URI")}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for _, uri := range uris {
+		if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", uri, err)
+		}
+		defer func() {
+			// the context may be canceled already
+			_ = session.Unsubscribe(context.WithoutCancel(ctx), &mcp.UnsubscribeParams{URI: uri})
+		}()
+	}
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- session.Wait()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-closed:
+			return fmt.Errorf("session closed: %w", err)
+		case update := <-updates:
+			if _, err := fmt.Fprintf(w, "%s updated %s\n", time.Now().UTC().Format(time.RFC3339), update.URI); err != nil {
+				return err
+			}
+			if !*read {
+				continue
+			}
+			result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: update.URI})
+			if err != nil {
+				return fmt.Errorf("failed to read resource: %w", err)
+			}
+			if err := writeResourceContents(w, *output, result.Contents); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeList writes a list of items: a name per line in the text output, or
+// the items in the other formats.
+func writeList[T any](w io.Writer, format string, items []T, name func(T) string) error {
+	if format == outputText {
+		for _, item := range items {
+			if _, err := fmt.Fprintln(w, name(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	value, err := jsonValue(items)
+	if err != nil {
+		return err
+	}
+	return writeValue(w, format, value)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// testProtocolServer is an MCP server exposing resources and prompts, which
+// records the resource subscriptions.
+type testProtocolServer struct {
+	server        *mcp.Server
+	subscribed    chan string
+	unsubscribed  chan string
+	clientSession *mcp.ClientSession
+	updates       chan *mcp.ResourceUpdatedNotificationParams
+}
+
+func newTestProtocolServer(t *testing.T) *testProtocolServer {
+	t.Helper()
+	ctx := context.Background()
+	s := &testProtocolServer{
+		subscribed:   make(chan string, 1),
+		unsubscribed: make(chan string, 1),
+		updates:      make(chan *mcp.ResourceUpdatedNotificationParams, 1),
+	}
+
+	s.server = mcp.NewServer(&mcp.Implementation{Name: "test-server"}, &mcp.ServerOptions{
+		SubscribeHandler: func(_ context.Context, req *mcp.SubscribeRequest) error {
+			s.subscribed <- req.Params.URI
+			return nil
+		},
+		UnsubscribeHandler: func(_ context.Context, req *mcp.UnsubscribeRequest) error {
+			s.unsubscribed <- req.Params.URI
+			return nil
+		},
+	})
+	s.server.AddResource(&mcp.Resource{URI: "twprojects://projects/42", Name: "project", MIMEType: "application/json"},
+		func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+				{URI: req.Params.URI, MIMEType: "application/json", Text: `{"id":42}`},
+			}}, nil
+		})
+	s.server.AddResource(&mcp.Resource{URI: "twprojects://logo", Name: "logo", MIMEType: "image/png"},
+		func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+				{URI: req.Params.URI, MIMEType: "image/png", Blob: []byte("PNG")},
+			}}, nil
+		})
+	s.server.AddResourceTemplate(&mcp.ResourceTemplate{URITemplate: "twprojects://projects/{id}", Name: "projects"},
+		func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		})
+	s.server.AddPrompt(&mcp.Prompt{
+		Name:        "summarize_project",
+		Description: "Summarize a project.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "project_id", Required: true},
+			{Name: "tone"},
+		},
+	}, func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		tone := req.Params.Arguments["tone"]
+		if tone == "" {
+			tone = "neutral"
+		}
+		return &mcp.GetPromptResult{
+			Description: "Project summary",
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{
+					Text: "Summarize project " + req.Params.Arguments["project_id"] + " with a " + tone + " tone."}},
+				{Role: "assistant", Content: &mcp.TextContent{Text: "Sure."}},
+			},
+		}, nil
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			s.updates <- req.Params
+		},
+	})
+	if s.clientSession, err = client.Connect(ctx, clientTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.clientSession.Close() })
+	return s
+}
+
+func TestResourceCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		command func(context.Context, *mcp.ClientSession, io.Writer, []string) error
+		args    []string
+		want    string
+		wantErr string
+	}{{
+		name:    "list resources",
+		command: listResources,
+		args:    []string{"-output", outputText},
+		want:    "twprojects://logo\ntwprojects://projects/42\n",
+	}, {
+		name:    "list resources table",
+		command: listResources,
+		want: "NAME     MIMETYPE          URI\n" +
+			"logo     image/png         twprojects://logo\n" +
+			"project  application/json  twprojects://projects/42\n",
+	}, {
+		name:    "list resource templates",
+		command: listResourceTemplates,
+		args:    []string{"-output", outputText},
+		want:    "twprojects://projects/{id}\n",
+	}, {
+		name:    "read resource",
+		command: readResource,
+		args:    []string{"twprojects://projects/42"},
+		want:    "{\"id\":42}\n",
+	}, {
+		name:    "read resource after the flags",
+		command: readResource,
+		args:    []string{"-output", outputText, "twprojects://projects/42"},
+		want:    "{\"id\":42}\n",
+	}, {
+		name:    "read binary resource",
+		command: readResource,
+		args:    []string{"twprojects://logo"},
+		want:    "PNG",
+	}, {
+		name:    "read resource yaml",
+		command: readResource,
+		args:    []string{"twprojects://projects/42", "-output", outputYAML},
+		want:    "- mimeType: application/json\n  text: '{\"id\":42}'\n  uri: twprojects://projects/42\n",
+	}, {
+		name:    "read unknown resource",
+		command: readResource,
+		args:    []string{"twprojects://projects/1"},
+		wantErr: "failed to read resource",
+	}, {
+		name:    "read resource without URI",
+		command: readResource,
+		wantErr: "expected a resource URI",
+	}, {
+		name:    "unknown output format",
+		command: listResources,
+		args:    []string{"-output", "xml"},
+		wantErr: `unknown output format "xml"`,
+	}}
+
+	session := newTestProtocolServer(t).clientSession
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := tt.command(context.Background(), session, &out, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("unexpected error %v, want %q", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("unexpected output %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+// lineWriter sends each write to a channel, so the output of a command running
+// in the background can be awaited.
+type lineWriter struct {
+	mutex sync.Mutex
+	lines chan string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lines <- string(p)
+	return len(p), nil
+}
+
+func TestSubscribe(t *testing.T) {
+	s := newTestProtocolServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := &lineWriter{lines: make(chan string, 2)}
+	done := make(chan error, 1)
+	go func() {
+		done <- subscribe(ctx, s.clientSession, s.updates, out, []string{"twprojects://projects/42", "-read"})
+	}()
+
+	select {
+	case uri := <-s.subscribed:
+		if uri != "twprojects://projects/42" {
+			t.Fatalf("unexpected subscription to %q", uri)
+		}
+	case err := <-done:
+		t.Fatalf("subscribe returned before subscribing: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the subscription")
+	}
+
+	err := s.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: "twprojects://projects/42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{" updated twprojects://projects/42\n", "{\"id\":42}\n"} {
+		select {
+		case line := <-out.lines:
+			if !strings.HasSuffix(line, want) {
+				t.Errorf("unexpected output %q, want suffix %q", line, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %q", want)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	select {
+	case uri := <-s.unsubscribed:
+		if uri != "twprojects://projects/42" {
+			t.Errorf("unexpected unsubscription from %q", uri)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timeout waiting for the unsubscription")
+	}
+
+	var usage usageError
+	if err := subscribe(ctx, s.clientSession, s.updates, out, nil); !errors.As(err, &usage) {
+		t.Errorf("unexpected error %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,583 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Status of the scenario steps.
+const (
+	stepPassed  = "passed"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// scenarioVariablePattern matches the variable references of the scenarios,
+// e.g. "${project_id}".
+var scenarioVariablePattern = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// scenario is an ordered list of tool calls, run by the run command as an end
+// to end test of the MCP server.
+type scenario struct {
+	Name string `yaml:"name"`
+	// Vars are the initial variables, referenced as ${name} in the steps.
+	Vars  map[string]any `yaml:"vars"`
+	Steps []scenarioStep `yaml:"steps"`
+
+	file string
+}
+
+// scenarioStep is a tool call of a scenario, with the assertions on its result.
+type scenarioStep struct {
+	Name   string          `yaml:"name"`
+	Tool   string          `yaml:"tool"`
+	Args   map[string]any  `yaml:"args"`
+	Expect stepExpectation `yaml:"expect"`
+	// Capture sets variables from the result, for the next steps. A value
+	// starting with "$" is a path into the JSON result (e.g. "$.project.id"),
+	// otherwise it's a regular expression matched against the text content,
+	// capturing its first group (e.g. "with ID (\d+)").
+	Capture map[string]string `yaml:"capture"`
+	// Always runs the step even if a previous step failed, e.g. to delete the
+	// entities created by the scenario.
+	Always bool `yaml:"always"`
+}
+
+// stepExpectation are the assertions on a tool result.
+type stepExpectation struct {
+	// Error is true when the tool is expected to fail.
+	Error        bool        `yaml:"error"`
+	TextContains string      `yaml:"text_contains"`
+	Checks       []pathCheck `yaml:"checks"`
+}
+
+// pathCheck is an assertion on a value of the JSON result.
+type pathCheck struct {
+	Path string `yaml:"path"`
+	// Equals compares the value, it's ignored when null.
+	Equals any `yaml:"equals"`
+	// Contains checks that a string contains a substring, or that a list
+	// contains an item.
+	Contains any    `yaml:"contains"`
+	Matches  string `yaml:"matches"`
+	Exists   *bool  `yaml:"exists"`
+	// Length checks the number of items of a list or an object, or the length
+	// of a string.
+	Length *int `yaml:"length"`
+}
+
+// loadScenario reads and validates a scenario file.
+func loadScenario(path string) (*scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	var s scenario
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario %s: %w", path, err)
+	}
+	s.file = path
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(s.Steps) == 0 {
+		return nil, fmt.Errorf("scenario %s has no steps", path)
+	}
+
+	var errs []error
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		if step.Tool == "" {
+			errs = append(errs, fmt.Errorf("step %d: missing tool", i+1))
+		}
+		if step.Name == "" {
+			step.Name = step.Tool
+		}
+		for j, check := range step.Expect.Checks {
+			if err := check.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("step %d: check %d: %w", i+1, j+1, err))
+			}
+		}
+		for name, expression := range step.Capture {
+			if strings.HasPrefix(expression, "$") {
+				continue
+			}
+			if _, err := regexp.Compile(expression); err != nil {
+				errs = append(errs, fmt.Errorf("step %d: capture %q: %w", i+1, name, err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// parseScenarioVariable parses a key=value variable given in the command line.
+// The value is decoded as a YAML scalar, as the variables of the scenario
+// files, so "42" is an integer.
+func parseScenarioVariable(pair string) (string, any, error) {
+	name, raw, ok := strings.Cut(pair, "=")
+	if !ok || !variableNamePattern.MatchString(name) {
+		return "", nil, fmt.Errorf("invalid variable %q, expected key=value", pair)
+	}
+	var value any
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil || value == nil {
+		return name, raw, nil
+	}
+	if !isScalar(value) {
+		// lists and objects are kept as text
+		return name, raw, nil
+	}
+	return name, value, nil
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c pathCheck) validate() error {
+	if !strings.HasPrefix(c.Path, "$") {
+		return fmt.Errorf("invalid path %q: must start with $", c.Path)
+	}
+	if c.Equals == nil && c.Contains == nil && c.Matches == "" && c.Exists == nil && c.Length == nil {
+		return fmt.Errorf("no assertion for path %q", c.Path)
+	}
+	if c.Matches != "" {
+		if _, err := regexp.Compile(c.Matches); err != nil {
+			return fmt.Errorf("invalid matches expression: %w", err)
+		}
+	}
+	return nil
+}
+
+// scenarioResult is the result of a scenario run, used for the reports.
+type scenarioResult struct {
+	Name     string
+	File     string
+	Passed   bool
+	Started  time.Time
+	Duration time.Duration
+	Steps    []stepResult
+}
+
+// stepResult is the result of a scenario step.
+type stepResult struct {
+	Name     string
+	Tool     string
+	Status   string
+	Duration time.Duration
+	Error    string
+	Captured map[string]any
+}
+
+// scenarioRunner runs the scenarios with an MCP client session, reporting the
+// progress to out.
+type scenarioRunner struct {
+	session *mcp.ClientSession
+	tools   map[string]*mcp.Tool
+	out     io.Writer
+}
+
+func newScenarioRunner(ctx context.Context, session *mcp.ClientSession, out io.Writer) (*scenarioRunner, error) {
+	r := &scenarioRunner{
+		session: session,
+		out:     out,
+	}
+	if err := r.loadTools(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// loadTools lists the tools of the MCP server, replacing the known ones.
+func (r *scenarioRunner) loadTools(ctx context.Context) error {
+	tools := make(map[string]*mcp.Tool)
+	for tool, err := range r.session.Tools(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		tools[tool.Name] = tool
+	}
+	r.tools = tools
+	return nil
+}
+
+// tool returns the tool of the given name. The tools are listed again when it
+// isn't known, as a previous step may have enabled it (e.g. the enable_toolset
+// tool of a server with dynamic toolsets).
+func (r *scenarioRunner) tool(ctx context.Context, name string) (*mcp.Tool, error) {
+	if tool, ok := r.tools[name]; ok {
+		return tool, nil
+	}
+	if err := r.loadTools(ctx); err != nil {
+		return nil, err
+	}
+	if tool, ok := r.tools[name]; ok {
+		return tool, nil
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
+
+// run runs the steps of a scenario in order. When a step fails the next ones
+// are skipped, except the ones that must always run. The given variables
+// override the ones of the scenario.
+func (r *scenarioRunner) run(ctx context.Context, s *scenario, overrides map[string]any) scenarioResult {
+	result := scenarioResult{
+		Name:    s.Name,
+		File:    s.file,
+		Passed:  true,
+		Started: time.Now(),
+	}
+	vars := make(map[string]any, len(s.Vars)+len(overrides))
+	for name, value := range s.Vars {
+		vars[name] = value
+	}
+	for name, value := range overrides {
+		vars[name] = value
+	}
+
+	_, _ = fmt.Fprintf(r.out, "Scenario: %s (%s)\n", s.Name, s.file)
+	for _, step := range s.Steps {
+		stepResult := stepResult{
+			Name: step.Name,
+			Tool: step.Tool,
+		}
+		if !result.Passed && !step.Always {
+			stepResult.Status = stepSkipped
+			result.Steps = append(result.Steps, stepResult)
+			_, _ = fmt.Fprintf(r.out, "  SKIP %s\n", step.Name)
+			continue
+		}
+
+		started := time.Now()
+		captured, err := r.runStep(ctx, step, vars)
+		stepResult.Duration = time.Since(started)
+		if err != nil {
+			result.Passed = false
+			stepResult.Status = stepFailed
+			stepResult.Error = err.Error()
+			_, _ = fmt.Fprintf(r.out, "  FAIL %s (%s)\n       %s\n", step.Name,
+				stepResult.Duration.Round(time.Millisecond), strings.ReplaceAll(err.Error(), "\n", "\n       "))
+		} else {
+			stepResult.Status = stepPassed
+			stepResult.Captured = captured
+			for name, value := range captured {
+				vars[name] = value
+			}
+			_, _ = fmt.Fprintf(r.out, "  PASS %s (%s)\n", step.Name, stepResult.Duration.Round(time.Millisecond))
+		}
+		result.Steps = append(result.Steps, stepResult)
+	}
+	result.Duration = time.Since(result.Started)
+	return result
+}
+
+// runStep calls the tool of a step and checks its result, returning the
+// captured variables.
+func (r *scenarioRunner) runStep(ctx context.Context, step scenarioStep, vars map[string]any) (map[string]any, error) {
+	tool, err := r.tool(ctx, step.Tool)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := decodeInputSchema(tool.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+	expanded, err := expandVariables(step.Args, vars)
+	if err != nil {
+		return nil, err
+	}
+	// the variables are strings when captured from the text content
+	arguments, err := coerceStrings(schema, schema, expanded)
+	if err != nil {
+		return nil, err
+	}
+	var toolArguments map[string]any
+	if arguments != nil {
+		toolArguments = arguments.(map[string]any)
+	}
+	if err := config.ValidateToolArguments(tool, toolArguments); err != nil {
+		return nil, err
+	}
+
+	result, err := r.session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      step.Tool,
+		Arguments: toolArguments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run tool: %w", err)
+	}
+	text := resultText(result)
+	switch {
+	case result.IsError && !step.Expect.Error:
+		return nil, fmt.Errorf("tool returned an error: %s", text)
+	case !result.IsError && step.Expect.Error:
+		return nil, errors.New("tool succeeded, but an error was expected")
+	}
+
+	var errs []error
+	if step.Expect.TextContains != "" {
+		expected, err := expandString(step.Expect.TextContains, vars)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(text, fmt.Sprint(expected)) {
+			errs = append(errs, fmt.Errorf("text doesn't contain %q: %s", expected, text))
+		}
+	}
+	value := resultValue(result)
+	for _, check := range step.Expect.Checks {
+		if err := check.run(value, vars); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	captured := make(map[string]any, len(step.Capture))
+	for name, expression := range step.Capture {
+		if captured[name], err = capture(expression, value, text); err != nil {
+			return nil, fmt.Errorf("failed to capture %q: %w", name, err)
+		}
+	}
+	return captured, nil
+}
+
+func (c pathCheck) run(value any, vars map[string]any) error {
+	if value == nil {
+		return fmt.Errorf("%s: the result has no JSON value", c.Path)
+	}
+	found, err := lookupPath(value, c.Path)
+	if c.Exists != nil {
+		if exists := err == nil; exists != *c.Exists {
+			return fmt.Errorf("%s: exists is %t, want %t", c.Path, exists, *c.Exists)
+		}
+		if !*c.Exists {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Equals != nil {
+		expected, err := expandVariables(c.Equals, vars)
+		if err != nil {
+			return err
+		}
+		// the values captured from the text are strings, so "42" equals 42
+		text, isText := expected.(string)
+		if !jsonEqual(found, expected) && (!isText || !isScalar(found) || valueString(found) != text) {
+			return fmt.Errorf("%s: got %s, want %s", c.Path, jsonString(found), jsonString(expected))
+		}
+	}
+	if c.Contains != nil {
+		expected, err := expandVariables(c.Contains, vars)
+		if err != nil {
+			return err
+		}
+		if !containsValue(found, expected) {
+			return fmt.Errorf("%s: %s doesn't contain %s", c.Path, jsonString(found), jsonString(expected))
+		}
+	}
+	if c.Matches != "" {
+		if !regexp.MustCompile(c.Matches).MatchString(valueString(found)) {
+			return fmt.Errorf("%s: %s doesn't match %q", c.Path, jsonString(found), c.Matches)
+		}
+	}
+	if c.Length != nil {
+		var length int
+		switch v := found.(type) {
+		case []any:
+			length = len(v)
+		case map[string]any:
+			length = len(v)
+		case string:
+			length = len([]rune(v))
+		default:
+			return fmt.Errorf("%s: %s has no length", c.Path, jsonString(found))
+		}
+		if length != *c.Length {
+			return fmt.Errorf("%s: length is %d, want %d", c.Path, length, *c.Length)
+		}
+	}
+	return nil
+}
+
+// capture returns the value of a capture expression: a path into the JSON
+// value, or a regular expression matched against the text.
+func capture(expression string, value any, text string) (any, error) {
+	if strings.HasPrefix(expression, "$") {
+		if value == nil {
+			return nil, errors.New("the result has no JSON value")
+		}
+		return lookupPath(value, expression)
+	}
+	match := regexp.MustCompile(expression).FindStringSubmatch(text)
+	switch {
+	case match == nil:
+		return nil, fmt.Errorf("%q doesn't match the text: %s", expression, text)
+	case len(match) > 1:
+		return match[1], nil
+	default:
+		return match[0], nil
+	}
+}
+
+// expandVariables replaces the variable references in the strings of a value
+// decoded from YAML. A string that only references a variable is replaced by
+// its value, keeping its type (e.g. an integer ID).
+func expandVariables(value any, vars map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return expandString(v, vars)
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, item := range v {
+			var err error
+			if expanded[key], err = expandVariables(item, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []any:
+		expanded := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if expanded[i], err = expandVariables(item, vars); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	default:
+		return value, nil
+	}
+}
+
+func expandString(text string, vars map[string]any) (any, error) {
+	if match := scenarioVariablePattern.FindStringSubmatch(text); match != nil && match[0] == text {
+		value, ok := vars[match[1]]
+		if !ok {
+			return nil, fmt.Errorf("undefined variable %q", match[1])
+		}
+		return value, nil
+	}
+	var missing []string
+	expanded := scenarioVariablePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		name := scenarioVariablePattern.FindStringSubmatch(reference)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return valueString(value)
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("undefined variables: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// coerceStrings converts the strings of the arguments to the types declared by
+// the input schema, as with the -arg flags.
+func coerceStrings(root, schema *jsonschema.Schema, value any) (any, error) {
+	schema = resolveSchema(root, schema)
+	switch v := value.(type) {
+	case string:
+		if kind := primaryType(schema); kind == "" || (kind == "string" && schema.Format == "") {
+			return v, nil
+		}
+		return coerceArgument(root, schema, v)
+	case map[string]any:
+		coerced := make(map[string]any, len(v))
+		for key, item := range v {
+			var property *jsonschema.Schema
+			if schema != nil {
+				if property = schema.Properties[key]; property == nil {
+					property = schema.AdditionalProperties
+				}
+			}
+			var err error
+			if coerced[key], err = coerceStrings(root, property, item); err != nil {
+				return nil, fmt.Errorf("invalid argument %q: %w", key, err)
+			}
+		}
+		return coerced, nil
+	case []any:
+		var items *jsonschema.Schema
+		if schema != nil {
+			items = schema.Items
+		}
+		coerced := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if coerced[i], err = coerceStrings(root, items, item); err != nil {
+				return nil, err
+			}
+		}
+		return coerced, nil
+	default:
+		return value, nil
+	}
+}
+
+// jsonEqual compares two values by their JSON representation, so the numbers
+// decoded from YAML and JSON are equal.
+func jsonEqual(a, b any) bool {
+	a, errA := jsonValue(a)
+	b, errB := jsonValue(b)
+	return errA == nil && errB == nil && reflect.DeepEqual(a, b)
+}
+
+func containsValue(container, item any) bool {
+	switch v := container.(type) {
+	case string:
+		return strings.Contains(v, valueString(item))
+	case []any:
+		for _, element := range v {
+			if jsonEqual(element, item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valueString returns a string as it is, and the other values as JSON.
+func valueString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return jsonString(value)
+}
+
+func jsonString(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
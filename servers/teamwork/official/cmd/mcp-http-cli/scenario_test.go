@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lifecycleScenario = `
+name: Project lifecycle
+vars:
+  prefix: smoke
+steps:
+  - name: Create project
+    tool: create_project
+    args:
+      name: ${prefix}-project
+      tags: [a, "${prefix}"]
+    expect:
+      checks:
+        - path: $.project.name
+          equals: smoke-project
+        - path: $.project.tags
+          contains: smoke
+          length: 2
+        - path: $.project.missing
+          exists: false
+    capture:
+      project_id: $.project.id
+  - name: Get project
+    tool: get_project
+    args:
+      id: ${project_id}
+    expect:
+      text_contains: '"id":${project_id}'
+      checks:
+        - path: $.project.id
+          equals: ${expected_id}
+        - path: $.project.id
+          equals: "42"
+  - name: Get unknown project
+    tool: get_project
+    args:
+      id: 1
+    expect:
+      error: true
+  - name: Delete project
+    tool: delete_project
+    always: true
+    args:
+      id: ${project_id}
+    capture:
+      deleted_id: 'Project (\d+) deleted'
+  - name: Delete deleted project
+    tool: delete_project
+    args:
+      # captured from the text, coerced to an integer
+      id: ${deleted_id}
+`
+
+func writeScenario(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScenarioRunner(t *testing.T) {
+	tests := []struct {
+		name       string
+		overrides  map[string]any
+		wantPassed bool
+		wantStatus []string
+		wantErrors []string
+	}{{
+		name:       "passed",
+		overrides:  map[string]any{"expected_id": 42},
+		wantPassed: true,
+		wantStatus: []string{stepPassed, stepPassed, stepPassed, stepPassed, stepPassed},
+	}, {
+		name:       "failed check",
+		overrides:  map[string]any{"expected_id": "7"},
+		wantStatus: []string{stepPassed, stepFailed, stepSkipped, stepPassed, stepSkipped},
+		wantErrors: []string{"", `$.project.id: got 42, want "7"`, "", "", ""},
+	}, {
+		name:       "undefined variable",
+		wantStatus: []string{stepPassed, stepFailed, stepSkipped, stepPassed, stepSkipped},
+		wantErrors: []string{"", `undefined variable "expected_id"`, "", "", ""},
+	}}
+
+	s, err := loadScenario(writeScenario(t, lifecycleScenario))
+	if err != nil {
+		t.Fatalf("failed to load scenario: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			runner, err := newScenarioRunner(context.Background(), newTestSession(t), &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := runner.run(context.Background(), s, tt.overrides)
+			if result.Passed != tt.wantPassed {
+				t.Errorf("unexpected passed %t, want %t\n%s", result.Passed, tt.wantPassed, out.String())
+			}
+			if len(result.Steps) != len(tt.wantStatus) {
+				t.Fatalf("unexpected %d steps, want %d", len(result.Steps), len(tt.wantStatus))
+			}
+			for i, step := range result.Steps {
+				if step.Status != tt.wantStatus[i] {
+					t.Errorf("step %q: unexpected status %q, want %q: %s", step.Name, step.Status, tt.wantStatus[i],
+						step.Error)
+				}
+				if tt.wantErrors != nil && !strings.Contains(step.Error, tt.wantErrors[i]) {
+					t.Errorf("step %q: unexpected error %q, want %q", step.Name, step.Error, tt.wantErrors[i])
+				}
+			}
+			if captured := result.Steps[0].Captured["project_id"]; captured != float64(42) {
+				t.Errorf("unexpected captured project_id %v", captured)
+			}
+		})
+	}
+}
+
+func TestScenarioRunnerEnabledTools(t *testing.T) {
+	s, err := loadScenario(writeScenario(t, `
+name: Enabled tools
+steps:
+  - name: Enable archive
+    tool: enable_archive
+  - name: Archive project
+    tool: archive_project
+    args:
+      id: 42
+    expect:
+      text_contains: Project 42 archived
+  - name: Unknown tool
+    tool: restore_project
+`))
+	if err != nil {
+		t.Fatalf("failed to load scenario: %v", err)
+	}
+
+	var out bytes.Buffer
+	runner, err := newScenarioRunner(context.Background(), newTestSession(t), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := runner.run(context.Background(), s, nil)
+
+	wantStatus := []string{stepPassed, stepPassed, stepFailed}
+	for i, step := range result.Steps {
+		if step.Status != wantStatus[i] {
+			t.Errorf("step %q: unexpected status %q, want %q: %s", step.Name, step.Status, wantStatus[i], step.Error)
+		}
+	}
+	if err := result.Steps[2].Error; !strings.Contains(err, `unknown tool "restore_project"`) {
+		t.Errorf("unexpected error %q", err)
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{{
+		name:    "unknown field",
+		content: "steps:\n  - tool: get_project\n    argz: {}\n",
+		wantErr: "field argz not found",
+	}, {
+		name:    "no steps",
+		content: "name: empty\n",
+		wantErr: "has no steps",
+	}, {
+		name: "invalid steps",
+		content: "steps:\n  - name: missing tool\n" +
+			"  - tool: get_project\n    expect:\n      checks:\n        - path: project.id\n          exists: true\n" +
+			"        - path: $.project.id\n" +
+			"  - tool: delete_project\n    capture:\n      id: '('\n",
+		wantErr: "step 1: missing tool\nstep 2: check 1: invalid path \"project.id\": must start with $\n" +
+			"step 2: check 2: no assertion for path \"$.project.id\"\nstep 3: capture \"id\"",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadScenario(writeScenario(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("unexpected error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExampleScenarios(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("scenarios", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no example scenarios")
+	}
+	for _, path := range paths {
+		if _, err := loadScenario(path); err != nil {
+			t.Errorf("invalid example scenario: %v", err)
+		}
+	}
+}
+
+func TestParseScenarioVariable(t *testing.T) {
+	tests := []struct {
+		pair      string
+		wantName  string
+		wantValue any
+		wantErr   bool
+	}{
+		{pair: "project_id=42", wantName: "project_id", wantValue: 42},
+		{pair: "prefix=smoke", wantName: "prefix", wantValue: "smoke"},
+		{pair: "archived=true", wantName: "archived", wantValue: true},
+		{pair: "empty=", wantName: "empty", wantValue: ""},
+		{pair: "tags=[a, b]", wantName: "tags", wantValue: "[a, b]"},
+		{pair: "prefix", wantErr: true},
+		{pair: "project-id=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pair, func(t *testing.T) {
+			name, value, err := parseScenarioVariable(tt.pair)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v, want error %t", err, tt.wantErr)
+			}
+			if name != tt.wantName || value != tt.wantValue {
+				t.Errorf("unexpected variable %s=%#v, want %s=%#v", name, value, tt.wantName, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestReports(t *testing.T) {
+	results := []scenarioResult{{
+		Name: "Project lifecycle",
+		File: "lifecycle.yaml",
+		Steps: []stepResult{
+			{Name: "Create project", Tool: "create_project", Status: stepPassed,
+				Captured: map[string]any{"project_id": 42}},
+			{Name: "Get project", Tool: "get_project", Status: stepFailed, Error: "$.id: got 1, want 2\n$.name: got a"},
+			{Name: "Delete project", Tool: "delete_project", Status: stepSkipped},
+		},
+	}}
+
+	var junit bytes.Buffer
+	if err := writeJUnitReport(&junit, results); err != nil {
+		t.Fatalf("failed to write JUnit report: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid JUnit report: %v\n%s", err, junit.String())
+	}
+	if suites.Tests != 3 || suites.Failures != 1 || suites.Skipped != 1 || len(suites.Suites) != 1 {
+		t.Errorf("unexpected JUnit counts:\n%s", junit.String())
+	}
+	if failure := suites.Suites[0].Cases[1].Failure; failure == nil || failure.Message != "$.id: got 1, want 2" {
+		t.Errorf("unexpected JUnit failure %+v", failure)
+	}
+
+	var report bytes.Buffer
+	if err := writeJSONReport(&report, results); err != nil {
+		t.Fatalf("failed to write JSON report: %v", err)
+	}
+	var decoded jsonReport
+	if err := json.Unmarshal(report.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	if decoded.Passed || len(decoded.Scenarios) != 1 || len(decoded.Scenarios[0].Steps) != 3 {
+		t.Errorf("unexpected JSON report:\n%s", report.String())
+	}
+	if status := decoded.Scenarios[0].Steps[2].Status; status != stepSkipped {
+		t.Errorf("unexpected JSON step status %q", status)
+	}
+}
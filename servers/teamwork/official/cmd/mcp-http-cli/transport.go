@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// List of transports used to connect to the MCP server.
+const (
+	// transportStreamable is the streamable HTTP transport, served by
+	// cmd/mcp-http.
+	transportStreamable = "streamable"
+	// transportSSE is the legacy HTTP with Server-Sent Events transport.
+	transportSSE = "sse"
+	// transportStdio launches the MCP server as a subprocess (e.g.
+	// cmd/mcp-stdio), communicating through its standard input and output.
+	transportStdio = "stdio"
+)
+
+// transportOptions contains the options to create the transport.
+type transportOptions struct {
+	// url is the endpoint of the HTTP transports.
+	url string
+	// token is the bearer token. The HTTP transports send it in the
+	// Authorization header, and the stdio transport in the TW_MCP_BEARER_TOKEN
+	// environment variable of the subprocess.
+	token string
+	// command is the command line of the MCP server launched by the stdio
+	// transport. Quotes group the text with spaces, e.g. a path or an argument.
+	command string
+	// httpClient is the client of the HTTP transports.
+	httpClient *http.Client
+}
+
+func newTransport(kind string, options transportOptions) (mcp.Transport, error) {
+	switch strings.ToLower(kind) {
+	case transportStreamable, transportSSE:
+		if options.url == "" {
+			return nil, errors.New("MCP URL is required")
+		}
+		httpClient := options.httpClient
+		if options.token != "" {
+			client := *httpClient
+			client.Transport = newAuthRoundTripper(options.token, client.Transport)
+			httpClient = &client
+		}
+		if strings.EqualFold(kind, transportSSE) {
+			return &mcp.SSEClientTransport{
+				Endpoint:   options.url,
+				HTTPClient: httpClient,
+			}, nil
+		}
+		return &mcp.StreamableClientTransport{
+			Endpoint:   options.url,
+			HTTPClient: httpClient,
+		}, nil
+
+	case transportStdio:
+		args, err := splitFields(options.command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MCP server command: %w", err)
+		}
+		if len(args) == 0 {
+			return nil, errors.New("MCP server command is required")
+		}
+		cmd := exec.Command(args[0], args[1:]...) //nolint:gosec
+		cmd.Env = os.Environ()
+		if options.token != "" {
+			cmd.Env = append(cmd.Env, "TW_MCP_BEARER_TOKEN="+options.token)
+		}
+		// the server logs are written to the standard error
+		cmd.Stderr = os.Stderr
+		return &mcp.CommandTransport{Command: cmd}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown transport %q, available transports: %s, %s, %s",
+			kind, transportStreamable, transportSSE, transportStdio)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// testServerEnv makes the test binary run as a STDIO MCP server, to test the
+// stdio transport.
+const testServerEnv = "MCP_HTTP_CLI_TEST_SERVER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testServerEnv) != "" {
+		if err := newTestServer().Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// newTestServer creates an MCP server with a tool returning the
+// TW_MCP_BEARER_TOKEN environment variable.
+func newTestServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+	server.AddTool(&mcp.Tool{
+		Name:        "whoami",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: os.Getenv("TW_MCP_BEARER_TOKEN")}},
+		}, nil
+	})
+	return server
+}
+
+// requireToken rejects the HTTP requests without the given bearer token.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestNewTransport(t *testing.T) {
+	getServer := func(*http.Request) *mcp.Server { return newTestServer() }
+	streamableServer := httptest.NewServer(requireToken("streamable-token",
+		mcp.NewStreamableHTTPHandler(getServer, nil)))
+	defer streamableServer.Close()
+	sseServer := httptest.NewServer(requireToken("sse-token", mcp.NewSSEHandler(getServer, nil)))
+	defer sseServer.Close()
+	// the HTTP servers run in the test process
+	t.Setenv("TW_MCP_BEARER_TOKEN", "")
+	// the test binary, from a path with spaces
+	spacedCommand := filepath.Join(t.TempDir(), "mcp server")
+	if err := os.Symlink(os.Args[0], spacedCommand); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		kind    string
+		options transportOptions
+		env     map[string]string
+		want    string
+		wantErr bool
+		// wantConnectErr is set when the transport is created, but the server
+		// rejects the connection
+		wantConnectErr bool
+	}{{
+		name: "streamable",
+		kind: transportStreamable,
+		options: transportOptions{
+			url:   streamableServer.URL,
+			token: "streamable-token",
+		},
+	}, {
+		name: "sse",
+		kind: "SSE",
+		options: transportOptions{
+			url:   sseServer.URL,
+			token: "sse-token",
+		},
+	}, {
+		name: "invalid token",
+		kind: transportStreamable,
+		options: transportOptions{
+			url:   streamableServer.URL,
+			token: "sse-token",
+		},
+		wantConnectErr: true,
+	}, {
+		name: "stdio",
+		kind: transportStdio,
+		options: transportOptions{
+			command: os.Args[0],
+			token:   "stdio-token",
+		},
+		env:  map[string]string{testServerEnv: "1"},
+		want: "stdio-token",
+	}, {
+		name: "stdio quoted command",
+		kind: transportStdio,
+		options: transportOptions{
+			command: `"` + spacedCommand + `" -config '/path with space.yaml'`,
+			token:   "stdio-token",
+		},
+		env:  map[string]string{testServerEnv: "1"},
+		want: "stdio-token",
+	}, {
+		name:    "unterminated quote",
+		kind:    transportStdio,
+		options: transportOptions{command: `"` + spacedCommand},
+		wantErr: true,
+	}, {
+		name:    "missing URL",
+		kind:    transportStreamable,
+		wantErr: true,
+	}, {
+		name:    "missing command",
+		kind:    transportStdio,
+		wantErr: true,
+	}, {
+		name:    "unknown transport",
+		kind:    "websocket",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			tt.options.httpClient = new(http.Client)
+			transport, err := newTransport(tt.kind, tt.options)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+			session, err := client.Connect(t.Context(), transport, nil)
+			if tt.wantConnectErr {
+				if err == nil {
+					_ = session.Close()
+					t.Error("expected a connection error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to connect: %v", err)
+			}
+			defer session.Close() //nolint:errcheck
+
+			result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "whoami"})
+			if err != nil {
+				t.Fatalf("failed to call tool: %v", err)
+			}
+			if got := result.Content[0].(*mcp.TextContent).Text; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
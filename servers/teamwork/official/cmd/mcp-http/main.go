@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log/slog"
@@ -22,46 +23,162 @@ import (
 	"github.com/teamwork/mcp/internal/auth"
 	"github.com/teamwork/mcp/internal/config"
 	"github.com/teamwork/mcp/internal/request"
-	"github.com/teamwork/mcp/internal/toolsets"
-	"github.com/teamwork/mcp/internal/twdesk"
-	"github.com/teamwork/mcp/internal/twprojects"
+	"github.com/teamwork/mcp/internal/streamable"
 	"github.com/teamwork/twapi-go-sdk/session"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-var reBearerToken = regexp.MustCompile(`^Bearer (.+)$`)
+var (
+	reBearerToken = regexp.MustCompile(`^Bearer (.+)$`)
+
+	allowDelete  bool
+	includeTools []string
+	excludeTools []string
+	stateful     bool
+	configFile   string
+	printConfig  bool
+)
 
 func main() {
 	defer handleExit()
 
-	resources, teardown := config.Load(os.Stdout)
+	flag.BoolVar(&allowDelete, "allow-delete", false, "Expose the tools that delete data")
+	flag.Func("tools", "Comma-separated list of glob patterns of the tools to expose (e.g. twprojects-list_*)",
+		func(value string) error {
+			includeTools = config.SplitList(value)
+			return nil
+		})
+	flag.Func("exclude-tools", "Comma-separated list of glob patterns of the tools to hide",
+		func(value string) error {
+			excludeTools = config.SplitList(value)
+			return nil
+		})
+	flag.BoolVar(&stateful, "stateful", false,
+		"Keep the sessions between requests, allowing notifications, progress and resumable streams")
+	flag.StringVar(&configFile, "config", "", "Path to a YAML or TOML config file (defaults to TW_MCP_CONFIG)")
+	flag.BoolVar(&printConfig, "print-config", false, "Print the effective configuration, with secrets masked, and exit")
+	flag.Parse()
+
+	file, err := config.ReadFile(configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+
+	// the configuration is printed before loading the resources, so no secret
+	// command runs and no audit sink or tracer is started
+	if printConfig {
+		resources := config.NewResources(file)
+		applyFlags(&resources)
+		if err := config.WriteConfig(os.Stdout, resources); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			exit(exitCodeSetupFailure)
+		}
+		exit(exitCodeOK)
+	}
+
+	resources, teardown, err := config.Load(os.Stdout, file)
+	if err != nil {
+		resources.Logger().Error("failed to load configuration",
+			slog.String("error", err.Error()),
+		)
+		exit(exitCodeSetupFailure)
+	}
 	defer teardown()
 
+	applyFlags(&resources)
+
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	mcpServer, err := newMCPServer(resources)
+	servers, err := newServerCache(resources)
+	if err == nil {
+		// the default server is created at startup to detect configuration errors
+		_, err = servers.get(servers.defaults)
+	}
 	if err != nil {
 		resources.Logger().Error("failed to create MCP server",
 			slog.String("error", err.Error()),
 		)
 		exit(exitCodeSetupFailure)
 	}
-	mcpHTTPServer := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
-		return mcpServer
-	}, &mcp.StreamableHTTPOptions{
-		Stateless: true,
-	})
+
+	var mcpHTTPServer http.Handler
+	var sessionHandler *streamable.Handler
+	if resources.Info.Sessions.Stateful {
+		eventStore, err := streamable.NewEventStore(
+			resources.Info.Sessions.EventStore,
+			resources.Info.Sessions.EventStorePath,
+		)
+		if err != nil {
+			resources.Logger().Error("failed to create session event store",
+				slog.String("error", err.Error()),
+			)
+			exit(exitCodeSetupFailure)
+		}
+		defer func() {
+			if err := eventStore.Close(); err != nil {
+				resources.Logger().Error("failed to close session event store",
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+		sessionHandler = streamable.NewHandler(resources.Logger(), servers.getServer, eventStore,
+			resources.Info.Sessions.TTL)
+		mcpHTTPServer = sessionHandler
+	} else {
+		mcpHTTPServer = mcp.NewStreamableHTTPHandler(servers.getServer, &mcp.StreamableHTTPOptions{
+			Stateless: true,
+		})
+	}
 
 	mux := newRouter(resources)
-	mux.Handle("/", mcpHTTPServer)
+	mux.Handle("/", servers.middleware(mcpHTTPServer))
+
+	bearerInfoCache := auth.NewBearerInfoCache(
+		resources.Info.AuthCache.Size,
+		resources.Info.AuthCache.TTL,
+		resources.Info.AuthCache.NegativeTTL,
+		func(ctx context.Context, token string) (*auth.BearerInfo, error) {
+			return auth.GetBearerInfo(ctx, resources, token)
+		},
+	)
+
+	registerMetrics(resources, bearerInfoCache, sessionHandler, servers.rateLimiter)
+	var metricsServer *http.Server
+	if resources.Info.Metrics.Enabled {
+		if resources.Info.Metrics.Address == "" {
+			// served by the public listener, so the metrics require authentication
+			mux.Handle("/metrics", resources.Metrics().Handler())
+		} else {
+			metricsServer = resources.Metrics().NewServer(resources.Info.Metrics.Address)
+			go func() {
+				resources.Logger().Info("starting metrics server",
+					slog.String("address", resources.Info.Metrics.Address),
+				)
+				if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					resources.Logger().Error("failed to start metrics server",
+						slog.String("address", resources.Info.Metrics.Address),
+						slog.String("error", err.Error()),
+					)
+				}
+			}()
+		}
+	}
 
 	httpServer := &http.Server{
 		Addr:    resources.Info.ServerAddress,
-		Handler: addRouterMiddlewares(resources, mux),
+		Handler: addRouterMiddlewares(resources, bearerInfoCache, mux),
+	}
+
+	if sessionHandler != nil {
+		// the open streams would block the shutdown until the timeout
+		httpServer.RegisterOnShutdown(sessionHandler.Close)
 	}
 
 	resources.Logger().Info("starting http server",
 		slog.String("address", resources.Info.ServerAddress),
+		slog.Bool("stateful", resources.Info.Sessions.Stateful),
 	)
 	go func() {
 		if err := httpServer.ListenAndServe(); err != nil {
@@ -89,21 +206,66 @@ func main() {
 			slog.String("error", err.Error()),
 		)
 	}
-	resources.Logger().Info("server stopped")
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			resources.Logger().Error("metrics server shutdown failed",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	stats := bearerInfoCache.Stats()
+	resources.Logger().Info("server stopped",
+		slog.Uint64("auth_cache_hits", stats.Hits),
+		slog.Uint64("auth_cache_misses", stats.Misses),
+		slog.Uint64("auth_cache_evictions", stats.Evictions),
+	)
 }
 
-func newMCPServer(resources config.Resources) (*mcp.Server, error) {
-	projectsGroup := twprojects.DefaultToolsetGroup(false, false, resources.TeamworkEngine())
-	if err := projectsGroup.EnableToolsets(toolsets.MethodAll); err != nil {
-		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
-	}
+// applyFlags overrides the configuration with the command line flags, which
+// take precedence over the environment variables.
+func applyFlags(resources *config.Resources) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "allow-delete":
+			resources.Info.Tools.AllowDelete = allowDelete
+		case "tools":
+			resources.Info.Tools.Include = includeTools
+		case "exclude-tools":
+			resources.Info.Tools.Exclude = excludeTools
+		case "stateful":
+			resources.Info.Sessions.Stateful = stateful
+		}
+	})
+}
 
-	deskGroup := twdesk.DefaultToolsetGroup(resources.DeskClient())
-	if err := deskGroup.EnableToolsets(toolsets.MethodAll); err != nil {
-		return nil, fmt.Errorf("failed to enable desk toolsets: %w", err)
-	}
+// registerMetrics exposes the authentication cache statistics, the active
+// sessions and the rate limited tool calls in the metrics.
+func registerMetrics(
+	resources config.Resources,
+	bearerInfoCache *auth.BearerInfoCache,
+	sessionHandler *streamable.Handler,
+	limiter *rateLimiter,
+) {
+	m := resources.Metrics()
+	m.CounterFunc("auth_cache_hits_total", "Number of bearer token lookups served from the cache.",
+		func() float64 { return float64(bearerInfoCache.Stats().Hits) })
+	m.CounterFunc("auth_cache_misses_total", "Number of bearer token lookups sent to the Teamwork API.",
+		func() float64 { return float64(bearerInfoCache.Stats().Misses) })
+	m.CounterFunc("auth_cache_evictions_total", "Number of bearer tokens evicted from the full cache.",
+		func() float64 { return float64(bearerInfoCache.Stats().Evictions) })
+	m.GaugeFunc("auth_cache_entries", "Number of bearer tokens in the cache.",
+		func() float64 { return float64(bearerInfoCache.Stats().Size) })
+
+	m.GaugeFunc("active_sessions", "Number of active MCP sessions (always zero in stateless mode).",
+		func() float64 {
+			if sessionHandler == nil {
+				return 0
+			}
+			return float64(sessionHandler.SessionCount())
+		})
 
-	return config.NewMCPServer(resources, projectsGroup, deskGroup), nil
+	m.CounterFunc("rate_limited_total", "Number of tool calls rejected by the rate limiter.",
+		func() float64 { return float64(limiter.Rejected()) })
 }
 
 func newRouter(resources config.Resources) *http.ServeMux {
@@ -141,8 +303,13 @@ func newRouter(resources config.Resources) *http.ServeMux {
 	return mux
 }
 
-func addRouterMiddlewares(resources config.Resources, mux *http.ServeMux) http.Handler {
-	return sentryMiddleware(resources, requestInfoMiddleware(tracerMiddleware(resources, authMiddleware(resources, mux))))
+func addRouterMiddlewares(
+	resources config.Resources,
+	bearerInfoCache *auth.BearerInfoCache,
+	mux *http.ServeMux,
+) http.Handler {
+	return sentryMiddleware(resources, requestInfoMiddleware(tracerMiddleware(resources,
+		authMiddleware(resources, bearerInfoCache, mux))))
 }
 
 func sentryMiddleware(resources config.Resources, next http.Handler) http.Handler {
@@ -164,26 +331,39 @@ func requestInfoMiddleware(next http.Handler) http.Handler {
 }
 
 func tracerMiddleware(resources config.Resources, next http.Handler) http.Handler {
-	if !resources.Info.DatadogAPM.Enabled {
-		return next
+	ignoreRequest := func(req *http.Request) bool {
+		if req.URL.Path == "/api/health" || req.URL.Path == "/metrics" {
+			return true
+		}
+		if strings.HasPrefix(req.URL.Path, "/.well-known") {
+			return true
+		}
+		return false
 	}
-	return ddhttp.WrapHandler(next, resources.Info.DatadogAPM.Service, "http.request",
-		ddhttp.WithResourceNamer(func(req *http.Request) string {
-			return fmt.Sprintf("%s_%s", req.Method, req.URL.Path)
-		}),
-		ddhttp.WithIgnoreRequest(func(req *http.Request) bool {
-			if req.URL.Path == "/api/health" {
-				return true
-			}
-			if strings.HasPrefix(req.URL.Path, "/.well-known") {
-				return true
-			}
-			return false
-		}),
-	)
+	resourceName := func(req *http.Request) string {
+		return fmt.Sprintf("%s_%s", req.Method, req.URL.Path)
+	}
+
+	if resources.Info.OpenTelemetry.Enabled {
+		next = otelhttp.NewHandler(next, "http.request",
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return resourceName(req)
+			}),
+			otelhttp.WithFilter(func(req *http.Request) bool {
+				return !ignoreRequest(req)
+			}),
+		)
+	}
+	if resources.Info.DatadogAPM.Enabled {
+		next = ddhttp.WrapHandler(next, resources.Info.DatadogAPM.Service, "http.request",
+			ddhttp.WithResourceNamer(resourceName),
+			ddhttp.WithIgnoreRequest(ignoreRequest),
+		)
+	}
+	return next
 }
 
-func authMiddleware(resources config.Resources, next http.Handler) http.Handler {
+func authMiddleware(resources config.Resources, bearerInfoCache *auth.BearerInfoCache, next http.Handler) http.Handler {
 	whitelistEndpoints := map[string][]string{
 		// health checks don't require authentication
 		"/api/health": {http.MethodGet, http.MethodOptions},
@@ -242,7 +422,7 @@ func authMiddleware(resources config.Resources, next http.Handler) http.Handler
 		}
 		bearerToken := matches[1]
 
-		info, err := auth.GetBearerInfo(r.Context(), resources, bearerToken)
+		info, err := bearerInfoCache.Get(r.Context(), bearerToken)
 		if err == auth.ErrBearerInfoUnauthorized {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
@@ -267,6 +447,8 @@ func authMiddleware(resources config.Resources, next http.Handler) http.Handler
 		ctx = config.WithCustomerURL(ctx, info.URL)
 		// inject scopes
 		ctx = config.WithScopes(ctx, info.Meta.Scopes)
+		// inject user, for the audit log
+		ctx = config.WithUser(ctx, info.UserID, info.InstallationID)
 		// inject session
 		ctx = session.WithBearerTokenContext(ctx, session.NewBearerToken(bearerToken, info.URL))
 
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+	"github.com/teamwork/mcp/internal/ratelimit"
+)
+
+// rateLimiter limits the tool calls of each user and installation, so a
+// runaway agent can't exhaust the Teamwork API quota of the installation.
+// Read-only and write tools have separate budgets.
+type rateLimiter struct {
+	userRead          *ratelimit.Limiter
+	userWrite         *ratelimit.Limiter
+	installationRead  *ratelimit.Limiter
+	installationWrite *ratelimit.Limiter
+
+	rejected atomic.Uint64
+}
+
+// newRateLimiter creates the rate limiter of the tool calls. It returns nil
+// when the rate limiting is disabled.
+func newRateLimiter(resources config.Resources) *rateLimiter {
+	if !resources.Info.RateLimit.Enabled {
+		return nil
+	}
+	return &rateLimiter{
+		userRead:          ratelimit.NewLimiter(resources.Info.RateLimit.UserRead),
+		userWrite:         ratelimit.NewLimiter(resources.Info.RateLimit.UserWrite),
+		installationRead:  ratelimit.NewLimiter(resources.Info.RateLimit.InstallationRead),
+		installationWrite: ratelimit.NewLimiter(resources.Info.RateLimit.InstallationWrite),
+	}
+}
+
+// middleware rejects the tool calls over the limits of the user or the
+// installation with a JSON-RPC error, containing the number of seconds to wait
+// before retrying in the "retryAfter" data field. Unknown tools use the write
+// budget, as they can't be proven to be read-only.
+func (l *rateLimiter) middleware(tools map[string]*mcp.Tool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			userID, installationID, ok := config.UserFromContext(ctx)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			userLimiter, installationLimiter := l.userWrite, l.installationWrite
+			if tool, known := tools[callToolParams.Name]; known && tool.Annotations != nil && tool.Annotations.ReadOnlyHint {
+				userLimiter, installationLimiter = l.userRead, l.installationRead
+			}
+			allowed, retryAfter := ratelimit.Allow(
+				ratelimit.Key{Limiter: userLimiter, Key: strconv.FormatInt(userID, 10)},
+				ratelimit.Key{Limiter: installationLimiter, Key: strconv.FormatInt(installationID, 10)},
+			)
+			if !allowed {
+				l.rejected.Add(1)
+				seconds := int64(math.Ceil(retryAfter.Seconds()))
+				return nil, config.NewJSONRPCError(config.JSONRPCErrorCodeRateLimited,
+					fmt.Sprintf("rate limit exceeded, retry after %s", time.Duration(seconds)*time.Second),
+					map[string]any{"retryAfter": seconds},
+				)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// Rejected returns the number of tool calls rejected by the rate limiter.
+func (l *rateLimiter) Rejected() uint64 {
+	if l == nil {
+		return 0
+	}
+	return l.rejected.Load()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var resources config.Resources
+	resources.Info.RateLimit.Enabled = true
+	resources.Info.RateLimit.UserRead = 2
+	resources.Info.RateLimit.UserWrite = 1
+	resources.Info.RateLimit.InstallationRead = 3
+
+	limiter := newRateLimiter(resources)
+	tools := map[string]*mcp.Tool{
+		"twprojects-get_task": {
+			Name:        "twprojects-get_task",
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+		},
+		"twprojects-create_task": {
+			Name:        "twprojects-create_task",
+			Annotations: &mcp.ToolAnnotations{},
+		},
+	}
+	handler := limiter.middleware(tools)(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+
+	tests := []struct {
+		name        string
+		userID      int64
+		tool        string
+		wantLimited bool
+	}{{
+		name:   "read",
+		userID: 1,
+		tool:   "twprojects-get_task",
+	}, {
+		name:   "write has a separate budget",
+		userID: 1,
+		tool:   "twprojects-create_task",
+	}, {
+		name:        "write over the user limit",
+		userID:      1,
+		tool:        "twprojects-create_task",
+		wantLimited: true,
+	}, {
+		name:        "unknown tool uses the write budget",
+		userID:      1,
+		tool:        "twprojects-unknown",
+		wantLimited: true,
+	}, {
+		name:   "read within the user limit",
+		userID: 1,
+		tool:   "twprojects-get_task",
+	}, {
+		name:        "read over the user limit",
+		userID:      1,
+		tool:        "twprojects-get_task",
+		wantLimited: true,
+	}, {
+		name:   "read of other user",
+		userID: 2,
+		tool:   "twprojects-get_task",
+	}, {
+		name:        "read over the installation limit",
+		userID:      3,
+		tool:        "twprojects-get_task",
+		wantLimited: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.WithUser(t.Context(), tt.userID, 100)
+			_, err := handler(ctx, "tools/call", &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{Name: tt.tool},
+			})
+			if !tt.wantLimited {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			expected := config.NewJSONRPCError(config.JSONRPCErrorCodeRateLimited, "", nil)
+			if !errors.Is(err, expected) {
+				t.Fatalf("expected rate limited error, got: %v", err)
+			}
+			// the error is encoded as a JSON-RPC error object
+			encoded, _ := json.Marshal(err)
+			var wireError struct {
+				Data struct {
+					RetryAfter int64 `json:"retryAfter"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(encoded, &wireError); err != nil || wireError.Data.RetryAfter <= 0 {
+				t.Errorf("unexpected error %s", encoded)
+			}
+		})
+	}
+
+	if rejected := limiter.Rejected(); rejected != 4 {
+		t.Errorf("unexpected %d rejected calls, want 4", rejected)
+	}
+
+	// other methods aren't limited
+	if _, err := handler(config.WithUser(t.Context(), 1, 100), "tools/list", &mcp.ListToolsRequest{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+	"github.com/teamwork/mcp/internal/toolsets"
+	"github.com/teamwork/mcp/internal/twdesk"
+	"github.com/teamwork/mcp/internal/twprojects"
+)
+
+// List of products that can be selected in the URL path.
+const (
+	productAll      = ""
+	productProjects = "projects"
+	productDesk     = "desk"
+)
+
+var errUnknownProduct = errors.New("unknown product")
+
+// serverSelection is the MCP server configuration picked by the client through
+// the URL, e.g. "/projects?read_only=true&toolsets=tasks,time".
+type serverSelection struct {
+	product  string
+	readOnly bool
+	toolsets []toolsets.Method
+}
+
+// parseServerSelection reads the product from the URL path, and the read-only
+// mode and toolsets from the query string. The toolsets of the defaults are
+// used when the request doesn't select any, and the read-only mode of the
+// defaults can't be disabled by the request.
+func parseServerSelection(r *http.Request, defaults serverSelection) (serverSelection, error) {
+	var selection serverSelection
+
+	switch product := strings.Trim(r.URL.Path, "/"); product {
+	case productAll, productProjects, productDesk:
+		selection.product = product
+	default:
+		return selection, fmt.Errorf("%w %q", errUnknownProduct, product)
+	}
+
+	query := r.URL.Query()
+	selection.readOnly = defaults.readOnly
+	if value := query.Get("read_only"); value != "" {
+		readOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return selection, fmt.Errorf("invalid read_only value %q", value)
+		}
+		selection.readOnly = selection.readOnly || readOnly
+	}
+
+	var methods []string
+	for _, value := range query["toolsets"] {
+		methods = append(methods, config.SplitList(value)...)
+	}
+	if len(methods) == 0 {
+		selection.toolsets = defaults.toolsets
+		return selection, nil
+	}
+	selection.toolsets = normalizeToolsets(methods)
+	return selection, nil
+}
+
+// normalizeToolsets converts the toolset names to methods, sorted and without
+// duplicates so equivalent selections share the same key. All toolsets are
+// enabled when the list is empty or contains "all".
+func normalizeToolsets(names []string) []toolsets.Method {
+	methods := make([]toolsets.Method, len(names))
+	for i, name := range names {
+		methods[i] = toolsets.Method(strings.ToLower(name))
+	}
+	if len(methods) == 0 || slices.Contains(methods, toolsets.MethodAll) {
+		return []toolsets.Method{toolsets.MethodAll}
+	}
+	slices.Sort(methods)
+	return slices.Compact(methods)
+}
+
+// key identifies the selection in the server cache.
+func (s serverSelection) key() string {
+	methods := make([]string, len(s.toolsets))
+	for i, method := range s.toolsets {
+		methods[i] = method.String()
+	}
+	return s.product + "|" + strconv.FormatBool(s.readOnly) + "|" + strings.Join(methods, ",")
+}
+
+// serverCacheSize is the maximum number of MCP servers kept by the server
+// cache.
+const serverCacheSize = 64
+
+// serverCache builds an MCP server for each selection, reusing it for the
+// following requests with the same selection. Each combination of product,
+// read-only mode and toolsets is a different selection, so the least recently
+// used servers are evicted when the cache is full. Sessions already created
+// keep using their server after it is evicted.
+//
+// The servers are built outside the lock, and concurrent requests with the
+// same selection wait for the same build.
+type serverCache struct {
+	resources   config.Resources
+	defaults    serverSelection
+	toolFilter  *toolsets.ToolFilter
+	rateLimiter *rateLimiter
+	size        int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type serverCacheEntry struct {
+	key    string
+	ready  chan struct{}
+	server *mcp.Server
+	err    error
+}
+
+func newServerCache(resources config.Resources) (*serverCache, error) {
+	toolFilter, err := toolsets.NewToolFilter(resources.Info.Tools.Include, resources.Info.Tools.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool filter: %w", err)
+	}
+	return &serverCache{
+		resources: resources,
+		defaults: serverSelection{
+			readOnly: resources.Info.Tools.ReadOnly,
+			toolsets: normalizeToolsets(resources.Info.Tools.Toolsets),
+		},
+		toolFilter:  toolFilter,
+		rateLimiter: newRateLimiter(resources),
+		size:        serverCacheSize,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}, nil
+}
+
+// get returns the MCP server of the selection, creating it when needed. Failed
+// builds aren't cached.
+func (c *serverCache) get(selection serverSelection) (*mcp.Server, error) {
+	key := selection.key()
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+		entry := element.Value.(*serverCacheEntry)
+		c.mu.Unlock()
+
+		<-entry.ready
+		return entry.server, entry.err
+	}
+	entry := &serverCacheEntry{key: key, ready: make(chan struct{})}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*serverCacheEntry).key)
+	}
+	c.mu.Unlock()
+
+	entry.server, entry.err = newMCPServer(c.resources, c.toolFilter, c.rateLimiter, selection)
+	close(entry.ready)
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if element, ok := c.entries[key]; ok && element.Value == entry {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.server, entry.err
+}
+
+// middleware resolves the MCP server of the request, rejecting invalid
+// selections before they reach the MCP handler.
+func (c *serverCache) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selection, err := parseServerSelection(r, c.defaults)
+		if errors.Is(err, errUnknownProduct) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		server, err := c.get(selection)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), serverContextKey{}, server)))
+	})
+}
+
+// getServer returns the MCP server resolved by the middleware.
+func (c *serverCache) getServer(r *http.Request) *mcp.Server {
+	server, _ := r.Context().Value(serverContextKey{}).(*mcp.Server)
+	return server
+}
+
+type serverContextKey struct{}
+
+func newMCPServer(
+	resources config.Resources,
+	toolFilter *toolsets.ToolFilter,
+	limiter *rateLimiter,
+	selection serverSelection,
+) (*mcp.Server, error) {
+	var groups []*toolsets.ToolsetGroup
+	if selection.product == productAll || selection.product == productProjects {
+		projectsGroup := twprojects.DefaultToolsetGroup(selection.readOnly, resources.Info.Tools.AllowDelete,
+			resources.TeamworkEngine())
+		projectsGroup.SetToolFilter(toolFilter)
+		groups = append(groups, projectsGroup)
+	}
+	if selection.product == productAll || selection.product == productDesk {
+		deskGroup := twdesk.DefaultToolsetGroup(selection.readOnly, resources.Info.Tools.AllowDelete,
+			resources.DeskClient(), resources.DeskAPIClient())
+		deskGroup.SetToolFilter(toolFilter)
+		groups = append(groups, deskGroup)
+	}
+	if err := toolsets.EnableToolsetsInGroups(selection.toolsets, groups...); err != nil {
+		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+	}
+
+	server := config.NewMCPServer(resources, groups...)
+	if limiter != nil {
+		// the rate limiter is the outermost middleware, rejecting the calls before
+		// any other processing
+		tools := make(map[string]*mcp.Tool)
+		for _, group := range groups {
+			for _, toolset := range group.Toolsets {
+				for _, toolWrapper := range toolset.GetAvailableTools() {
+					tools[toolWrapper.Tool.Name] = toolWrapper.Tool
+				}
+			}
+		}
+		server.AddReceivingMiddleware(limiter.middleware(tools))
+	}
+	return server, nil
+}
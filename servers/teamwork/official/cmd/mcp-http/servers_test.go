@@ -0,0 +1,281 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestParseServerSelection(t *testing.T) {
+	allToolsets := serverSelection{toolsets: []toolsets.Method{toolsets.MethodAll}}
+
+	tests := []struct {
+		name     string
+		target   string
+		defaults *serverSelection
+		want     serverSelection
+		wantErr  bool
+	}{{
+		name:   "default",
+		target: "/",
+		want:   serverSelection{toolsets: []toolsets.Method{toolsets.MethodAll}},
+	}, {
+		name:   "product",
+		target: "/desk/",
+		want:   serverSelection{product: productDesk, toolsets: []toolsets.Method{toolsets.MethodAll}},
+	}, {
+		name:   "read-only toolsets",
+		target: "/projects?read_only=true&toolsets=time,Tasks&toolsets=tasks",
+		want: serverSelection{
+			product:  productProjects,
+			readOnly: true,
+			toolsets: []toolsets.Method{"tasks", "time"},
+		},
+	}, {
+		name:   "all toolsets",
+		target: "/?toolsets=tasks,all",
+		want:   serverSelection{toolsets: []toolsets.Method{toolsets.MethodAll}},
+	}, {
+		name:   "configured defaults",
+		target: "/desk?read_only=false",
+		defaults: &serverSelection{
+			readOnly: true,
+			toolsets: []toolsets.Method{"tickets"},
+		},
+		want: serverSelection{
+			product:  productDesk,
+			readOnly: true,
+			toolsets: []toolsets.Method{"tickets"},
+		},
+	}, {
+		name:     "toolsets override the defaults",
+		target:   "/?toolsets=time",
+		defaults: &serverSelection{toolsets: []toolsets.Method{"tasks"}},
+		want:     serverSelection{toolsets: []toolsets.Method{"time"}},
+	}, {
+		name:    "unknown product",
+		target:  "/unknown",
+		wantErr: true,
+	}, {
+		name:    "invalid read-only",
+		target:  "/?read_only=maybe",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := allToolsets
+			if tt.defaults != nil {
+				defaults = *tt.defaults
+			}
+			got, err := parseServerSelection(httptest.NewRequest(http.MethodPost, tt.target, nil), defaults)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.key() != tt.want.key() {
+				t.Errorf("unexpected selection %q, want %q", got.key(), tt.want.key())
+			}
+		})
+	}
+}
+
+func TestServerCache(t *testing.T) {
+	servers, err := newServerCache(config.Resources{})
+	if err != nil {
+		t.Fatalf("failed to create server cache: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		check      func(t *testing.T, tools []string)
+	}{{
+		name:       "desk read-only",
+		target:     "/desk?read_only=true",
+		wantStatus: http.StatusOK,
+		check: func(t *testing.T, tools []string) {
+			for _, tool := range tools {
+				if !strings.HasPrefix(tool, "twdesk-") {
+					t.Errorf("unexpected tool %q", tool)
+				}
+			}
+			if !slices.Contains(tools, "twdesk-get_ticket") {
+				t.Errorf("expected the read tools, got %v", tools)
+			}
+			if slices.Contains(tools, "twdesk-create_ticket") {
+				t.Error("unexpected write tool in read-only mode")
+			}
+		},
+	}, {
+		name:       "projects toolsets",
+		target:     "/projects?toolsets=time",
+		wantStatus: http.StatusOK,
+		check: func(t *testing.T, tools []string) {
+			if !slices.Contains(tools, "twprojects-create_timelog") {
+				t.Errorf("expected the timelog tools, got %v", tools)
+			}
+			if slices.Contains(tools, "twprojects-create_task") {
+				t.Error("unexpected tool of a disabled toolset")
+			}
+		},
+	}, {
+		name:       "unknown toolset",
+		target:     "/desk?toolsets=tasks",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "unknown product",
+		target:     "/unknown",
+		wantStatus: http.StatusNotFound,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *mcp.Server
+			handler := servers.middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				server = servers.getServer(r)
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, tt.target, nil))
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("unexpected status %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.check == nil {
+				return
+			}
+
+			// the same selection reuses the server
+			cached, err := servers.get(mustParseServerSelection(t, tt.target))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cached != server {
+				t.Error("expected the cached server")
+			}
+
+			tt.check(t, listTools(t, server))
+		})
+	}
+}
+
+func TestServerCacheEviction(t *testing.T) {
+	servers, err := newServerCache(config.Resources{})
+	if err != nil {
+		t.Fatalf("failed to create server cache: %v", err)
+	}
+	servers.size = 2
+
+	get := func(target string) *mcp.Server {
+		server, err := servers.get(mustParseServerSelection(t, target))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return server
+	}
+
+	desk := get("/desk")
+	projects := get("/projects")
+	if get("/desk") != desk {
+		t.Error("expected the cached desk server")
+	}
+	get("/desk?read_only=true")
+
+	if servers.lru.Len() != 2 {
+		t.Errorf("unexpected cache size %d, want 2", servers.lru.Len())
+	}
+	if get("/desk") != desk {
+		t.Error("expected the recently used desk server to be kept")
+	}
+	if get("/projects") == projects {
+		t.Error("expected the least recently used projects server to be evicted")
+	}
+
+	if _, err := servers.get(mustParseServerSelection(t, "/desk?toolsets=tasks")); err == nil {
+		t.Fatal("expected an error for an unknown toolset")
+	}
+	if _, ok := servers.entries[mustParseServerSelection(t, "/desk?toolsets=tasks").key()]; ok {
+		t.Error("unexpected cached failed build")
+	}
+}
+
+func TestServerCacheConcurrent(t *testing.T) {
+	servers, err := newServerCache(config.Resources{})
+	if err != nil {
+		t.Fatalf("failed to create server cache: %v", err)
+	}
+
+	selection := mustParseServerSelection(t, "/desk")
+	const requests = 10
+	results := make([]*mcp.Server, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server, err := servers.get(selection)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = server
+		}()
+	}
+	wg.Wait()
+
+	for _, server := range results {
+		if server == nil || server != results[0] {
+			t.Fatal("expected the same server for concurrent requests")
+		}
+	}
+}
+
+func mustParseServerSelection(t *testing.T, target string) serverSelection {
+	t.Helper()
+
+	selection, err := parseServerSelection(httptest.NewRequest(http.MethodPost, target, nil),
+		serverSelection{toolsets: []toolsets.Method{toolsets.MethodAll}})
+	if err != nil {
+		t.Fatalf("failed to parse selection: %v", err)
+	}
+	return selection
+}
+
+func listTools(t *testing.T, server *mcp.Server) []string {
+	t.Helper()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect server: %v", err)
+	}
+	defer serverSession.Close() //nolint:errcheck
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil)
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	var tools []string
+	for tool, err := range clientSession.Tools(t.Context(), nil) {
+		if err != nil {
+			t.Fatalf("failed to list tools: %v", err)
+		}
+		tools = append(tools, tool.Name)
+	}
+	return tools
+}
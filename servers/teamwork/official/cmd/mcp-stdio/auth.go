@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/auth"
+	"github.com/teamwork/mcp/internal/config"
+	"github.com/teamwork/mcp/internal/helpers"
+	"github.com/teamwork/mcp/internal/toolsets"
+	"github.com/teamwork/twapi-go-sdk/session"
+)
+
+var errNotAuthenticated = errors.New("not authenticated")
+
+// authenticator authenticates the requests with the bearer token of the
+// selected installation (profile). The token is loaded for each request, and
+// its information is fetched again only when it changes, so a rotated token is
+// picked up without restarting.
+//
+// The information is fetched without holding the lock, so a slow installation
+// doesn't block the others, and concurrent requests of an installation share
+// the same lookup.
+type authenticator struct {
+	resources config.Resources
+	names     []string
+
+	mu            sync.Mutex
+	current       string
+	installations map[string]*installation
+	lookups       map[string]*installation
+}
+
+// installation is the last bearer token of a profile and its information. The
+// done channel is closed when the lookup of the information finishes.
+type installation struct {
+	token string
+	info  *auth.BearerInfo
+	err   error
+	done  chan struct{}
+}
+
+func newAuthenticator(resources config.Resources) (*authenticator, error) {
+	a := &authenticator{
+		resources:     resources,
+		names:         resources.ProfileNames(),
+		current:       resources.Info.Profile,
+		installations: make(map[string]*installation),
+		lookups:       make(map[string]*installation),
+	}
+	if !slices.Contains(a.names, a.current) {
+		return nil, fmt.Errorf("unknown profile %q", a.current)
+	}
+	return a, nil
+}
+
+// Names returns the names of the installations.
+func (a *authenticator) Names() []string {
+	return a.names
+}
+
+// Current returns the name of the installation used when a tool call doesn't
+// select one.
+func (a *authenticator) Current() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.current
+}
+
+// Switch changes the installation used when a tool call doesn't select one.
+func (a *authenticator) Switch(name string) error {
+	if !slices.Contains(a.names, name) {
+		return fmt.Errorf("unknown installation %q, available installations: %v", name, a.names)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.current = name
+	return nil
+}
+
+// authenticate injects the customer URL, the user and the bearer token of the
+// given installation in the context. An empty name uses the current
+// installation. The failures are logged.
+func (a *authenticator) authenticate(ctx context.Context, name string) (context.Context, error) {
+	if name == "" {
+		name = a.Current()
+	}
+	info, token, err := a.Info(ctx, name)
+	if err != nil {
+		return ctx, err
+	}
+	// inject customer URL in the context
+	ctx = config.WithCustomerURL(ctx, info.URL)
+	// inject user in the context, for the audit log
+	ctx = config.WithUser(ctx, info.UserID, info.InstallationID)
+	// inject bearer token in the context
+	ctx = session.WithBearerTokenContext(ctx, session.NewBearerToken(token, info.URL))
+	return ctx, nil
+}
+
+// middleware authenticates the requests with the installation selected by the
+// tool call, or the current one.
+func (a *authenticator) middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		var installation string
+		bypass := auth.BypassMethod(method)
+		if callToolRequest, ok := req.(*mcp.CallToolRequest); ok {
+			switch toolsets.Method(callToolRequest.Params.Name) {
+			case methodListInstallations, methodSwitchInstallation:
+				// the installations can be switched even if the current one isn't
+				// authenticated
+				bypass = true
+			default:
+				var err error
+				if installation, err = popInstallationArgument(callToolRequest); err != nil {
+					return helpers.NewToolResultTextError(err.Error()), nil
+				}
+				if installation != "" && !slices.Contains(a.names, installation) {
+					return helpers.NewToolResultTextError(fmt.Sprintf("unknown installation %q, available "+
+						"installations: %v", installation, a.names)), nil
+				}
+			}
+		}
+
+		// the bearer token is loaded on every request, so it can be rotated
+		authCtx, err := a.authenticate(ctx, installation)
+		if err != nil {
+			if !bypass {
+				return nil, errNotAuthenticated
+			}
+			return next(ctx, method, req)
+		}
+		return next(authCtx, method, req)
+	}
+}
+
+// Info returns the information and the current bearer token of the given
+// installation.
+func (a *authenticator) Info(ctx context.Context, name string) (*auth.BearerInfo, string, error) {
+	if !slices.Contains(a.names, name) {
+		return nil, "", fmt.Errorf("unknown installation %q, available installations: %v", name, a.names)
+	}
+	token, err := a.resources.ProfileBearerToken(ctx, name)
+	if err != nil {
+		a.resources.Logger().Error("failed to load bearer token",
+			slog.String("installation", name),
+			slog.String("error", err.Error()),
+		)
+		return nil, "", err
+	}
+	if token == "" {
+		return nil, "", errNotAuthenticated
+	}
+
+	info, err := a.bearerInfo(ctx, name, token)
+	if err != nil {
+		return nil, "", err
+	}
+	return info, token, nil
+}
+
+func (a *authenticator) bearerInfo(ctx context.Context, name, token string) (*auth.BearerInfo, error) {
+	a.mu.Lock()
+	cached, rotated := a.installations[name]
+	if rotated && token == cached.token {
+		a.mu.Unlock()
+		return cached.info, cached.err
+	}
+	if pending, ok := a.lookups[name]; ok && token == pending.token {
+		a.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.info, pending.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	lookup := &installation{token: token, done: make(chan struct{})}
+	a.lookups[name] = lookup
+	a.mu.Unlock()
+
+	lookup.info, lookup.err = a.fetchBearerInfo(ctx, name, token, rotated)
+	close(lookup.done)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// a lookup of a newer token replaces this one
+	if a.lookups[name] == lookup {
+		delete(a.lookups, name)
+		if lookup.err == nil || errors.Is(lookup.err, auth.ErrBearerInfoUnauthorized) {
+			a.installations[name] = lookup
+		}
+	}
+	return lookup.info, lookup.err
+}
+
+// fetchBearerInfo detects the installation from the bearer token. Transient
+// failures are returned without being cached, so the next request tries again.
+func (a *authenticator) fetchBearerInfo(ctx context.Context, name, token string, rotated bool) (*auth.BearerInfo, error) {
+	info, err := auth.GetBearerInfo(ctx, a.resources, token)
+	if err != nil {
+		a.resources.Logger().Error("failed to get bearer info",
+			slog.String("installation", name),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, auth.ErrBearerInfoUnauthorized) {
+			// a rejected access token obtained with the login command is refreshed
+			a.resources.InvalidateBearerToken(token)
+		}
+		return nil, err
+	}
+	if rotated {
+		a.resources.Logger().Info("bearer token rotated",
+			slog.String("installation", name),
+		)
+	}
+	// the configured URL takes precedence, e.g. for custom domains
+	if profile, _ := a.resources.Profile(name); profile.URL != "" {
+		info.URL = profile.URL
+	}
+	return info, nil
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/config"
+)
+
+func TestAuthenticatorMiddleware(t *testing.T) {
+	installations := map[string]map[string]any{
+		"Bearer token-a": {"user_id": 1, "installation_id": 10, "url": "https://a.teamwork.com"},
+		"Bearer token-b": {"user_id": 2, "installation_id": 20, "url": "https://b.teamwork.com"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, ok := installations[r.Header.Get("Authorization")]
+		if r.URL.Path != "/launchpad/v1/userinfo.json" || !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(info)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "api_url: " + server.URL + `
+bearer_token: token-a
+profiles:
+  - name: b
+    bearer_token: token-b
+  - name: c
+    url: https://c.example.com
+    bearer_token: invalid
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	file, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	resources, teardown, err := config.Load(io.Discard, file)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	defer teardown()
+
+	authenticator, err := newAuthenticator(resources)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	handler := authenticator.middleware(func(ctx context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		customerURL, _ := config.CustomerURLFromContext(ctx)
+		var arguments json.RawMessage
+		if callToolRequest, ok := req.(*mcp.CallToolRequest); ok {
+			arguments = callToolRequest.Params.Arguments
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: customerURL + " " + string(arguments)}},
+		}, nil
+	})
+
+	tests := []struct {
+		name      string
+		switchTo  string
+		tool      string
+		arguments string
+		want      string
+		wantError bool
+		wantErr   error
+	}{{
+		name:      "current installation",
+		tool:      "twprojects-list_projects",
+		arguments: `{"page":1}`,
+		want:      `https://a.teamwork.com {"page":1}`,
+	}, {
+		name:      "selected installation",
+		tool:      "twprojects-list_projects",
+		arguments: `{"installation":"b","page":1}`,
+		want:      `https://b.teamwork.com {"page":1}`,
+	}, {
+		name:      "unknown installation",
+		tool:      "twprojects-list_projects",
+		arguments: `{"installation":"d"}`,
+		wantError: true,
+	}, {
+		name:      "unauthorized installation",
+		tool:      "twprojects-list_projects",
+		arguments: `{"installation":"c"}`,
+		wantErr:   errNotAuthenticated,
+	}, {
+		name:      "switched installation",
+		switchTo:  "b",
+		tool:      "twprojects-list_projects",
+		arguments: `{}`,
+		want:      `https://b.teamwork.com {}`,
+	}, {
+		name:      "switch from an unauthorized installation",
+		switchTo:  "c",
+		tool:      string(methodSwitchInstallation),
+		arguments: `{"installation":"a"}`,
+		want:      ` {"installation":"a"}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.switchTo != "" {
+				if err := authenticator.Switch(tt.switchTo); err != nil {
+					t.Fatalf("failed to switch installation: %v", err)
+				}
+			}
+			result, err := handler(t.Context(), "tools/call", &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{Name: tt.tool, Arguments: json.RawMessage(tt.arguments)},
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			callToolResult := result.(*mcp.CallToolResult)
+			if callToolResult.IsError != tt.wantError {
+				t.Fatalf("unexpected result %+v", callToolResult)
+			}
+			if text := callToolResult.Content[0].(*mcp.TextContent).Text; !tt.wantError && text != tt.want {
+				t.Errorf("got %q, want %q", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticatorConcurrentLookups(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer token-a":
+			// the lookup of the installation a is slow
+			if requests.Add(1) == 1 {
+				close(started)
+			}
+			<-release
+			_ = json.NewEncoder(w).Encode(map[string]any{"user_id": 1, "url": "https://a.teamwork.com"})
+		case "Bearer token-b":
+			_ = json.NewEncoder(w).Encode(map[string]any{"user_id": 2, "url": "https://b.teamwork.com"})
+		default:
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "api_url: " + server.URL + "\nbearer_token: token-a\nprofiles:\n  - name: b\n    bearer_token: token-b\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	file, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	resources, teardown, err := config.Load(io.Discard, file)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	defer teardown()
+
+	authenticator, err := newAuthenticator(resources)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	current := authenticator.Current()
+
+	const lookups = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, lookups)
+	for range lookups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, _, err := authenticator.Info(t.Context(), current)
+			if err == nil && info.URL != "https://a.teamwork.com" {
+				err = fmt.Errorf("unexpected URL %q", info.URL)
+			}
+			errs <- err
+		}()
+	}
+	<-started
+
+	// the other installations aren't blocked by the slow lookup
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	if info, _, err := authenticator.Info(ctx, "b"); err != nil || info.URL != "https://b.teamwork.com" {
+		t.Errorf("unexpected installation b %+v (%v)", info, err)
+	}
+	if err := authenticator.Switch("b"); err != nil {
+		t.Errorf("failed to switch installation: %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("unexpected %d lookups of the installation, want 1", got)
+	}
+}
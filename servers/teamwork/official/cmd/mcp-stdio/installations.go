@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/helpers"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+// List of meta-tools available to select the Teamwork installation when
+// multiple profiles are configured. They don't belong to any product, so they
+// are not prefixed like the other methods.
+const (
+	methodListInstallations  toolsets.Method = "list_installations"
+	methodSwitchInstallation toolsets.Method = "switch_installation"
+)
+
+// methodInstallations is the name of the Toolset that groups the meta-tools
+// used to select the Teamwork installation.
+const methodInstallations toolsets.Method = "installations"
+
+// installationArgument is the optional argument added to every tool to select
+// the installation of a single call.
+const installationArgument = "installation"
+
+const installationsDescription = "Multiple Teamwork.com installations (sites) are available in this session. " +
+	"Tools use the current installation, unless the call selects another one with the \"" +
+	installationArgument + "\" argument."
+
+func init() {
+	// register the toolset methods
+	toolsets.RegisterMethod(methodListInstallations)
+	toolsets.RegisterMethod(methodSwitchInstallation)
+}
+
+type installationInfo struct {
+	Name           string `json:"name"`
+	URL            string `json:"url,omitempty"`
+	InstallationID int64  `json:"installationId,omitempty"`
+	Current        bool   `json:"current"`
+	Error          string `json:"error,omitempty"`
+}
+
+type installationListResponse struct {
+	Installations []installationInfo `json:"installations"`
+}
+
+type installationSwitchInput struct {
+	Installation string `json:"installation" jsonschema:"The name of the installation, as returned by list_installations."`
+}
+
+// newInstallationsGroup creates a ToolsetGroup containing the meta-tools that
+// allow the LLM to list the installations and switch between them. The
+// returned group is already enabled, and as the meta-tools don't change any
+// data they are also available in read-only mode.
+func newInstallationsGroup(authenticator *authenticator) *toolsets.ToolsetGroup {
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset(methodInstallations, installationsDescription).
+		AddReadTools(
+			listInstallations(authenticator),
+			switchInstallation(authenticator),
+		))
+	if err := group.EnableToolset(methodInstallations); err != nil {
+		// should never happen, the toolset was just added
+		panic(fmt.Sprintf("failed to enable installations toolset: %v", err))
+	}
+	return group
+}
+
+// installationInputProperty is the schema of the installation argument added
+// to every tool.
+func installationInputProperty(authenticator *authenticator) *jsonschema.Schema {
+	enum := make([]any, len(authenticator.Names()))
+	for i, name := range authenticator.Names() {
+		enum[i] = name
+	}
+	return &jsonschema.Schema{
+		Type: "string",
+		Description: "The Teamwork.com installation used by this call, as returned by " +
+			string(methodListInstallations) + ". Defaults to the current installation.",
+		Enum: enum,
+	}
+}
+
+// popInstallationArgument removes the installation argument from the tool
+// call, returning its value.
+func popInstallationArgument(request *mcp.CallToolRequest) (string, error) {
+	if request.Params == nil || len(request.Params.Arguments) == 0 {
+		return "", nil
+	}
+	var arguments map[string]json.RawMessage
+	if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
+		// invalid arguments are reported by the tool
+		return "", nil
+	}
+	value, ok := arguments[installationArgument]
+	if !ok {
+		return "", nil
+	}
+	var installation string
+	if err := json.Unmarshal(value, &installation); err != nil {
+		return "", fmt.Errorf("invalid parameters: parameter %s must be a string", installationArgument)
+	}
+	delete(arguments, installationArgument)
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	request.Params.Arguments = encoded
+	return installation, nil
+}
+
+func listInstallations(authenticator *authenticator) toolsets.ToolWrapper {
+	outputSchema, err := jsonschema.For[installationListResponse](&jsonschema.ForOptions{})
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate JSON schema for installationListResponse: %v", err))
+	}
+
+	return toolsets.ToolWrapper{
+		Tool: &mcp.Tool{
+			Name:        string(methodListInstallations),
+			Description: "List the available installations, indicating the current one. " + installationsDescription,
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Installations",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			OutputSchema: outputSchema,
+		},
+		Handler: func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			current := authenticator.Current()
+			response := installationListResponse{
+				Installations: make([]installationInfo, 0, len(authenticator.Names())),
+			}
+			for _, name := range authenticator.Names() {
+				info := installationInfo{
+					Name:    name,
+					Current: name == current,
+				}
+				if bearerInfo, _, err := authenticator.Info(ctx, name); err != nil {
+					info.Error = err.Error()
+				} else {
+					info.URL = bearerInfo.URL
+					info.InstallationID = bearerInfo.InstallationID
+				}
+				response.Installations = append(response.Installations, info)
+			}
+			return helpers.NewToolResultJSON(response)
+		},
+	}
+}
+
+func switchInstallation(authenticator *authenticator) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(methodSwitchInstallation),
+			Description: "Switch the current installation of the session, used by the tools that don't select " +
+				"one. " + installationsDescription,
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Switch Installation",
+				// switching doesn't change any data, it only changes the installation
+				// used by the next calls
+				ReadOnlyHint:   true,
+				IdempotentHint: true,
+			},
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input installationSwitchInput) (*mcp.CallToolResult, error) {
+			if err := authenticator.Switch(input.Installation); err != nil {
+				return helpers.NewToolResultTextError(err.Error()), nil
+			}
+			if info, _, err := authenticator.Info(ctx, input.Installation); err == nil {
+				return helpers.NewToolResultText("Switched to installation %q (%s)", input.Installation, info.URL), nil
+			}
+			return helpers.NewToolResultText("Switched to installation %q, but it isn't authenticated",
+				input.Installation), nil
+		},
+		toolsets.WithInputSchemaEnum("installation", authenticator.Names()...),
+	)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/teamwork/mcp/internal/auth"
+	"github.com/teamwork/mcp/internal/config"
+	"github.com/teamwork/mcp/internal/oauth"
+)
+
+// loginTimeout is how long the login command waits for the user to log in.
+const loginTimeout = 5 * time.Minute
+
+// runLogin runs the login command, which logs in with the Teamwork app login
+// flow and stores the tokens in the token file, used by the server when no
+// bearer token is set.
+func runLogin(args []string) {
+	flags := flag.NewFlagSet("login", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to a YAML or TOML config file (defaults to TW_MCP_CONFIG)")
+	noBrowser := flags.Bool("no-browser", false, "Print the login URL instead of opening the browser")
+	_ = flags.Parse(args)
+
+	file, err := config.ReadFile(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+	resources, teardown, err := config.Load(os.Stderr, file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+	defer teardown()
+
+	switch {
+	case resources.Info.OAuth.ClientID == "":
+		fmt.Fprintln(os.Stderr, "the client ID of the Teamwork app is required (TW_MCP_OAUTH_CLIENT_ID)")
+		exit(exitCodeSetupFailure)
+	case resources.Info.OAuth.TokenFile == "":
+		fmt.Fprintln(os.Stderr, "the path of the token file is required (TW_MCP_OAUTH_TOKEN_FILE)")
+		exit(exitCodeSetupFailure)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+
+	token, err := resources.OAuthConfig().Login(ctx, resources.Info.OAuth.RedirectAddress, func(loginURL string) error {
+		fmt.Fprintf(os.Stderr, "Open the following URL to log in to Teamwork.com:\n\n  %s\n\n", loginURL)
+		if !*noBrowser {
+			if err := oauth.OpenBrowser(loginURL); err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to log in: %s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+
+	info, err := auth.GetBearerInfo(ctx, resources, token.AccessToken)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get bearer info: %s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+	if err := oauth.SaveToken(resources.Info.OAuth.TokenFile, token); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+	fmt.Fprintf(os.Stderr, "Logged in to %s, the token is stored in %s\n", info.URL, resources.Info.OAuth.TokenFile)
+}
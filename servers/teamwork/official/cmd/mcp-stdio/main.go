@@ -7,32 +7,77 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/teamwork/mcp/internal/auth"
+	"github.com/teamwork/mcp/internal/audit"
 	"github.com/teamwork/mcp/internal/config"
 	"github.com/teamwork/mcp/internal/toolsets"
 	"github.com/teamwork/mcp/internal/twdesk"
 	"github.com/teamwork/mcp/internal/twprojects"
-	"github.com/teamwork/twapi-go-sdk/session"
 )
 
 var (
-	methods   = methodsInput([]toolsets.Method{toolsets.MethodAll})
-	readOnly  bool
-	logToFile string
+	methods         = methodsInput([]toolsets.Method{toolsets.MethodAll})
+	readOnly        bool
+	dynamicToolsets bool
+	allowDelete     bool
+	includeTools    []string
+	excludeTools    []string
+	logToFile       string
+	configFile      string
+	printConfig     bool
 )
 
 func main() {
 	defer handleExit()
 
+	if len(os.Args) > 1 && os.Args[1] == "login" {
+		runLogin(os.Args[2:])
+		return
+	}
+
 	flag.Var(&methods, "toolsets", "Comma-separated list of toolsets to enable")
 	flag.StringVar(&logToFile, "log-to-file", "", "Path to log file (if empty, logs to stderr)")
 	flag.BoolVar(&readOnly, "read-only", false, "Restrict the server to read-only operations")
+	flag.BoolVar(&dynamicToolsets, "dynamic-toolsets", false,
+		"Start without toolsets and allow the LLM to enable them at runtime")
+	flag.BoolVar(&allowDelete, "allow-delete", false, "Expose the tools that delete data")
+	flag.Func("tools", "Comma-separated list of glob patterns of the tools to expose (e.g. twprojects-list_*)",
+		func(value string) error {
+			includeTools = config.SplitList(value)
+			return nil
+		})
+	flag.Func("exclude-tools", "Comma-separated list of glob patterns of the tools to hide",
+		func(value string) error {
+			excludeTools = config.SplitList(value)
+			return nil
+		})
+	flag.StringVar(&configFile, "config", "", "Path to a YAML or TOML config file (defaults to TW_MCP_CONFIG)")
+	flag.BoolVar(&printConfig, "print-config", false, "Print the effective configuration, with secrets masked, and exit")
 	flag.Parse()
 
+	file, err := config.ReadFile(configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		exit(exitCodeSetupFailure)
+	}
+
+	// the configuration is printed before loading the resources, so no secret
+	// command runs and no audit sink or tracer is started
+	if printConfig {
+		resources := config.NewResources(file)
+		applyFlags(&resources)
+		if err := config.WriteConfig(os.Stdout, resources); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			exit(exitCodeSetupFailure)
+		}
+		exit(exitCodeOK)
+	}
+
 	f := os.Stderr
 	if logToFile != "" {
 		var err error
@@ -44,40 +89,65 @@ func main() {
 	}
 
 	defer f.Close() //nolint:errcheck
-	resources, teardown := config.Load(f)
+	resources, teardown, err := config.Load(f, file)
+	if err != nil {
+		resources.Logger().Error("failed to load configuration",
+			slog.String("error", err.Error()),
+		)
+		exit(exitCodeSetupFailure)
+	}
 	defer teardown()
 
+	applyFlags(&resources)
+
+	readOnly = resources.Info.Tools.ReadOnly
+	if err := methods.Set(strings.Join(resources.Info.Tools.Toolsets, ",")); err != nil {
+		mcpError(resources.Logger(), fmt.Errorf("invalid toolsets: %w", err), jsonRPCErrorCodeInvalidParams)
+		exit(exitCodeSetupFailure)
+	}
+
+	// stdout carries the MCP protocol, so audit events can't be written to it
+	if slices.ContainsFunc(resources.Info.Audit.Sinks, func(sink string) bool {
+		return strings.EqualFold(sink, audit.SinkStdout)
+	}) {
+		mcpError(resources.Logger(), errors.New("the stdout audit sink is not supported in STDIO mode"),
+			jsonRPCErrorCodeInternalError)
+		exit(exitCodeSetupFailure)
+	}
+
 	ctx := context.Background()
 
-	var authenticated bool
-	if resources.Info.BearerToken != "" {
-		// detect the installation from the bearer token
-		if info, err := auth.GetBearerInfo(ctx, resources, resources.Info.BearerToken); err != nil {
-			resources.Logger().Error("failed to get bearer info",
-				slog.String("error", err.Error()),
-			)
+	if resources.Info.Metrics.Enabled {
+		if resources.Info.Metrics.Address == "" {
+			resources.Logger().Error("metrics are enabled without a listener address")
 		} else {
-			authenticated = true
-			// inject customer URL in the context
-			ctx = config.WithCustomerURL(ctx, info.URL)
-			// inject bearer token in the context
-			ctx = session.WithBearerTokenContext(ctx, session.NewBearerToken(resources.Info.BearerToken, info.URL))
+			metricsServer := resources.Metrics().NewServer(resources.Info.Metrics.Address)
+			defer metricsServer.Close() //nolint:errcheck
+			go func() {
+				if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					resources.Logger().Error("failed to start metrics server",
+						slog.String("address", resources.Info.Metrics.Address),
+						slog.String("error", err.Error()),
+					)
+				}
+			}()
 		}
 	}
 
-	mcpServer, err := newMCPServer(resources)
+	authenticator, err := newAuthenticator(resources)
+	if err != nil {
+		mcpError(resources.Logger(), fmt.Errorf("invalid profile: %w", err), jsonRPCErrorCodeInvalidParams)
+		exit(exitCodeSetupFailure)
+	}
+	// authenticate on startup to report an invalid bearer token early
+	_, _ = authenticator.authenticate(ctx, "")
+
+	mcpServer, err := newMCPServer(resources, authenticator)
 	if err != nil {
 		mcpError(resources.Logger(), fmt.Errorf("failed to create MCP server: %s", err), jsonRPCErrorCodeInternalError)
 		exit(exitCodeSetupFailure)
 	}
-	mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
-		return func(ctx context.Context, method string, req mcp.Request) (result mcp.Result, err error) {
-			if !authenticated && !auth.BypassMethod(method) {
-				return nil, errors.New("not authenticated")
-			}
-			return next(ctx, method, req)
-		}
-	})
+	mcpServer.AddReceivingMiddleware(authenticator.middleware)
 
 	if err := mcpServer.Run(ctx, &mcp.StdioTransport{}); err != nil {
 		mcpError(resources.Logger(), fmt.Errorf("failed to serve: %s", err), jsonRPCErrorCodeInternalError)
@@ -85,18 +155,63 @@ func main() {
 	}
 }
 
-func newMCPServer(resources config.Resources) (*mcp.Server, error) {
-	projectsGroup := twprojects.DefaultToolsetGroup(readOnly, false, resources.TeamworkEngine())
-	if err := projectsGroup.EnableToolsets(methods...); err != nil {
-		return nil, fmt.Errorf("failed to enable projects toolsets: %w", err)
+// applyFlags overrides the configuration with the command line flags, which
+// take precedence over the environment variables.
+func applyFlags(resources *config.Resources) {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "toolsets":
+			resources.Info.Tools.Toolsets = make([]string, len(methods))
+			for i, method := range methods {
+				resources.Info.Tools.Toolsets[i] = method.String()
+			}
+		case "read-only":
+			resources.Info.Tools.ReadOnly = readOnly
+		case "allow-delete":
+			resources.Info.Tools.AllowDelete = allowDelete
+		case "tools":
+			resources.Info.Tools.Include = includeTools
+		case "exclude-tools":
+			resources.Info.Tools.Exclude = excludeTools
+		}
+	})
+}
+
+func newMCPServer(resources config.Resources, authenticator *authenticator) (*mcp.Server, error) {
+	enabledMethods := methods
+	if dynamicToolsets {
+		// in dynamic mode the toolsets are enabled by the LLM when needed, so only
+		// the explicitly requested ones are enabled at startup
+		enabledMethods = slices.DeleteFunc(slices.Clone(methods), func(method toolsets.Method) bool {
+			return method == toolsets.MethodAll
+		})
+	}
+
+	toolFilter, err := toolsets.NewToolFilter(resources.Info.Tools.Include, resources.Info.Tools.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool filter: %w", err)
 	}
 
-	deskGroup := twdesk.DefaultToolsetGroup(resources.DeskClient())
-	if err := deskGroup.EnableToolsets(methods...); err != nil {
-		return nil, fmt.Errorf("failed to enable desk toolsets: %w", err)
+	projectsGroup := twprojects.DefaultToolsetGroup(readOnly, resources.Info.Tools.AllowDelete, resources.TeamworkEngine())
+	projectsGroup.SetToolFilter(toolFilter)
+	deskGroup := twdesk.DefaultToolsetGroup(readOnly, resources.Info.Tools.AllowDelete, resources.DeskClient(),
+		resources.DeskAPIClient())
+	deskGroup.SetToolFilter(toolFilter)
+	if err := toolsets.EnableToolsetsInGroups(enabledMethods, projectsGroup, deskGroup); err != nil {
+		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+	}
+
+	groups := []*toolsets.ToolsetGroup{projectsGroup, deskGroup}
+	if dynamicToolsets {
+		groups = append(groups, toolsets.NewDynamicToolsetGroup(projectsGroup, deskGroup))
+	}
+	if len(authenticator.Names()) > 1 {
+		projectsGroup.AddInputProperty(installationArgument, installationInputProperty(authenticator))
+		deskGroup.AddInputProperty(installationArgument, installationInputProperty(authenticator))
+		groups = append(groups, newInstallationsGroup(authenticator))
 	}
 
-	return config.NewMCPServer(resources, projectsGroup, deskGroup), nil
+	return config.NewMCPServer(resources, groups...), nil
 }
 
 func mcpError(logger *slog.Logger, err error, code jsonRPCErrorCode) {
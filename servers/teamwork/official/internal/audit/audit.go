@@ -0,0 +1,79 @@
+// Package audit records the tool calls that create, update or delete data in
+// Teamwork.com, so administrators can review who changed what through the MCP
+// server. Events are written to one or more sinks (e.g. a JSON-lines file or an
+// HTTP webhook).
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// List of outcomes of a tool call.
+const (
+	// OutcomeSuccess is the outcome of a tool call that succeeded.
+	OutcomeSuccess = "success"
+	// OutcomeError is the outcome of a tool call rejected before running the
+	// tool (e.g. invalid arguments or missing scopes).
+	OutcomeError = "error"
+	// OutcomeToolError is the outcome of a tool call that returned an error
+	// result (e.g. the Teamwork API rejected the request).
+	OutcomeToolError = "tool_error"
+)
+
+// Event is the audit record of a write tool call.
+type Event struct {
+	// Time is when the tool was called.
+	Time time.Time `json:"time"`
+	// UserID is the Teamwork.com user that called the tool, zero when unknown.
+	UserID int64 `json:"user_id,omitempty"`
+	// InstallationID is the Teamwork.com installation of the user, zero when
+	// unknown.
+	InstallationID int64 `json:"installation_id,omitempty"`
+	// Tool is the name of the called tool.
+	Tool string `json:"tool"`
+	// Arguments are the tool arguments, with the sensitive values redacted.
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	// Outcome is the outcome of the tool call. It can be "success", "error" or
+	// "tool_error".
+	Outcome string `json:"outcome"`
+	// Error is the error message when the tool call didn't succeed.
+	Error string `json:"error,omitempty"`
+	// CreatedIDs are the IDs of the entities created by the tool.
+	CreatedIDs []int64 `json:"created_ids,omitempty"`
+	// AffectedIDs are the IDs of the entities referenced by the arguments,
+	// grouped by argument name (e.g. "id" or "project_id").
+	AffectedIDs map[string][]int64 `json:"affected_ids,omitempty"`
+}
+
+// Sink stores the audit events. Implementations must be safe for concurrent
+// use.
+type Sink interface {
+	Record(ctx context.Context, event Event) error
+	io.Closer
+}
+
+// MultiSink records the events in all the sinks.
+type MultiSink []Sink
+
+// Record records the event in all the sinks, returning the errors of the sinks
+// that failed.
+func (m MultiSink) Record(ctx context.Context, event Event) error {
+	var errs error
+	for _, sink := range m {
+		errs = errors.Join(errs, sink.Record(ctx, event))
+	}
+	return errs
+}
+
+// Close closes all the sinks.
+func (m MultiSink) Close() error {
+	var errs error
+	for _, sink := range m {
+		errs = errors.Join(errs, sink.Close())
+	}
+	return errs
+}
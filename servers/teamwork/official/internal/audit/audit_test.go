@@ -0,0 +1,237 @@
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/audit"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := audit.NewWriterSink(&buffer)
+
+	events := []audit.Event{{
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UserID:  1,
+		Tool:    "twprojects-create_task",
+		Outcome: audit.OutcomeSuccess,
+	}, {
+		Time:    time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC),
+		Tool:    "twprojects-delete_task",
+		Outcome: audit.OutcomeToolError,
+		Error:   "not found",
+	}}
+	for _, event := range events {
+		if err := sink.Record(t.Context(), event); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("failed to close sink: %v", err)
+	}
+
+	var got []audit.Event
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		var event audit.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("failed to decode line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, event)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("unexpected events %+v, want %+v", got, events)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// events are appended to the existing file
+	for range 2 {
+		sink, err := audit.NewFileSink(path)
+		if err != nil {
+			t.Fatalf("failed to create sink: %v", err)
+		}
+		if err := sink.Record(t.Context(), audit.Event{Tool: "twprojects-create_task"}); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("failed to close sink: %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if lines := bytes.Count(content, []byte("\n")); lines != 2 {
+		t.Errorf("unexpected %d lines in audit log:\n%s", lines, content)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{{
+		name:   "accepted",
+		status: http.StatusAccepted,
+	}, {
+		name:    "rejected",
+		status:  http.StatusInternalServerError,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received audit.Event
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &received)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sink, err := audit.NewWebhookSink(server.Client(), server.URL, []string{"Authorization: Bearer secret"})
+			if err != nil {
+				t.Fatalf("failed to create sink: %v", err)
+			}
+			err = sink.Record(t.Context(), audit.Event{Tool: "twdesk-create_ticket", InstallationID: 2})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if received.Tool != "twdesk-create_ticket" || received.InstallationID != 2 {
+				t.Errorf("unexpected event %+v", received)
+			}
+			if authorization != "Bearer secret" {
+				t.Errorf("unexpected authorization header %q", authorization)
+			}
+		})
+	}
+}
+
+func TestNewWebhookSinkInvalid(t *testing.T) {
+	if _, err := audit.NewWebhookSink(http.DefaultClient, "", nil); err == nil {
+		t.Error("expected an error for a missing URL")
+	}
+	if _, err := audit.NewWebhookSink(http.DefaultClient, "https://example.com", []string{"invalid"}); err == nil {
+		t.Error("expected an error for an invalid header")
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) Record(context.Context, audit.Event) error { return errors.New("failed") }
+func (failingSink) Close() error                              { return nil }
+
+func TestMultiSink(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := audit.MultiSink{failingSink{}, audit.NewWriterSink(&buffer)}
+
+	if err := sink.Record(t.Context(), audit.Event{Tool: "twprojects-create_task"}); err == nil {
+		t.Error("expected the error of the failing sink")
+	}
+	if buffer.Len() == 0 {
+		t.Error("expected the event in the other sinks")
+	}
+}
+
+func TestAffectedIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments string
+		want      map[string][]int64
+	}{{
+		name:      "entity references",
+		arguments: `{"id":10,"project_id":20,"tag_ids":[1,2,2],"name":"Task","priority":3}`,
+		want: map[string][]int64{
+			"id":         {10},
+			"project_id": {20},
+			"tag_ids":    {1, 2},
+		},
+	}, {
+		name:      "no references",
+		arguments: `{"name":"Task","user_id":0}`,
+	}, {
+		name:      "invalid arguments",
+		arguments: `[1,2]`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := audit.AffectedIDs(json.RawMessage(tt.arguments)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AffectedIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatedIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments string
+		result    *mcp.CallToolResult
+		want      []int64
+	}{{
+		name:      "text result",
+		arguments: `{"name":"Task"}`,
+		result: &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Task created successfully with ID 123"}},
+		},
+		want: []int64{123},
+	}, {
+		name:      "wrapped JSON result",
+		arguments: `{"subject":"Help"}`,
+		result: &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: `{"ticket":{"id":456,"subject":"Help"}}`}},
+		},
+		want: []int64{456},
+	}, {
+		name:      "JSON result",
+		arguments: `{"name":"Urgent"}`,
+		result: &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: `{"id":789,"name":"Urgent"}`}},
+		},
+		want: []int64{789},
+	}, {
+		name:      "update result",
+		arguments: `{"id":456,"subject":"Help"}`,
+		result: &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: `{"ticket":{"id":456,"subject":"Help"}}`}},
+		},
+	}, {
+		name:      "error result",
+		arguments: `{"name":"Task"}`,
+		result: &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Task created successfully with ID 123"}},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := audit.CreatedIDs(json.RawMessage(tt.arguments), tt.result)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CreatedIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// createdIDPattern matches the text results of the create tools (e.g. "Task
+// created successfully with ID 123").
+var createdIDPattern = regexp.MustCompile(`(?i)\bcreated\b.*\bwith ID (\d+)`)
+
+// AffectedIDs returns the entity IDs referenced by the tool arguments. The
+// arguments named "id", or ending with "_id" or "_ids", are considered entity
+// references.
+func AffectedIDs(arguments json.RawMessage) map[string][]int64 {
+	var fields map[string]any
+	if err := json.Unmarshal(arguments, &fields); err != nil {
+		return nil
+	}
+
+	affected := make(map[string][]int64)
+	for name, value := range fields {
+		if name != "id" && !strings.HasSuffix(name, "_id") && !strings.HasSuffix(name, "_ids") {
+			continue
+		}
+		var ids []int64
+		switch v := value.(type) {
+		case float64:
+			ids = appendID(ids, v)
+		case []any:
+			for _, item := range v {
+				if number, ok := item.(float64); ok {
+					ids = appendID(ids, number)
+				}
+			}
+		}
+		if len(ids) > 0 {
+			affected[name] = ids
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+	return affected
+}
+
+// CreatedIDs returns the IDs of the entities created by a tool, from its
+// result. Text results must report the ID (e.g. "Task created successfully with
+// ID 123"), while JSON results must contain the created entity, directly or in
+// a wrapper object (e.g. {"ticket":{"id":123}}). As update tools may also
+// return the entity, JSON results are ignored when the arguments have an "id".
+func CreatedIDs(arguments json.RawMessage, result *mcp.CallToolResult) []int64 {
+	if result == nil || result.IsError {
+		return nil
+	}
+
+	var ids []int64
+	for _, content := range result.Content {
+		textContent, ok := content.(*mcp.TextContent)
+		if !ok {
+			continue
+		}
+		if matches := createdIDPattern.FindStringSubmatch(textContent.Text); matches != nil {
+			if id, err := strconv.ParseInt(matches[1], 10, 64); err == nil {
+				ids = append(ids, id)
+			}
+			continue
+		}
+		if hasIDArgument(arguments) {
+			continue
+		}
+		var document map[string]any
+		if err := json.Unmarshal([]byte(textContent.Text), &document); err != nil {
+			continue
+		}
+		if id, ok := document["id"].(float64); ok {
+			ids = appendID(ids, id)
+			continue
+		}
+		if len(document) == 1 {
+			for _, value := range document {
+				if entity, ok := value.(map[string]any); ok {
+					if id, ok := entity["id"].(float64); ok {
+						ids = appendID(ids, id)
+					}
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func hasIDArgument(arguments json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(arguments, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["id"]
+	return ok
+}
+
+func appendID(ids []int64, value float64) []int64 {
+	if value <= 0 || value != float64(int64(value)) {
+		return ids
+	}
+	if id := int64(value); !slices.Contains(ids, id) {
+		return append(ids, id)
+	}
+	return ids
+}
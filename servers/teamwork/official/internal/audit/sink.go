@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// List of sink kinds.
+const (
+	SinkFile    = "file"
+	SinkStdout  = "stdout"
+	SinkWebhook = "webhook"
+)
+
+// DefaultWebhookTimeout is the default maximum time to deliver an event to the
+// webhook.
+const DefaultWebhookTimeout = 5 * time.Second
+
+// WriterSink writes the events as JSON lines.
+type WriterSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink creates a sink writing the events as JSON lines to the given
+// writer (e.g. os.Stdout). The writer isn't closed with the sink.
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{writer: writer}
+}
+
+// NewFileSink creates a sink appending the events as JSON lines to the given
+// file, which is created when missing.
+func NewFileSink(path string) (*WriterSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &WriterSink{writer: file, closer: file}, nil
+}
+
+// Record writes the event as a JSON line.
+func (s *WriterSink) Record(_ context.Context, event Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	encoded = append(encoded, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(encoded); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file of the sink, if any.
+func (s *WriterSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// WebhookSink posts each event as JSON to an HTTP endpoint.
+type WebhookSink struct {
+	client  *http.Client
+	url     string
+	headers http.Header
+	// Timeout is the maximum time to deliver an event. The delivery isn't
+	// canceled with the tool call, so events of canceled calls are still
+	// delivered.
+	Timeout time.Duration
+}
+
+// NewWebhookSink creates a sink posting the events to the given URL with the
+// given HTTP client. Headers are "Name: value" pairs added to every request
+// (e.g. an authorization header expected by the endpoint).
+func NewWebhookSink(client *http.Client, url string, headers []string) (*WebhookSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("missing audit webhook URL")
+	}
+	sink := &WebhookSink{
+		client:  client,
+		url:     url,
+		headers: make(http.Header),
+		Timeout: DefaultWebhookTimeout,
+	}
+	for _, header := range headers {
+		name, value, found := strings.Cut(header, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid audit webhook header %q", header)
+		}
+		sink.headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return sink, nil
+}
+
+// Record posts the event to the webhook. Any response status other than 2xx is
+// an error.
+func (s *WebhookSink) Record(ctx context.Context, event Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(encoded))
+	if err != nil {
+		return fmt.Errorf("failed to create audit webhook request: %w", err)
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post audit event: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close does nothing, as the events are delivered synchronously.
+func (s *WebhookSink) Close() error {
+	return nil
+}
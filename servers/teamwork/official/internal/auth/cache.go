@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BearerInfoFetcher retrieves the bearer information of a token when it isn't
+// available in the cache.
+type BearerInfoFetcher func(ctx context.Context, token string) (*BearerInfo, error)
+
+// BearerInfoCacheStats contains the counters of a BearerInfoCache.
+type BearerInfoCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+// BearerInfoCache is an in-process cache of bearer information, avoiding a
+// request to Teamwork API for every HTTP request. Entries expire after a TTL
+// and the least recently used entries are evicted when the cache is full.
+// Unauthorized tokens are also cached (negative caching) with a separate TTL.
+//
+// Tokens are never stored, entries are keyed by the SHA-256 hash of the token.
+type BearerInfoCache struct {
+	fetch       BearerInfoFetcher
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+type bearerInfoCacheEntry struct {
+	key       [sha256.Size]byte
+	info      *BearerInfo
+	err       error
+	expiresAt time.Time
+}
+
+// NewBearerInfoCache creates a new BearerInfoCache with the given maximum
+// number of entries and TTLs. A size lower or equal to zero or a zero TTL
+// disables the cache, so every lookup calls the fetcher. A zero negative TTL
+// disables the caching of unauthorized tokens.
+func NewBearerInfoCache(
+	size int,
+	ttl, negativeTTL time.Duration,
+	fetch BearerInfoFetcher,
+) *BearerInfoCache {
+	return &BearerInfoCache{
+		fetch:       fetch,
+		size:        size,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     make(map[[sha256.Size]byte]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// Get returns the bearer information of the token, from the cache when
+// available. Only successful lookups and ErrBearerInfoUnauthorized errors are
+// cached, any other error is returned without being cached.
+func (c *BearerInfoCache) Get(ctx context.Context, token string) (*BearerInfo, error) {
+	if c.size <= 0 || c.ttl <= 0 {
+		c.misses.Add(1)
+		return c.fetch(ctx, token)
+	}
+
+	key := sha256.Sum256([]byte(token))
+	if entry, ok := c.lookup(key); ok {
+		c.hits.Add(1)
+		return entry.info, entry.err
+	}
+	c.misses.Add(1)
+
+	info, err := c.fetch(ctx, token)
+	switch {
+	case err == nil:
+		c.store(key, info, nil, c.ttl)
+	case errors.Is(err, ErrBearerInfoUnauthorized) && c.negativeTTL > 0:
+		c.store(key, nil, err, c.negativeTTL)
+	}
+	return info, err
+}
+
+// Stats returns the current counters of the cache.
+func (c *BearerInfoCache) Stats() BearerInfoCacheStats {
+	c.mutex.Lock()
+	size := c.lru.Len()
+	c.mutex.Unlock()
+
+	return BearerInfoCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+	}
+}
+
+func (c *BearerInfoCache) lookup(key [sha256.Size]byte) (*bearerInfoCacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*bearerInfoCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry, true
+}
+
+func (c *BearerInfoCache) store(key [sha256.Size]byte, info *BearerInfo, err error, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry := &bearerInfoCacheEntry{
+		key:       key,
+		info:      info,
+		err:       err,
+		expiresAt: c.now().Add(ttl),
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*bearerInfoCacheEntry).key)
+		c.evictions.Add(1)
+	}
+}
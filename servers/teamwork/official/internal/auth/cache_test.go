@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBearerInfoCache(t *testing.T) {
+	now := time.Now()
+	calls := make(map[string]int)
+	fetch := func(_ context.Context, token string) (*BearerInfo, error) {
+		calls[token]++
+		switch token {
+		case "invalid":
+			return nil, ErrBearerInfoUnauthorized
+		case "failure":
+			return nil, errors.New("network failure")
+		}
+		return &BearerInfo{URL: "https://" + token + ".example.com"}, nil
+	}
+
+	cache := NewBearerInfoCache(2, time.Minute, 10*time.Second, fetch)
+	cache.now = func() time.Time { return now }
+
+	get := func(token string) (*BearerInfo, error) {
+		t.Helper()
+		return cache.Get(t.Context(), token)
+	}
+
+	t.Run("hit", func(t *testing.T) {
+		for range 3 {
+			info, err := get("first")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.URL != "https://first.example.com" {
+				t.Errorf("unexpected URL: %s", info.URL)
+			}
+		}
+		if calls["first"] != 1 {
+			t.Errorf("expected 1 fetch, got %d", calls["first"])
+		}
+	})
+
+	t.Run("negative caching", func(t *testing.T) {
+		for range 2 {
+			if _, err := get("invalid"); !errors.Is(err, ErrBearerInfoUnauthorized) {
+				t.Errorf("expected unauthorized error, got %v", err)
+			}
+		}
+		if calls["invalid"] != 1 {
+			t.Errorf("expected 1 fetch, got %d", calls["invalid"])
+		}
+
+		now = now.Add(11 * time.Second)
+		if _, err := get("invalid"); !errors.Is(err, ErrBearerInfoUnauthorized) {
+			t.Errorf("expected unauthorized error, got %v", err)
+		}
+		if calls["invalid"] != 2 {
+			t.Errorf("expected negative entry to expire, got %d fetches", calls["invalid"])
+		}
+	})
+
+	t.Run("other errors are not cached", func(t *testing.T) {
+		for range 2 {
+			if _, err := get("failure"); err == nil {
+				t.Error("expected an error")
+			}
+		}
+		if calls["failure"] != 2 {
+			t.Errorf("expected 2 fetches, got %d", calls["failure"])
+		}
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		if _, err := get("first"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls["first"] != 2 {
+			t.Errorf("expected entry to expire, got %d fetches", calls["first"])
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		// "first" and "invalid" are in the cache, "first" is the most recent one
+		if _, err := get("second"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := get("invalid"); !errors.Is(err, ErrBearerInfoUnauthorized) {
+			t.Errorf("expected unauthorized error, got %v", err)
+		}
+		if calls["invalid"] != 3 {
+			t.Errorf("expected least recently used entry to be evicted, got %d fetches", calls["invalid"])
+		}
+	})
+
+	stats := cache.Stats()
+	if stats.Size != 2 {
+		t.Errorf("expected 2 entries, got %d", stats.Size)
+	}
+	if stats.Hits != 3 {
+		t.Errorf("expected 3 hits, got %d", stats.Hits)
+	}
+	if stats.Misses != 8 {
+		t.Errorf("expected 8 misses, got %d", stats.Misses)
+	}
+	if stats.Evictions == 0 {
+		t.Error("expected evictions")
+	}
+}
+
+func TestBearerInfoCacheDisabled(t *testing.T) {
+	var calls int
+	cache := NewBearerInfoCache(0, time.Minute, time.Minute, func(context.Context, string) (*BearerInfo, error) {
+		calls++
+		return &BearerInfo{}, nil
+	})
+	for range 2 {
+		if _, err := cache.Get(t.Context(), "token"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 fetches, got %d", calls)
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/audit"
+)
+
+// newAuditSink creates the sinks of the audit log. It returns nil when the
+// audit log is disabled.
+func newAuditSink(resources Resources) (audit.Sink, error) {
+	var sinks audit.MultiSink
+	for _, kind := range resources.Info.Audit.Sinks {
+		var sink audit.Sink
+		var err error
+		switch strings.ToLower(kind) {
+		case audit.SinkFile:
+			sink, err = audit.NewFileSink(resources.Info.Audit.FilePath)
+		case audit.SinkStdout:
+			sink = audit.NewWriterSink(os.Stdout)
+		case audit.SinkWebhook:
+			sink, err = audit.NewWebhookSink(new(http.Client),
+				resources.Info.Audit.WebhookURL,
+				resources.Info.Audit.WebhookHeaders,
+			)
+		default:
+			err = fmt.Errorf("unsupported audit sink %q", kind)
+		}
+		if err != nil {
+			_ = sinks.Close()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return sinks, nil
+}
+
+// isWriteTool returns true if the tool may change data. Tools are considered
+// write tools unless they are explicitly annotated as read-only.
+func isWriteTool(tool *mcp.Tool) bool {
+	return tool.Annotations == nil || !tool.Annotations.ReadOnlyHint
+}
+
+// auditToolCall records the call of a write tool in the audit log. Failures
+// to record the event are logged, as they must not change the tool result.
+func auditToolCall(
+	ctx context.Context,
+	resources Resources,
+	params *mcp.CallToolParamsRaw,
+	result mcp.Result,
+	err error,
+	start time.Time,
+) {
+	event := audit.Event{
+		Time:        start.UTC(),
+		Tool:        params.Name,
+		Outcome:     audit.OutcomeSuccess,
+		AffectedIDs: audit.AffectedIDs(params.Arguments),
+	}
+	event.UserID, event.InstallationID, _ = UserFromContext(ctx)
+	if len(params.Arguments) > 0 {
+		arguments := []byte(resources.auditRedactor.Body(params.Arguments))
+		if !json.Valid(arguments) {
+			// keep the event valid JSON when the arguments are malformed
+			arguments, _ = json.Marshal(string(arguments))
+		}
+		event.Arguments = arguments
+	}
+
+	callToolResult, _ := result.(*mcp.CallToolResult)
+	switch {
+	case err != nil:
+		event.Outcome = audit.OutcomeError
+		event.Error = err.Error()
+	case callToolResult != nil && callToolResult.IsError:
+		event.Outcome = audit.OutcomeToolError
+		for _, content := range callToolResult.Content {
+			if textContent, ok := content.(*mcp.TextContent); ok {
+				event.Error = textContent.Text
+				break
+			}
+		}
+	default:
+		event.CreatedIDs = audit.CreatedIDs(params.Arguments, callToolResult)
+	}
+
+	if err := resources.audit.Record(ctx, event); err != nil {
+		resources.logger.ErrorContext(ctx, "failed to record audit event",
+			slog.String("tool", params.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+}
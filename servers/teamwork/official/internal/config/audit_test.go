@@ -0,0 +1,185 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/audit"
+	"github.com/teamwork/mcp/internal/network"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (s *recordingSink) Record(_ context.Context, event audit.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	newTool := func(name string, readOnly bool, result *mcp.CallToolResult) toolsets.ToolWrapper {
+		return toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name:        name,
+				Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+				InputSchema: &jsonschema.Schema{Type: "object"},
+			},
+			Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return result, nil
+			},
+		}
+	}
+
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset("example", "Example toolset.").
+		AddReadTools(
+			newTool("twprojects-get_task", true, &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: `{"id":1}`}},
+			}),
+		).
+		AddWriteTools(
+			newTool("twprojects-create_task", false, &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: "Task created successfully with ID 123"}},
+			}),
+			newTool("twprojects-delete_task", false, &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: "task not found"}},
+			}),
+		))
+	if err := group.EnableToolsets(toolsets.MethodAll); err != nil {
+		t.Fatalf("failed to enable toolsets: %v", err)
+	}
+
+	sink := new(recordingSink)
+	resources := Resources{
+		audit:         sink,
+		auditRedactor: network.NewRedactor(nil, nil),
+	}
+	mcpServer := NewMCPServer(resources, group)
+	// simulate the user injected by the HTTP authentication middleware
+	mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(WithUser(ctx, 10, 20), method, req)
+		}
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect to server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	calls := []*mcp.CallToolParams{{
+		Name:      "twprojects-get_task",
+		Arguments: map[string]any{"id": 1},
+	}, {
+		Name:      "twprojects-create_task",
+		Arguments: map[string]any{"tasklist_id": 5, "name": "Task", "assignee_email": "john@example.com"},
+	}, {
+		Name:      "twprojects-delete_task",
+		Arguments: map[string]any{"id": 7},
+	}}
+	for _, call := range calls {
+		if _, err := clientSession.CallTool(t.Context(), call); err != nil {
+			t.Fatalf("failed to call tool %s: %v", call.Name, err)
+		}
+	}
+
+	if len(sink.events) != 2 {
+		t.Fatalf("expected 2 audit events, got %d: %+v", len(sink.events), sink.events)
+	}
+
+	created := sink.events[0]
+	if created.Tool != "twprojects-create_task" || created.Outcome != audit.OutcomeSuccess {
+		t.Errorf("unexpected create event %+v", created)
+	}
+	if created.UserID != 10 || created.InstallationID != 20 {
+		t.Errorf("unexpected user %d and installation %d", created.UserID, created.InstallationID)
+	}
+	if len(created.CreatedIDs) != 1 || created.CreatedIDs[0] != 123 {
+		t.Errorf("unexpected created IDs %v", created.CreatedIDs)
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal(created.Arguments, &arguments); err != nil {
+		t.Fatalf("failed to decode arguments: %v", err)
+	}
+	if arguments["assignee_email"] != "REDACTED" || arguments["name"] != "Task" {
+		t.Errorf("unexpected redacted arguments %s", created.Arguments)
+	}
+
+	deleted := sink.events[1]
+	if deleted.Outcome != audit.OutcomeToolError || deleted.Error != "task not found" {
+		t.Errorf("unexpected delete event %+v", deleted)
+	}
+	if ids := deleted.AffectedIDs["id"]; len(ids) != 1 || ids[0] != 7 {
+		t.Errorf("unexpected affected IDs %v", deleted.AffectedIDs)
+	}
+}
+
+func TestLoadAudit(t *testing.T) {
+	tests := []struct {
+		name    string
+		sinks   string
+		file    string
+		wantErr bool
+	}{{
+		name:  "file sink",
+		sinks: "file",
+		file:  filepath.Join(t.TempDir(), "audit.log"),
+	}, {
+		name: "disabled",
+	}, {
+		name:    "unsupported sink",
+		sinks:   "syslog",
+		wantErr: true,
+	}, {
+		name:    "unwritable file",
+		sinks:   "file",
+		file:    filepath.Join(t.TempDir(), "missing", "audit.log"),
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TW_MCP_AUDIT_SINKS", tt.sinks)
+			t.Setenv("TW_MCP_AUDIT_FILE", tt.file)
+
+			resources, teardown, err := Load(io.Discard, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if resources.Logger() == nil {
+					t.Error("expected the logger on error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			teardown()
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
-	"slices"
 	"strings"
 	"time"
 
@@ -20,22 +19,36 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	desksdk "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/deskapi"
+	"github.com/teamwork/mcp/internal/metrics"
 	"github.com/teamwork/mcp/internal/network"
+	"github.com/teamwork/mcp/internal/oauth"
 	"github.com/teamwork/mcp/internal/request"
 	"github.com/teamwork/mcp/internal/toolsets"
 	twapi "github.com/teamwork/twapi-go-sdk"
 	"github.com/teamwork/twapi-go-sdk/session"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 const (
-	mcpName            = "Teamwork.com"
-	sentryFlushTimeout = 2 * time.Second
+	mcpName                   = "Teamwork.com"
+	sentryFlushTimeout        = 2 * time.Second
+	openTelemetryFlushTimeout = 5 * time.Second
 )
 
-// Load loads the configuration for the MCP service.
-func Load(logOutput io.Writer) (Resources, func()) {
-	resources := newResources()
+// Load loads the configuration for the MCP service. It returns the function
+// that releases the resources, or an error when a resource required by the
+// configuration can't be created. The logger is available even on error.
+func Load(logOutput io.Writer, file *File) (Resources, func(), error) {
+	resources := NewResources(file)
+	secretsErr := resources.loadSecrets(context.Background())
 	resources.logger = slog.New(newCustomLogHandler(resources, logOutput))
+	if secretsErr != nil {
+		resources.logger.Error("failed to load secrets",
+			slog.String("error", secretsErr.Error()),
+		)
+	}
+	resources.metrics = metrics.New()
 	resources.teamworkHTTPClient = new(http.Client)
 
 	var haProxyURL *url.URL
@@ -80,11 +93,41 @@ func Load(logOutput io.Writer) (Resources, func()) {
 		)
 	}
 
+	if resources.Info.OpenTelemetry.Enabled {
+		resources.teamworkHTTPClient.Transport = otelhttp.NewTransport(resources.teamworkHTTPClient.Transport,
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return fmt.Sprintf("%s_%s", req.Method, req.URL.Path)
+			}),
+		)
+	}
+
 	// Allow logging HTTP requests
-	resources.teamworkHTTPClient.Transport = network.NewLoggingRoundTripper(
-		resources.logger,
-		resources.teamworkHTTPClient.Transport,
+	loggingRoundTripper := network.NewLoggingRoundTripper(resources.logger, resources.teamworkHTTPClient.Transport)
+	switch mode := network.LoggingMode(resources.Info.Log.HTTP.Mode); mode {
+	case network.LoggingModeOff, network.LoggingModeHeaders, network.LoggingModeFull:
+		loggingRoundTripper.Mode = mode
+	default:
+		resources.logger.Error("invalid HTTP logging mode, using headers",
+			slog.String("mode", resources.Info.Log.HTTP.Mode),
+		)
+	}
+	loggingRoundTripper.MaxBodySize = resources.Info.Log.HTTP.MaxBodySize
+	loggingRoundTripper.SampleRate = resources.Info.Log.HTTP.SampleRate
+	loggingRoundTripper.Redactor = network.NewRedactor(
+		resources.Info.Log.HTTP.RedactHeaders,
+		resources.Info.Log.HTTP.RedactFields,
 	)
+	resources.teamworkHTTPClient.Transport = loggingRoundTripper
+
+	// Retry transient errors, logging each attempt
+	retryRoundTripper := network.NewRetryRoundTripper(resources.logger, resources.teamworkHTTPClient.Transport)
+	retryRoundTripper.MaxRetries = resources.Info.Retry.MaxRetries
+	retryRoundTripper.Budget = resources.Info.Retry.Budget
+	resources.teamworkHTTPClient.Transport = retryRoundTripper
+
+	if !resources.bearerToken.IsSet() && resources.Info.OAuth.TokenFile != "" {
+		resources.oauthToken = oauth.NewTokenSource(resources.OAuthConfig(), resources.Info.OAuth.TokenFile)
+	}
 
 	resources.teamworkEngine = twapi.NewEngine(session.NewBearerTokenContext(),
 		twapi.WithHTTPClient(resources.teamworkHTTPClient),
@@ -120,30 +163,46 @@ func Load(logOutput io.Writer) (Resources, func()) {
 				return next.Do(req)
 			})
 		}),
+		twapi.WithMiddleware(func(next twapi.HTTPClient) twapi.HTTPClient {
+			return twapi.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				start := time.Now()
+				resp, err := next.Do(req)
+				resources.metrics.ObserveUpstream("projects", req, resp, err, start)
+				return resp, err
+			})
+		}),
 		twapi.WithLogger(resources.logger),
 	)
 
+	deskMiddleware := func(
+		ctx context.Context,
+		req *http.Request,
+		next desksdk.RequestHandler,
+	) (*http.Response, error) {
+		// Get the bearer token from the context (if available)
+		btx := session.NewBearerTokenContext()
+		err := btx.Authenticate(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		request.SetProxyHeaders(req)
+		req.Header.Set("User-Agent", "Teamwork MCP/"+resources.Info.Version)
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		resources.metrics.ObserveUpstream("desk", req, resp, err, start)
+		return resp, err
+	}
+	deskURL := resources.Info.APIURL + "/desk/api/v2"
 	resources.deskClient = desksdk.NewClient(
-		resources.Info.APIURL+"/desk/api/v2",
+		deskURL,
 		desksdk.WithHTTPClient(resources.teamworkHTTPClient),
-		desksdk.WithMiddleware(
-			func(
-				ctx context.Context,
-				req *http.Request,
-				next desksdk.RequestHandler,
-			) (*http.Response, error) {
-				// Get the bearer token from the context (if available)
-				btx := session.NewBearerTokenContext()
-				err := btx.Authenticate(ctx, req)
-				if err != nil {
-					return nil, err
-				}
-
-				request.SetProxyHeaders(req)
-				req.Header.Set("User-Agent", "Teamwork MCP/"+resources.Info.Version)
-				return next(ctx, req)
-			}),
+		desksdk.WithMiddleware(deskMiddleware),
 	)
+	// the Desk SDK doesn't support every operation, the other ones are sent with
+	// the same middleware
+	resources.deskAPIClient = deskapi.NewClient(deskURL, resources.teamworkHTTPClient, deskMiddleware)
 
 	if resources.Info.DatadogAPM.Enabled {
 		if err := startDatadog(resources); err != nil {
@@ -153,14 +212,52 @@ func Load(logOutput io.Writer) (Resources, func()) {
 		}
 	}
 
-	return resources, func() {
+	var stopOpenTelemetry func(context.Context) error
+	teardown := func() {
 		if resources.Info.DatadogAPM.Enabled {
 			tracer.Stop()
 		}
+		if stopOpenTelemetry != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), openTelemetryFlushTimeout)
+			defer cancel()
+			if err := stopOpenTelemetry(ctx); err != nil {
+				resources.logger.Error("failed to stop OpenTelemetry tracing",
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+		if resources.audit != nil {
+			if err := resources.audit.Close(); err != nil {
+				resources.logger.Error("failed to close audit log",
+					slog.String("error", err.Error()),
+				)
+			}
+		}
 		if resources.Info.Log.SentryDSN != "" {
 			sentry.Flush(sentryFlushTimeout)
 		}
 	}
+
+	// the write tool calls must not run without being audited, so the service
+	// doesn't start when the configured audit log can't be created
+	resources.auditRedactor = network.NewRedactor(nil, resources.Info.Audit.RedactFields)
+	auditSink, err := newAuditSink(resources)
+	if err != nil {
+		teardown()
+		return resources, func() {}, fmt.Errorf("failed to create audit log: %w", err)
+	}
+	resources.audit = auditSink
+
+	if resources.Info.OpenTelemetry.Enabled {
+		var err error
+		if stopOpenTelemetry, err = startOpenTelemetry(context.Background(), resources); err != nil {
+			resources.logger.Error("failed to start OpenTelemetry tracing",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return resources, teardown, nil
 }
 
 // NewMCPServer creates a new MCP server with the given resources and toolset
@@ -182,9 +279,48 @@ func NewMCPServer(resources Resources, groups ...*toolsets.ToolsetGroup) *mcp.Se
 	}, &mcp.ServerOptions{
 		HasTools: hasTools,
 	})
+
+	// restrict the available tools based on the bearer token scopes
+	tools := make(map[string]*mcp.Tool)
+	for _, group := range groups {
+		for _, toolset := range group.Toolsets {
+			for _, toolWrapper := range toolset.GetAvailableTools() {
+				tools[toolWrapper.Tool.Name] = toolWrapper.Tool
+			}
+		}
+	}
+	// middlewares added later wrap the earlier ones, so the arguments are only
+	// validated after checking the scopes
+	mcpServer.AddReceivingMiddleware(validationMiddleware(tools))
+	mcpServer.AddReceivingMiddleware(scopeMiddleware(tools))
+
 	mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (result mcp.Result, err error) {
+			start := time.Now()
+			if resources.Info.OpenTelemetry.Enabled {
+				var endSpan func(mcp.Result, error)
+				ctx, endSpan = startMCPSpan(ctx, resources.auditRedactor, method, req)
+				defer func() { endSpan(result, err) }()
+			}
 			result, err = next(ctx, method, req)
+
+			// record the request metrics, using a known tool name as label
+			var toolName string
+			if callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+				toolName = metrics.UnknownTool
+				if _, known := tools[callToolParams.Name]; known {
+					toolName = callToolParams.Name
+				}
+			}
+			resources.metrics.ObserveRequest(method, toolName, result, err, start)
+
+			// record the calls of the tools that may change data
+			if callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok && resources.audit != nil {
+				if tool, known := tools[callToolParams.Name]; known && isWriteTool(tool) {
+					auditToolCall(ctx, resources, callToolParams, result, err, start)
+				}
+			}
+
 			if err != nil {
 				return result, err
 			}
@@ -209,25 +345,7 @@ func NewMCPServer(resources Resources, groups ...*toolsets.ToolsetGroup) *mcp.Se
 				}
 			}
 
-			listToolsResult, ok := result.(*mcp.ListToolsResult)
-			if !ok || listToolsResult == nil || len(listToolsResult.Tools) == 0 {
-				return result, nil
-			}
-
-			// filter tools based on scopes
-			scopes := scopes(ctx)
-			if len(scopes) == 0 {
-				return result, err
-			}
-
-			projectsScope := slices.Contains(scopes, "projects")
-			deskScope := slices.Contains(scopes, "desk")
-
-			listToolsResult.Tools = slices.DeleteFunc(listToolsResult.Tools, func(tool *mcp.Tool) bool {
-				return (strings.HasPrefix(tool.Name, "twprojects") && !projectsScope) ||
-					(strings.HasPrefix(tool.Name, "twdesk") && !deskScope)
-			})
-			return listToolsResult, nil
+			return result, nil
 		}
 	})
 
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv is the environment variable with the path of the configuration
+// file, used when the path isn't set with the command line flag.
+const ConfigFileEnv = "TW_MCP_CONFIG"
+
+// maskedValue replaces the secrets when printing the configuration.
+const maskedValue = "********"
+
+// File is a YAML or TOML configuration file. It has the same structure as
+// Resources.Info, with snake case keys (e.g. "api_url" or "log.http.mode").
+// Environment variables and command line flags take precedence over it.
+type File struct {
+	path    string
+	content []byte
+}
+
+// ReadFile reads the configuration file of the given path, or of the path in
+// the TW_MCP_CONFIG environment variable when empty. The format is detected
+// from the file extension (".yaml", ".yml" or ".toml"). It returns nil when no
+// file is configured.
+func ReadFile(path string) (*File, error) {
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	file := &File{path: path, content: content}
+
+	// detect unknown keys and invalid values early
+	var resources Resources
+	if err := file.decode(&resources.Info); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := validateSecret("bearer_token", resources.Info.BearerToken, resources.Info.BearerTokenFile,
+		resources.Info.BearerTokenCommand); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := validateSecret("log.sentry_dsn", resources.Info.Log.SentryDSN, resources.Info.Log.SentryDSNFile,
+		resources.Info.Log.SentryDSNCommand); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := validateProfiles(resources.Info.Profiles); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Path returns the path of the configuration file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// decode decodes the configuration file over the given value, so the keys
+// missing in the file keep their current value. Unknown keys are rejected.
+func (f *File) decode(v any) error {
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(f.content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	case ".toml":
+		metadata, err := toml.Decode(string(f.content), v)
+		if err != nil {
+			return err
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown key %q", undecoded[0].String())
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported config file format %q", filepath.Ext(f.path))
+	}
+}
+
+func validateProfiles(profiles []Profile) error {
+	names := make(map[string]struct{}, len(profiles))
+	for _, profile := range profiles {
+		switch _, duplicated := names[profile.Name]; {
+		case profile.Name == "":
+			return errors.New("profile without name")
+		case profile.Name == DefaultProfile:
+			return fmt.Errorf("profile name %q is reserved for the top-level bearer token", DefaultProfile)
+		case duplicated:
+			return fmt.Errorf("duplicated profile %q", profile.Name)
+		}
+		names[profile.Name] = struct{}{}
+
+		if err := validateSecret("bearer_token", profile.BearerToken, profile.BearerTokenFile,
+			profile.BearerTokenCommand); err != nil {
+			return fmt.Errorf("profile %q: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateSecret rejects a secret with more than one source, as only one of
+// them would be used.
+func validateSecret(key, value, file, command string) error {
+	var sources int
+	for _, source := range []string{value, file, command} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of %[1]s, %[1]s_file and %[1]s_command can be set", key)
+	}
+	return nil
+}
+
+// WriteConfig writes the effective configuration as YAML, with the secrets
+// masked, so it can be reviewed or used as a configuration file.
+func WriteConfig(w io.Writer, resources Resources) error {
+	info := resources.Info
+	info.BearerToken = maskSecret(info.BearerToken)
+	// the URLs may contain credentials in the user info or the query string
+	info.HAProxyURL = maskSecret(info.HAProxyURL)
+	info.Audit.WebhookURL = maskSecret(info.Audit.WebhookURL)
+	info.Log.SentryDSN = maskSecret(info.Log.SentryDSN)
+	info.OAuth.ClientSecret = maskSecret(info.OAuth.ClientSecret)
+	if len(info.Profiles) > 0 {
+		profiles := slices.Clone(info.Profiles)
+		for i := range profiles {
+			profiles[i].BearerToken = maskSecret(profiles[i].BearerToken)
+		}
+		info.Profiles = profiles
+	}
+	if len(info.Audit.WebhookHeaders) > 0 {
+		headers := make([]string, len(info.Audit.WebhookHeaders))
+		for i, header := range info.Audit.WebhookHeaders {
+			name, _, _ := strings.Cut(header, ":")
+			headers[i] = name + ": " + maskedValue
+		}
+		info.Audit.WebhookHeaders = headers
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(info); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	return encoder.Close()
+}
+
+func maskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return maskedValue
+}
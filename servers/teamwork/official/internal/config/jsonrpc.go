@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+)
+
+// List of JSON-RPC error codes used by the MCP server. Codes between -32000
+// and -32099 are reserved for implementation-defined server errors, and the MCP
+// SDK already uses some of them (-32000 to -32004) for its own errors.
+//
+// https://www.jsonrpc.org/specification#error_object
+const (
+	JSONRPCErrorCodeInvalidParams int64 = -32602
+	JSONRPCErrorCodeInternalError int64 = -32603
+	JSONRPCErrorCodeForbidden     int64 = -32010
+	JSONRPCErrorCodeRateLimited   int64 = -32029
+)
+
+// NewJSONRPCError creates an error that is sent to the MCP client as a
+// JSON-RPC error object with the given code, message and optional data.
+//
+// The MCP SDK only keeps the error code of its own (unexported) error type, so
+// the error is built by decoding a JSON-RPC response from the wire format.
+func NewJSONRPCError(code int64, message string, data any) error {
+	wireError := struct {
+		Code    int64           `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}{
+		Code:    code,
+		Message: message,
+	}
+	if data != nil {
+		encodedData, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("%s (failed to encode error data: %w)", message, err)
+		}
+		wireError.Data = encodedData
+	}
+
+	encoded, err := json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      int64  `json:"id"`
+		Error   any    `json:"error"`
+	}{
+		JSONRPC: "2.0",
+		ID:      1,
+		Error:   wireError,
+	})
+	if err != nil {
+		return errors.New(message)
+	}
+
+	msg, err := jsonrpc.DecodeMessage(encoded)
+	if err != nil {
+		return errors.New(message)
+	}
+	response, ok := msg.(*jsonrpc.Response)
+	if !ok || response.Error == nil {
+		return errors.New(message)
+	}
+	return response.Error
+}
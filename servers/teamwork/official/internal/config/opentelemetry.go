@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/network"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans created by the MCP
+// server.
+const tracerName = "github.com/teamwork/mcp"
+
+// List of OTLP protocols.
+const (
+	otlpProtocolHTTP = "http/protobuf"
+	otlpProtocolGRPC = "grpc"
+)
+
+// startOpenTelemetry configures the global OpenTelemetry tracer provider,
+// exporting the spans with OTLP, and the W3C trace context propagator. The
+// exporter reads the standard OTEL_EXPORTER_OTLP_* environment variables (e.g.
+// endpoint, headers and certificates). It returns the function that flushes the
+// pending spans and stops the exporter.
+func startOpenTelemetry(ctx context.Context, resources Resources) (func(context.Context) error, error) {
+	var client otlptrace.Client
+	switch protocol := strings.ToLower(resources.Info.OpenTelemetry.Protocol); protocol {
+	case otlpProtocolHTTP, "http":
+		client = otlptracehttp.NewClient()
+	case otlpProtocolGRPC:
+		client = otlptracegrpc.NewClient()
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", protocol)
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	otelResource, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", resources.Info.OpenTelemetry.ServiceName),
+		attribute.String("service.version", resources.Info.Version),
+		attribute.String("deployment.environment.name", resources.Info.Environment),
+		attribute.String("cloud.region", resources.Info.AWSRegion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenTelemetry resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(otelResource),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return tracerProvider.Shutdown, nil
+}
+
+// Tracer returns the OpenTelemetry tracer of the MCP server. When
+// OpenTelemetry is disabled, the spans are discarded.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// startMCPSpan starts the span of an MCP request, with the same MCP attributes
+// of the Datadog APM traces. The tool arguments are redacted like in the audit
+// log, as the spans are exported to a third party. It returns the function that
+// ends the span with the request result.
+func startMCPSpan(
+	ctx context.Context,
+	redactor *network.Redactor,
+	method string,
+	req mcp.Request,
+) (context.Context, func(result mcp.Result, err error)) {
+	spanName := method
+	attributes := []attribute.KeyValue{attribute.String("mcp.method", method)}
+	if callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+		spanName += " " + callToolParams.Name
+		attributes = append(attributes,
+			attribute.String("mcp.tool.name", callToolParams.Name),
+			attribute.String("mcp.tool.arguments", redactor.Body(callToolParams.Arguments)),
+		)
+	}
+
+	ctx, span := Tracer().Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...),
+	)
+	return ctx, func(result mcp.Result, err error) {
+		defer span.End()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult.IsError {
+			if encoded, err := json.Marshal(callToolResult.Content); err == nil {
+				span.SetStatus(codes.Error, string(encoded))
+			} else {
+				span.SetStatus(codes.Error, "failed to execute tool")
+			}
+		}
+	}
+}
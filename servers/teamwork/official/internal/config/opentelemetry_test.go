@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/network"
+	"github.com/teamwork/mcp/internal/request"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartMCPSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	tests := []struct {
+		name       string
+		method     string
+		params     mcp.Params
+		result     mcp.Result
+		err        error
+		wantName   string
+		wantAttrs  map[attribute.Key]string
+		wantStatus codes.Code
+	}{{
+		name:   "tool call",
+		method: "tools/call",
+		params: &mcp.CallToolParamsRaw{
+			Name:      "twprojects-get_project",
+			Arguments: []byte(`{"id":1}`),
+		},
+		result:   &mcp.CallToolResult{},
+		wantName: "tools/call twprojects-get_project",
+		wantAttrs: map[attribute.Key]string{
+			"mcp.method":         "tools/call",
+			"mcp.tool.name":      "twprojects-get_project",
+			"mcp.tool.arguments": `{"id":1}`,
+		},
+		wantStatus: codes.Unset,
+	}, {
+		name:   "redacted arguments",
+		method: "tools/call",
+		params: &mcp.CallToolParamsRaw{
+			Name:      "twprojects-create_user",
+			Arguments: []byte(`{"email":"jane@example.com","password":"secret","first_name":"Jane"}`),
+		},
+		result:   &mcp.CallToolResult{},
+		wantName: "tools/call twprojects-create_user",
+		wantAttrs: map[attribute.Key]string{
+			"mcp.tool.arguments": `{"email":"REDACTED","first_name":"Jane","password":"REDACTED"}`,
+		},
+		wantStatus: codes.Unset,
+	}, {
+		name:   "tool error",
+		method: "tools/call",
+		params: &mcp.CallToolParamsRaw{Name: "twprojects-get_project"},
+		result: &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "not found"}},
+		},
+		wantName:   "tools/call twprojects-get_project",
+		wantAttrs:  map[attribute.Key]string{"mcp.tool.name": "twprojects-get_project"},
+		wantStatus: codes.Error,
+	}, {
+		name:       "method error",
+		method:     "tools/list",
+		params:     &mcp.ListToolsParams{},
+		err:        errors.New("forbidden"),
+		wantName:   "tools/list",
+		wantAttrs:  map[attribute.Key]string{"mcp.method": "tools/list"},
+		wantStatus: codes.Error,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, endSpan := startMCPSpan(t.Context(), network.NewRedactor(nil, nil), tt.method, &mcp.ServerRequest[mcp.Params]{Params: tt.params})
+			endSpan(tt.result, tt.err)
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			if span.Name() != tt.wantName {
+				t.Errorf("unexpected span name %q, want %q", span.Name(), tt.wantName)
+			}
+			attrs := make(map[attribute.Key]string)
+			for _, attr := range span.Attributes() {
+				attrs[attr.Key] = attr.Value.AsString()
+			}
+			for key, want := range tt.wantAttrs {
+				if attrs[key] != want {
+					t.Errorf("unexpected attribute %s %q, want %q", key, attrs[key], want)
+				}
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("unexpected status %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	ctx, span := Tracer().Start(t.Context(), "test")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	request.SetProxyHeaders(req)
+
+	traceparent := req.Header.Get("Traceparent")
+	want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if traceparent != want {
+		t.Errorf("unexpected traceparent %q, want %q", traceparent, want)
+	}
+}
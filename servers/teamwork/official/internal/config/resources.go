@@ -1,12 +1,22 @@
 package config
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	desksdk "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/audit"
+	"github.com/teamwork/mcp/internal/deskapi"
+	"github.com/teamwork/mcp/internal/metrics"
+	"github.com/teamwork/mcp/internal/network"
+	"github.com/teamwork/mcp/internal/oauth"
+	"github.com/teamwork/mcp/internal/secret"
 	twapi "github.com/teamwork/twapi-go-sdk"
 )
 
@@ -20,92 +30,393 @@ type Resources struct {
 	teamworkHTTPClient *http.Client
 	teamworkEngine     *twapi.Engine
 	deskClient         *desksdk.Client
+	deskAPIClient      *deskapi.Client
 	logger             *slog.Logger
+	metrics            *metrics.Metrics
+	audit              audit.Sink
+	auditRedactor      *network.Redactor
+	bearerToken        *secret.Secret
+	oauthToken         *oauth.TokenSource
+	profileTokens      map[string]*secret.Secret
 
-	// Info stores environment variables mappings.
+	// Info stores the configuration, loaded from the configuration file and the
+	// environment variables.
 	Info struct {
 		// Version is the current version of the MCP server.
-		Version string
+		Version string `yaml:"version" toml:"version"`
 		// ServerAddress is the address of the server. This is useful for the MCP
 		// server in HTTP mode.
-		ServerAddress string
+		ServerAddress string `yaml:"server_address" toml:"server_address"`
 		// Environment is the environment this app is running in.
-		Environment string
+		Environment string `yaml:"environment" toml:"environment"`
 		// AWSRegion is the AWS region this app is running in.
-		AWSRegion string
+		AWSRegion string `yaml:"aws_region" toml:"aws_region"`
 		// MCPURL is the base URL of the MCP server. This is useful for the MCP
 		// server in HTTP mode.
-		MCPURL string
+		MCPURL string `yaml:"mcp_url" toml:"mcp_url"`
 		// APIURL is the base URL of the Teamwork API.
-		APIURL string
+		APIURL string `yaml:"api_url" toml:"api_url"`
 		// HAProxyURL is the URL of the HAProxy instance. This is useful for the MCP
 		// server in HTTP mode.
-		HAProxyURL string
+		HAProxyURL string `yaml:"haproxy_url" toml:"haproxy_url"`
 		// BearerToken is the bearer token to be used to authenticate with Teamwork
 		// API. This is useful for the MCP server in STDIO mode.
-		BearerToken string
+		BearerToken string `yaml:"bearer_token" toml:"bearer_token"`
+		// BearerTokenFile is the path of a file containing the bearer token. The
+		// file is read again when modified, so the token can be rotated without
+		// restarting.
+		BearerTokenFile string `yaml:"bearer_token_file" toml:"bearer_token_file"`
+		// BearerTokenCommand is a command printing the bearer token, like a
+		// credential helper (e.g. "pass show teamwork/token").
+		BearerTokenCommand string `yaml:"bearer_token_command" toml:"bearer_token_command"`
+		// Profile is the name of the profile used when the tool calls don't select
+		// an installation. This is useful for the MCP server in STDIO mode.
+		Profile string `yaml:"profile" toml:"profile"`
+		// Profiles contains the named Teamwork installations, each with its own
+		// bearer token, that can be used in the same session. The top-level bearer
+		// token is the DefaultProfile.
+		Profiles []Profile `yaml:"profiles" toml:"profiles"`
+		// OAuth contains the configuration of the Teamwork app login flow, used by
+		// the MCP server in STDIO mode when no bearer token is set.
+		OAuth struct {
+			// ClientID is the client ID of the Teamwork app.
+			ClientID string `yaml:"client_id" toml:"client_id"`
+			// ClientSecret is the client secret of the Teamwork app.
+			ClientSecret string `yaml:"client_secret" toml:"client_secret"`
+			// RedirectAddress is the address listening for the redirect of the login
+			// page.
+			RedirectAddress string `yaml:"redirect_address" toml:"redirect_address"`
+			// TokenFile is the path of the file storing the tokens.
+			TokenFile string `yaml:"token_file" toml:"token_file"`
+		} `yaml:"oauth" toml:"oauth"`
+		// SecretCommandTTL is how long the output of the secret commands is reused
+		// before running them again.
+		SecretCommandTTL time.Duration `yaml:"secret_command_ttl" toml:"secret_command_ttl"`
+		// Tools contains the configuration of the exposed tools.
+		Tools struct {
+			// Toolsets contains the enabled toolsets (e.g. "tasks"). In HTTP mode,
+			// they are the default when the request doesn't select the toolsets.
+			Toolsets []string `yaml:"toolsets" toml:"toolsets"`
+			// ReadOnly indicates if only the read-only tools are exposed.
+			ReadOnly bool `yaml:"read_only" toml:"read_only"`
+			// AllowDelete indicates if the tools that delete data are exposed.
+			AllowDelete bool `yaml:"allow_delete" toml:"allow_delete"`
+			// Include contains glob patterns of the tool names to expose (e.g.
+			// "twprojects-list_*"). When empty, all tools are exposed.
+			Include []string `yaml:"include" toml:"include"`
+			// Exclude contains glob patterns of the tool names to hide. It takes
+			// precedence over Include.
+			Exclude []string `yaml:"exclude" toml:"exclude"`
+		} `yaml:"tools" toml:"tools"`
+		// Sessions contains the configuration of the streamable HTTP sessions. This
+		// is useful for the MCP server in HTTP mode.
+		Sessions struct {
+			// Stateful indicates if the sessions are kept between requests, so the
+			// server can send notifications and progress, and the clients can resume
+			// a dropped stream.
+			Stateful bool `yaml:"stateful" toml:"stateful"`
+			// TTL is how long an idle session is kept. Zero disables the eviction.
+			TTL time.Duration `yaml:"ttl" toml:"ttl"`
+			// EventStore is where the events of the sessions are stored. It can be
+			// "memory", "file" or "sqlite".
+			EventStore string `yaml:"event_store" toml:"event_store"`
+			// EventStorePath is the directory of the "file" event store, or the
+			// database file of the "sqlite" event store.
+			EventStorePath string `yaml:"event_store_path" toml:"event_store_path"`
+		} `yaml:"sessions" toml:"sessions"`
+		// RateLimit contains the limits of the tool calls, in calls per minute, of
+		// each user and installation. Read-only and write tools have separate
+		// budgets, and a zero limit disables it. This is useful for the MCP server
+		// in HTTP mode.
+		RateLimit struct {
+			// Enabled indicates if the tool calls are rate limited.
+			Enabled bool `yaml:"enabled" toml:"enabled"`
+			// UserRead is the limit of read-only tool calls of each user.
+			UserRead int `yaml:"user_read" toml:"user_read"`
+			// UserWrite is the limit of write tool calls of each user.
+			UserWrite int `yaml:"user_write" toml:"user_write"`
+			// InstallationRead is the limit of read-only tool calls of all the users
+			// of an installation.
+			InstallationRead int `yaml:"installation_read" toml:"installation_read"`
+			// InstallationWrite is the limit of write tool calls of all the users of
+			// an installation.
+			InstallationWrite int `yaml:"installation_write" toml:"installation_write"`
+		} `yaml:"rate_limit" toml:"rate_limit"`
+		// Retry contains the retry policy of the Teamwork API requests.
+		Retry struct {
+			// MaxRetries is the maximum number of retries of a request failing with
+			// a transient error. Zero disables the retries.
+			MaxRetries int `yaml:"max_retries" toml:"max_retries"`
+			// Budget is the maximum time spent on a request, including all retries.
+			Budget time.Duration `yaml:"budget" toml:"budget"`
+		} `yaml:"retry" toml:"retry"`
+		// AuthCache contains the configuration of the bearer information cache.
+		// This is useful for the MCP server in HTTP mode.
+		AuthCache struct {
+			// Size is the maximum number of cached tokens. Zero disables the cache.
+			Size int `yaml:"size" toml:"size"`
+			// TTL is how long the information of a valid token is cached.
+			TTL time.Duration `yaml:"ttl" toml:"ttl"`
+			// NegativeTTL is how long an unauthorized token is cached. Zero disables
+			// the caching of unauthorized tokens.
+			NegativeTTL time.Duration `yaml:"negative_ttl" toml:"negative_ttl"`
+		} `yaml:"auth_cache" toml:"auth_cache"`
 		// Log contains the logging configuration.
 		Log struct {
 			// Format is the format of the logs. It can be "json" or "text".
-			Format string
+			Format string `yaml:"format" toml:"format"`
 			// Level is the log level. It can be "debug", "info", "warn", "error" or
 			// "fatal".
-			Level string
+			Level string `yaml:"level" toml:"level"`
 			// SentryDSN is the Sentry DSN to be used for error reporting.
-			SentryDSN string
-		}
+			SentryDSN string `yaml:"sentry_dsn" toml:"sentry_dsn"`
+			// SentryDSNFile is the path of a file containing the Sentry DSN.
+			SentryDSNFile string `yaml:"sentry_dsn_file" toml:"sentry_dsn_file"`
+			// SentryDSNCommand is a command printing the Sentry DSN.
+			SentryDSNCommand string `yaml:"sentry_dsn_command" toml:"sentry_dsn_command"`
+			// HTTP contains the logging configuration of the Teamwork API requests,
+			// which are logged at debug level.
+			HTTP struct {
+				// Mode defines what is logged. It can be "off", "headers" or "full".
+				Mode string `yaml:"mode" toml:"mode"`
+				// MaxBodySize is the maximum number of bytes of a body that are logged.
+				MaxBodySize int `yaml:"max_body_size" toml:"max_body_size"`
+				// SampleRate is the fraction of requests that are logged, between 0
+				// and 1.
+				SampleRate float64 `yaml:"sample_rate" toml:"sample_rate"`
+				// RedactHeaders contains extra headers to redact, besides the default
+				// ones (e.g. "Authorization", "Set-Cookie").
+				RedactHeaders []string `yaml:"redact_headers" toml:"redact_headers"`
+				// RedactFields contains extra JSON fields to redact, besides the
+				// default ones (e.g. "email", "token"). Fields containing any of them
+				// in their name are redacted.
+				RedactFields []string `yaml:"redact_fields" toml:"redact_fields"`
+			} `yaml:"http" toml:"http"`
+		} `yaml:"log" toml:"log"`
+		// Metrics contains the configuration of the Prometheus metrics endpoint.
+		Metrics struct {
+			// Enabled indicates if the metrics are exposed.
+			Enabled bool `yaml:"enabled" toml:"enabled"`
+			// Address is the address of a dedicated metrics listener. In HTTP mode,
+			// when empty, the metrics are exposed in the "/metrics" path of the
+			// server, requiring authentication. In STDIO mode, it's required to
+			// expose the metrics.
+			Address string `yaml:"address" toml:"address"`
+		} `yaml:"metrics" toml:"metrics"`
+		// Audit contains the configuration of the audit log, which records every
+		// call of a tool that may change data.
+		Audit struct {
+			// Sinks are where the audit events are written. It can contain "file",
+			// "stdout" and "webhook". When empty, the audit log is disabled.
+			Sinks []string `yaml:"sinks" toml:"sinks"`
+			// FilePath is the JSON-lines file of the "file" sink.
+			FilePath string `yaml:"file" toml:"file"`
+			// WebhookURL is the endpoint receiving the events of the "webhook" sink.
+			WebhookURL string `yaml:"webhook_url" toml:"webhook_url"`
+			// WebhookHeaders contains "Name: value" headers sent to the webhook (e.g.
+			// an authorization header).
+			WebhookHeaders []string `yaml:"webhook_headers" toml:"webhook_headers"`
+			// RedactFields contains extra argument fields to redact, besides the
+			// default ones (e.g. "email", "token").
+			RedactFields []string `yaml:"redact_fields" toml:"redact_fields"`
+		} `yaml:"audit" toml:"audit"`
+		// OpenTelemetry contains the configuration for OpenTelemetry tracing, a
+		// vendor-neutral alternative to Datadog APM.
+		OpenTelemetry struct {
+			// Enabled indicates if the spans are exported with OTLP.
+			Enabled bool `yaml:"enabled" toml:"enabled"`
+			// Protocol is the OTLP protocol. It can be "http/protobuf" or "grpc".
+			Protocol string `yaml:"protocol" toml:"protocol"`
+			// ServiceName is the name of the service in the exported spans.
+			ServiceName string `yaml:"service_name" toml:"service_name"`
+		} `yaml:"opentelemetry" toml:"opentelemetry"`
 		// DatadogAPM contains the configuration for Datadog APM. This is useful for
 		// the MCP server in HTTP mode.
 		DatadogAPM struct {
 			// Enabled indicates if Datadog APM is enabled.
-			Enabled bool
+			Enabled bool `yaml:"enabled" toml:"enabled"`
 			// Service is the name of the service to be used in Datadog APM.
-			Service string
+			Service string `yaml:"service" toml:"service"`
 			// AgentHost is the host of the Datadog Agent.
-			AgentHost string
+			AgentHost string `yaml:"agent_host" toml:"agent_host"`
 			// AgentPort is the port of the Datadog Agent.
-			AgentPort string
+			AgentPort string `yaml:"agent_port" toml:"agent_port"`
 			// StatsdPort is the port of the DogStatsD Agent.
-			StatsdPort string
+			StatsdPort string `yaml:"statsd_port" toml:"statsd_port"`
 			// Environment is the environment to be used in Datadog APM.
-			Environment string
+			Environment string `yaml:"environment" toml:"environment"`
 			// Version is the version of the service to be used in Datadog APM.
-			Version string
-		}
+			Version string `yaml:"version" toml:"version"`
+		} `yaml:"datadog_apm" toml:"datadog_apm"`
 	}
 }
 
-func newResources() Resources {
+// DefaultProfile is the name of the profile of the top-level bearer token.
+const DefaultProfile = "default"
+
+// Profile is a named Teamwork installation.
+type Profile struct {
+	// Name identifies the installation in the tool calls.
+	Name string `yaml:"name" toml:"name"`
+	// URL is the URL of the installation (e.g. "https://example.teamwork.com").
+	// When empty, it's detected from the bearer token.
+	URL string `yaml:"url" toml:"url"`
+	// BearerToken is the bearer token of the installation.
+	BearerToken string `yaml:"bearer_token" toml:"bearer_token"`
+	// BearerTokenFile is the path of a file containing the bearer token.
+	BearerTokenFile string `yaml:"bearer_token_file" toml:"bearer_token_file"`
+	// BearerTokenCommand is a command printing the bearer token.
+	BearerTokenCommand string `yaml:"bearer_token_command" toml:"bearer_token_command"`
+}
+
+// NewResources returns the configuration merged from the defaults, the
+// configuration file and the environment variables, without loading any
+// resource: no secret command runs and no tracer or audit sink is started. Load
+// must be used to run the service.
+func NewResources(file *File) Resources {
 	var resources Resources
-	resources.Info.Version = getEnv("TW_MCP_VERSION", Version)
-	resources.Info.ServerAddress = getEnv("TW_MCP_SERVER_ADDRESS", ":8080")
-	resources.Info.Environment = getEnv("TW_MCP_ENV", "dev")
-	resources.Info.AWSRegion = getEnv("TW_MCP_AWS_REGION", "us-east-1")
-	resources.Info.MCPURL = strings.TrimSuffix(getEnv("TW_MCP_URL", "https://mcp.ai.teamwork.com"), "/")
-	resources.Info.APIURL = strings.TrimSuffix(getEnv("TW_MCP_API_URL", "https://teamwork.com"), "/")
-	resources.Info.HAProxyURL = getEnv("TW_MCP_HAPROXY_URL", "")
-	resources.Info.BearerToken = getEnv("TW_MCP_BEARER_TOKEN", "")
-	resources.Info.Log.Format = strings.ToLower(getEnv("TW_MCP_LOG_FORMAT", "text"))
-	resources.Info.Log.Level = strings.ToLower(getEnv("TW_MCP_LOG_LEVEL", "info"))
-	resources.Info.Log.SentryDSN = getEnv("TW_MCP_SENTRY_DSN", "")
+	setDefaults(&resources)
+	if file != nil {
+		// the file was validated when read
+		_ = file.decode(&resources.Info)
+	}
+
+	// environment variables take precedence over the configuration file
+	resources.Info.Version = getEnv("TW_MCP_VERSION", resources.Info.Version)
+	resources.Info.ServerAddress = getEnv("TW_MCP_SERVER_ADDRESS", resources.Info.ServerAddress)
+	resources.Info.Environment = getEnv("TW_MCP_ENV", resources.Info.Environment)
+	resources.Info.AWSRegion = getEnv("TW_MCP_AWS_REGION", resources.Info.AWSRegion)
+	resources.Info.MCPURL = strings.TrimSuffix(getEnv("TW_MCP_URL", resources.Info.MCPURL), "/")
+	resources.Info.APIURL = strings.TrimSuffix(getEnv("TW_MCP_API_URL", resources.Info.APIURL), "/")
+	resources.Info.HAProxyURL = getEnv("TW_MCP_HAPROXY_URL", resources.Info.HAProxyURL)
+	getEnvSecret("TW_MCP_BEARER_TOKEN", &resources.Info.BearerToken, &resources.Info.BearerTokenFile,
+		&resources.Info.BearerTokenCommand)
+	resources.Info.Profile = getEnv("TW_MCP_PROFILE", resources.Info.Profile)
+	resources.Info.OAuth.ClientID = getEnv("TW_MCP_OAUTH_CLIENT_ID", resources.Info.OAuth.ClientID)
+	resources.Info.OAuth.ClientSecret = getEnv("TW_MCP_OAUTH_CLIENT_SECRET", resources.Info.OAuth.ClientSecret)
+	resources.Info.OAuth.RedirectAddress = getEnv("TW_MCP_OAUTH_REDIRECT_ADDRESS",
+		resources.Info.OAuth.RedirectAddress)
+	resources.Info.OAuth.TokenFile = getEnv("TW_MCP_OAUTH_TOKEN_FILE", resources.Info.OAuth.TokenFile)
+	resources.Info.SecretCommandTTL = getEnvDuration("TW_MCP_SECRET_COMMAND_TTL", resources.Info.SecretCommandTTL)
+	resources.Info.Tools.Toolsets = getEnvList("TW_MCP_TOOLSETS", resources.Info.Tools.Toolsets)
+	resources.Info.Tools.ReadOnly = getEnvBool("TW_MCP_READ_ONLY", resources.Info.Tools.ReadOnly)
+	resources.Info.Tools.AllowDelete = getEnvBool("TW_MCP_ALLOW_DELETE", resources.Info.Tools.AllowDelete)
+	resources.Info.Tools.Include = getEnvList("TW_MCP_TOOLS", resources.Info.Tools.Include)
+	resources.Info.Tools.Exclude = getEnvList("TW_MCP_EXCLUDE_TOOLS", resources.Info.Tools.Exclude)
+	resources.Info.Sessions.Stateful = getEnvBool("TW_MCP_SESSIONS_STATEFUL", resources.Info.Sessions.Stateful)
+	resources.Info.Sessions.TTL = getEnvDuration("TW_MCP_SESSIONS_TTL", resources.Info.Sessions.TTL)
+	resources.Info.Sessions.EventStore = strings.ToLower(getEnv("TW_MCP_SESSIONS_EVENT_STORE",
+		resources.Info.Sessions.EventStore))
+	resources.Info.Sessions.EventStorePath = getEnv("TW_MCP_SESSIONS_EVENT_STORE_PATH",
+		resources.Info.Sessions.EventStorePath)
+	resources.Info.RateLimit.Enabled = getEnvBool("TW_MCP_RATE_LIMIT_ENABLED", resources.Info.RateLimit.Enabled)
+	resources.Info.RateLimit.UserRead = getEnvInt("TW_MCP_RATE_LIMIT_USER_READ", resources.Info.RateLimit.UserRead)
+	resources.Info.RateLimit.UserWrite = getEnvInt("TW_MCP_RATE_LIMIT_USER_WRITE", resources.Info.RateLimit.UserWrite)
+	resources.Info.RateLimit.InstallationRead = getEnvInt("TW_MCP_RATE_LIMIT_INSTALLATION_READ",
+		resources.Info.RateLimit.InstallationRead)
+	resources.Info.RateLimit.InstallationWrite = getEnvInt("TW_MCP_RATE_LIMIT_INSTALLATION_WRITE",
+		resources.Info.RateLimit.InstallationWrite)
+	resources.Info.Retry.MaxRetries = getEnvInt("TW_MCP_RETRY_MAX_RETRIES", resources.Info.Retry.MaxRetries)
+	resources.Info.Retry.Budget = getEnvDuration("TW_MCP_RETRY_BUDGET", resources.Info.Retry.Budget)
+	resources.Info.AuthCache.Size = getEnvInt("TW_MCP_AUTH_CACHE_SIZE", resources.Info.AuthCache.Size)
+	resources.Info.AuthCache.TTL = getEnvDuration("TW_MCP_AUTH_CACHE_TTL", resources.Info.AuthCache.TTL)
+	resources.Info.AuthCache.NegativeTTL = getEnvDuration("TW_MCP_AUTH_CACHE_NEGATIVE_TTL",
+		resources.Info.AuthCache.NegativeTTL)
+	resources.Info.Log.Format = strings.ToLower(getEnv("TW_MCP_LOG_FORMAT", resources.Info.Log.Format))
+	resources.Info.Log.Level = strings.ToLower(getEnv("TW_MCP_LOG_LEVEL", resources.Info.Log.Level))
+	getEnvSecret("TW_MCP_SENTRY_DSN", &resources.Info.Log.SentryDSN, &resources.Info.Log.SentryDSNFile,
+		&resources.Info.Log.SentryDSNCommand)
+	resources.Info.Log.HTTP.Mode = strings.ToLower(getEnv("TW_MCP_LOG_HTTP_MODE", resources.Info.Log.HTTP.Mode))
+	resources.Info.Log.HTTP.MaxBodySize = getEnvInt("TW_MCP_LOG_HTTP_MAX_BODY_SIZE", resources.Info.Log.HTTP.MaxBodySize)
+	resources.Info.Log.HTTP.SampleRate = getEnvFloat("TW_MCP_LOG_HTTP_SAMPLE_RATE", resources.Info.Log.HTTP.SampleRate)
+	resources.Info.Log.HTTP.RedactHeaders = getEnvList("TW_MCP_LOG_HTTP_REDACT_HEADERS",
+		resources.Info.Log.HTTP.RedactHeaders)
+	resources.Info.Log.HTTP.RedactFields = getEnvList("TW_MCP_LOG_HTTP_REDACT_FIELDS",
+		resources.Info.Log.HTTP.RedactFields)
+	resources.Info.Metrics.Enabled = getEnvBool("TW_MCP_METRICS_ENABLED", resources.Info.Metrics.Enabled)
+	resources.Info.Metrics.Address = getEnv("TW_MCP_METRICS_ADDRESS", resources.Info.Metrics.Address)
+	resources.Info.Audit.Sinks = getEnvList("TW_MCP_AUDIT_SINKS", resources.Info.Audit.Sinks)
+	resources.Info.Audit.FilePath = getEnv("TW_MCP_AUDIT_FILE", resources.Info.Audit.FilePath)
+	resources.Info.Audit.WebhookURL = getEnv("TW_MCP_AUDIT_WEBHOOK_URL", resources.Info.Audit.WebhookURL)
+	resources.Info.Audit.WebhookHeaders = getEnvList("TW_MCP_AUDIT_WEBHOOK_HEADERS",
+		resources.Info.Audit.WebhookHeaders)
+	resources.Info.Audit.RedactFields = getEnvList("TW_MCP_AUDIT_REDACT_FIELDS", resources.Info.Audit.RedactFields)
+
+	// https://opentelemetry.io/docs/specs/otel/protocol/exporter/
+	resources.Info.OpenTelemetry.Enabled = getEnvBool("TW_MCP_OTEL_ENABLED", resources.Info.OpenTelemetry.Enabled)
+	resources.Info.OpenTelemetry.Protocol = getEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+		getEnv("OTEL_EXPORTER_OTLP_PROTOCOL", resources.Info.OpenTelemetry.Protocol))
+	resources.Info.OpenTelemetry.ServiceName = getEnv("OTEL_SERVICE_NAME", resources.Info.OpenTelemetry.ServiceName)
 
 	// https://docs.datadoghq.com/containers/docker/apm/?tab=linux#docker-apm-agent-environment-variables
-	resources.Info.DatadogAPM.Enabled = strings.EqualFold(getEnv("DD_APM_TRACING_ENABLED", "false"), "true")
-	resources.Info.DatadogAPM.Service = getEnv("DD_SERVICE", "mcp-server")
-	resources.Info.DatadogAPM.AgentHost = getEnv("DD_AGENT_HOST", "localhost")
-	resources.Info.DatadogAPM.AgentPort = getEnv("DD_TRACE_AGENT_PORT", "8126")
-	resources.Info.DatadogAPM.StatsdPort = getEnv("DD_DOGSTATSD_PORT", "8125")
-	resources.Info.DatadogAPM.Environment = getEnv("DD_ENV", resources.Info.Environment)
-	resources.Info.DatadogAPM.Version = getEnv("DD_VERSION", resources.Info.Version)
+	resources.Info.DatadogAPM.Enabled = getEnvBool("DD_APM_TRACING_ENABLED", resources.Info.DatadogAPM.Enabled)
+	resources.Info.DatadogAPM.Service = getEnv("DD_SERVICE", resources.Info.DatadogAPM.Service)
+	resources.Info.DatadogAPM.AgentHost = getEnv("DD_AGENT_HOST", resources.Info.DatadogAPM.AgentHost)
+	resources.Info.DatadogAPM.AgentPort = getEnv("DD_TRACE_AGENT_PORT", resources.Info.DatadogAPM.AgentPort)
+	resources.Info.DatadogAPM.StatsdPort = getEnv("DD_DOGSTATSD_PORT", resources.Info.DatadogAPM.StatsdPort)
+	resources.Info.DatadogAPM.Environment = getEnv("DD_ENV", resources.Info.DatadogAPM.Environment)
+	resources.Info.DatadogAPM.Version = getEnv("DD_VERSION", resources.Info.DatadogAPM.Version)
+	if resources.Info.DatadogAPM.Environment == "" {
+		resources.Info.DatadogAPM.Environment = resources.Info.Environment
+	}
+	if resources.Info.DatadogAPM.Version == "" {
+		resources.Info.DatadogAPM.Version = resources.Info.Version
+	}
 
 	return resources
 }
 
+// setDefaults sets the default configuration, used for the values missing in
+// the configuration file and environment variables.
+func setDefaults(resources *Resources) {
+	resources.Info.Version = Version
+	resources.Info.ServerAddress = ":8080"
+	resources.Info.Environment = "dev"
+	resources.Info.AWSRegion = "us-east-1"
+	resources.Info.MCPURL = "https://mcp.ai.teamwork.com"
+	resources.Info.APIURL = "https://teamwork.com"
+	resources.Info.Profile = DefaultProfile
+	resources.Info.OAuth.RedirectAddress = oauth.DefaultRedirectAddress
+	// without a user config directory the token file must be configured
+	resources.Info.OAuth.TokenFile, _ = oauth.DefaultTokenFile()
+	resources.Info.SecretCommandTTL = secret.DefaultCommandTTL
+	resources.Info.Tools.Toolsets = []string{"all"}
+	resources.Info.Sessions.TTL = 30 * time.Minute
+	resources.Info.Sessions.EventStore = "memory"
+	resources.Info.RateLimit.UserRead = 300
+	resources.Info.RateLimit.UserWrite = 60
+	resources.Info.RateLimit.InstallationRead = 1200
+	resources.Info.RateLimit.InstallationWrite = 240
+	resources.Info.Retry.MaxRetries = network.DefaultRetryMaxRetries
+	resources.Info.Retry.Budget = network.DefaultRetryBudget
+	resources.Info.AuthCache.Size = 10000
+	resources.Info.AuthCache.TTL = 5 * time.Minute
+	resources.Info.AuthCache.NegativeTTL = 30 * time.Second
+	resources.Info.Log.Format = "text"
+	resources.Info.Log.Level = "info"
+	resources.Info.Log.HTTP.Mode = string(network.LoggingModeHeaders)
+	resources.Info.Log.HTTP.MaxBodySize = network.DefaultLoggingMaxBodySize
+	resources.Info.Log.HTTP.SampleRate = 1
+	resources.Info.Metrics.Address = metrics.DefaultAddress
+	resources.Info.Audit.FilePath = "audit.log"
+	resources.Info.OpenTelemetry.Protocol = otlpProtocolHTTP
+	resources.Info.OpenTelemetry.ServiceName = "mcp-server"
+	resources.Info.DatadogAPM.Service = "mcp-server"
+	resources.Info.DatadogAPM.AgentHost = "localhost"
+	resources.Info.DatadogAPM.AgentPort = "8126"
+	resources.Info.DatadogAPM.StatsdPort = "8125"
+}
+
 // Logger returns the logger resource.
 func (r *Resources) Logger() *slog.Logger {
 	return r.logger
 }
 
+// Metrics returns the Prometheus metrics of the MCP server.
+func (r *Resources) Metrics() *metrics.Metrics {
+	return r.metrics
+}
+
 // TeamworkHTTPClient returns the HTTP client to be used to make requests to
 // Teamwork API.
 func (r *Resources) TeamworkHTTPClient() *http.Client {
@@ -118,14 +429,158 @@ func (r *Resources) TeamworkEngine() *twapi.Engine {
 	return r.teamworkEngine
 }
 
+// BearerToken returns the current bearer token to be used to authenticate with
+// Teamwork API, loaded from the environment variable, the file or the command.
+// When none is configured, the access token obtained with the login command is
+// used, refreshing it when needed.
+func (r *Resources) BearerToken(ctx context.Context) (string, error) {
+	switch {
+	case r.oauthToken != nil:
+		return r.oauthToken.AccessToken(ctx)
+	case r.bearerToken != nil:
+		return r.bearerToken.Get(ctx)
+	default:
+		return r.Info.BearerToken, nil
+	}
+}
+
+// ProfileBearerToken returns the current bearer token of the given profile.
+// The DefaultProfile uses BearerToken.
+func (r *Resources) ProfileBearerToken(ctx context.Context, name string) (string, error) {
+	if name == DefaultProfile {
+		return r.BearerToken(ctx)
+	}
+	token, ok := r.profileTokens[name]
+	if !ok {
+		return "", fmt.Errorf("unknown profile %q", name)
+	}
+	return token.Get(ctx)
+}
+
+// Profile returns the configuration of the given profile. The DefaultProfile
+// has only a name.
+func (r *Resources) Profile(name string) (Profile, bool) {
+	if name == DefaultProfile {
+		return Profile{Name: DefaultProfile}, true
+	}
+	for _, profile := range r.Info.Profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// ProfileNames returns the names of the configured profiles. The
+// DefaultProfile is included when a top-level bearer token is configured, or
+// when there are no other profiles.
+func (r *Resources) ProfileNames() []string {
+	var names []string
+	if len(r.Info.Profiles) == 0 || r.bearerToken.IsSet() || r.oauthToken != nil && r.oauthToken.LoggedIn() {
+		names = append(names, DefaultProfile)
+	}
+	for _, profile := range r.Info.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// InvalidateBearerToken reports that the given bearer token was rejected by
+// Teamwork API, so the access token obtained with the login command is
+// refreshed on the next call to BearerToken.
+func (r *Resources) InvalidateBearerToken(token string) {
+	if r.oauthToken != nil {
+		r.oauthToken.Invalidate(token)
+	}
+}
+
+// OAuthConfig returns the configuration of the Teamwork app login flow.
+func (r *Resources) OAuthConfig() oauth.Config {
+	config := oauth.NewConfig(r.Info.APIURL, r.Info.OAuth.ClientID, r.Info.OAuth.ClientSecret)
+	config.HTTPClient = r.teamworkHTTPClient
+	return config
+}
+
 // DeskClient returns the Teamwork Desk Client for use.
 func (r *Resources) DeskClient() *desksdk.Client {
 	return r.deskClient
 }
 
+// DeskAPIClient returns the client for the Teamwork Desk API operations not
+// supported by the Desk SDK.
+func (r *Resources) DeskAPIClient() *deskapi.Client {
+	return r.deskAPIClient
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
 	}
 	return fallback
 }
+
+// getEnvSecret reads the sources of a secret from the environment variable of
+// the key and its "_FILE" and "_COMMAND" variants. When any of them is set, the
+// sources of the configuration file are replaced, otherwise a value from the
+// file would take precedence over a file or command from the environment.
+func getEnvSecret(key string, value, file, command *string) {
+	envValue, hasValue := os.LookupEnv(key)
+	envFile, hasFile := os.LookupEnv(key + "_FILE")
+	envCommand, hasCommand := os.LookupEnv(key + "_COMMAND")
+	if hasValue || hasFile || hasCommand {
+		*value, *file, *command = envValue, envFile, envCommand
+	}
+}
+
+func getEnvList(key string, fallback []string) []string {
+	if value, ok := os.LookupEnv(key); ok {
+		return SplitList(value)
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		return strings.EqualFold(value, "true")
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if number, err := strconv.Atoi(value); err == nil {
+			return number
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if value, ok := os.LookupEnv(key); ok {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return fallback
+}
+
+// SplitList splits a comma-separated list, trimming spaces and ignoring empty
+// items.
+func SplitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,6 +1,27 @@
 package config
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// List of scope suffixes that restrict a product scope to read-only or write
+// tools. A scope without suffix (e.g. "projects") grants access to all tools of
+// the product.
+const (
+	scopeSuffixRead  = ":read"
+	scopeSuffixWrite = ":write"
+)
+
+// scopeProducts maps the tool name prefix to the product scope.
+var scopeProducts = map[string]string{
+	"twprojects": "projects",
+	"twdesk":     "desk",
+}
 
 type scopesKey struct{}
 
@@ -16,3 +37,80 @@ func scopes(ctx context.Context) []string {
 	}
 	return scopes
 }
+
+// toolScopes returns the scopes that grant access to the tool. Tools that
+// don't belong to a product (like the meta-tools) don't require any scope, so
+// it returns nil for them.
+//
+// Read-only tools are granted by the product scope (e.g. "projects") or by the
+// read and write scopes ("projects:read", "projects:write"). Other tools are
+// only granted by the product and write scopes.
+func toolScopes(tool *mcp.Tool) []string {
+	prefix, _, found := strings.Cut(tool.Name, "-")
+	if !found {
+		return nil
+	}
+	product, ok := scopeProducts[prefix]
+	if !ok {
+		return nil
+	}
+	if tool.Annotations != nil && tool.Annotations.ReadOnlyHint {
+		return []string{product, product + scopeSuffixRead, product + scopeSuffixWrite}
+	}
+	return []string{product, product + scopeSuffixWrite}
+}
+
+// scopesAllowTool checks if any of the scopes grants access to the tool. When
+// there are no scopes (e.g. STDIO mode with a personal token), there are no
+// restrictions.
+func scopesAllowTool(scopes []string, tool *mcp.Tool) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	required := toolScopes(tool)
+	if required == nil {
+		return true
+	}
+	return slices.ContainsFunc(required, func(scope string) bool {
+		return slices.Contains(scopes, scope)
+	})
+}
+
+// scopeMiddleware filters the list of tools and rejects tool calls according
+// to the scopes of the Bearer Token. The tools map is used to check the
+// annotations of the called tool, as the request only contains its name.
+func scopeMiddleware(tools map[string]*mcp.Tool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			scopes := scopes(ctx)
+			if len(scopes) == 0 {
+				return next(ctx, method, req)
+			}
+
+			if callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+				// unknown tools are handled by the MCP server
+				if tool, ok := tools[callToolParams.Name]; ok && !scopesAllowTool(scopes, tool) {
+					return nil, NewJSONRPCError(JSONRPCErrorCodeForbidden,
+						fmt.Sprintf("tool %q is not allowed by the token scopes", tool.Name),
+						map[string]any{
+							"tool":           tool.Name,
+							"requiredScopes": toolScopes(tool),
+						},
+					)
+				}
+			}
+
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			if listToolsResult, ok := result.(*mcp.ListToolsResult); ok && listToolsResult != nil {
+				listToolsResult.Tools = slices.DeleteFunc(listToolsResult.Tools, func(tool *mcp.Tool) bool {
+					return !scopesAllowTool(scopes, tool)
+				})
+			}
+			return result, nil
+		}
+	}
+}
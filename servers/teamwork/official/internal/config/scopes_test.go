@@ -0,0 +1,178 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestScopesAllowTool(t *testing.T) {
+	readTool := &mcp.Tool{
+		Name:        "twprojects-get_project",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}
+	writeTool := &mcp.Tool{
+		Name:        "twprojects-create_project",
+		Annotations: &mcp.ToolAnnotations{},
+	}
+	deskTool := &mcp.Tool{
+		Name:        "twdesk-get_ticket",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}
+	metaTool := &mcp.Tool{
+		Name:        "list_available_toolsets",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+	}
+
+	tests := []struct {
+		name   string
+		scopes []string
+		tool   *mcp.Tool
+		want   bool
+	}{{
+		name: "no scopes",
+		tool: writeTool,
+		want: true,
+	}, {
+		name:   "product scope allows read tool",
+		scopes: []string{"projects"},
+		tool:   readTool,
+		want:   true,
+	}, {
+		name:   "product scope allows write tool",
+		scopes: []string{"projects"},
+		tool:   writeTool,
+		want:   true,
+	}, {
+		name:   "read scope allows read tool",
+		scopes: []string{"projects:read"},
+		tool:   readTool,
+		want:   true,
+	}, {
+		name:   "read scope denies write tool",
+		scopes: []string{"projects:read"},
+		tool:   writeTool,
+		want:   false,
+	}, {
+		name:   "write scope allows read tool",
+		scopes: []string{"projects:write"},
+		tool:   readTool,
+		want:   true,
+	}, {
+		name:   "write scope allows write tool",
+		scopes: []string{"projects:write"},
+		tool:   writeTool,
+		want:   true,
+	}, {
+		name:   "other product scope denies tool",
+		scopes: []string{"projects"},
+		tool:   deskTool,
+		want:   false,
+	}, {
+		name:   "meta-tools don't require scopes",
+		scopes: []string{"desk"},
+		tool:   metaTool,
+		want:   true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopesAllowTool(tt.scopes, tt.tool); got != tt.want {
+				t.Errorf("scopesAllowTool() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeMiddleware(t *testing.T) {
+	newTool := func(name string, readOnly bool) toolsets.ToolWrapper {
+		return toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name:        name,
+				Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+				InputSchema: &jsonschema.Schema{Type: "object"},
+			},
+			Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			},
+		}
+	}
+
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset("example", "Example toolset.").
+		AddReadTools(
+			newTool("twprojects-get_project", true),
+			newTool("twdesk-get_ticket", true),
+		).
+		AddWriteTools(
+			newTool("twprojects-create_project", false),
+		))
+	if err := group.EnableToolsets(toolsets.MethodAll); err != nil {
+		t.Fatalf("failed to enable toolsets: %v", err)
+	}
+
+	mcpServer := NewMCPServer(Resources{}, group)
+	// simulate the scopes injected by the HTTP authentication middleware
+	mcpServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(WithScopes(ctx, []string{"projects:read"}), method, req)
+		}
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect to server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	t.Run("tools/list", func(t *testing.T) {
+		result, err := clientSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+		if err != nil {
+			t.Fatalf("failed to list tools: %v", err)
+		}
+		var names []string
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		if !slices.Equal(names, []string{"twprojects-get_project"}) {
+			t.Errorf("unexpected tools: %v", names)
+		}
+	})
+
+	t.Run("tools/call allowed", func(t *testing.T) {
+		_, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+			Name: "twprojects-get_project",
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	for _, toolName := range []string{"twprojects-create_project", "twdesk-get_ticket"} {
+		t.Run("tools/call denied "+toolName, func(t *testing.T) {
+			_, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+				Name: toolName,
+			})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			expected := NewJSONRPCError(JSONRPCErrorCodeForbidden, "", nil)
+			if !errors.Is(err, expected) {
+				t.Errorf("expected forbidden error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamwork/mcp/internal/secret"
+)
+
+// loadSecrets prepares the secrets that can be loaded from a file or a command.
+// The bearer token is loaded on demand, so it can be rotated, while the Sentry
+// DSN is only needed on startup.
+func (r *Resources) loadSecrets(ctx context.Context) error {
+	r.bearerToken = secret.New(secret.Source{
+		Value:      r.Info.BearerToken,
+		File:       r.Info.BearerTokenFile,
+		Command:    r.Info.BearerTokenCommand,
+		CommandTTL: r.Info.SecretCommandTTL,
+	})
+
+	r.profileTokens = make(map[string]*secret.Secret, len(r.Info.Profiles))
+	for _, profile := range r.Info.Profiles {
+		r.profileTokens[profile.Name] = secret.New(secret.Source{
+			Value:      profile.BearerToken,
+			File:       profile.BearerTokenFile,
+			Command:    profile.BearerTokenCommand,
+			CommandTTL: r.Info.SecretCommandTTL,
+		})
+	}
+
+	sentryDSN, err := secret.New(secret.Source{
+		Value:   r.Info.Log.SentryDSN,
+		File:    r.Info.Log.SentryDSNFile,
+		Command: r.Info.Log.SentryDSNCommand,
+	}).Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load Sentry DSN: %w", err)
+	}
+	r.Info.Log.SentryDSN = sentryDSN
+	return nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSecrets(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("secret-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	dsnFile := filepath.Join(dir, "sentry-dsn")
+	if err := os.WriteFile(dsnFile, []byte("https://key@sentry.example.com/1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write Sentry DSN file: %v", err)
+	}
+	t.Setenv("TW_MCP_BEARER_TOKEN_FILE", tokenFile)
+	t.Setenv("TW_MCP_SENTRY_DSN_FILE", dsnFile)
+
+	resources := NewResources(nil)
+	if err := resources.loadSecrets(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, err := resources.BearerToken(t.Context()); err != nil || token != "secret-token" {
+		t.Errorf("unexpected bearer token %q (%v)", token, err)
+	}
+	if resources.Info.Log.SentryDSN != "https://key@sentry.example.com/1" {
+		t.Errorf("unexpected Sentry DSN %q", resources.Info.Log.SentryDSN)
+	}
+
+	// the token is read again when rotated
+	if err := os.WriteFile(tokenFile, []byte("rotated-token-value\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	if token, err := resources.BearerToken(t.Context()); err != nil || token != "rotated-token-value" {
+		t.Errorf("unexpected bearer token %q (%v)", token, err)
+	}
+
+	t.Setenv("TW_MCP_SENTRY_DSN_FILE", filepath.Join(dir, "missing"))
+	resources = NewResources(nil)
+	if err := resources.loadSecrets(t.Context()); err == nil {
+		t.Error("expected an error for a missing Sentry DSN file")
+	}
+}
+
+func TestNewResourcesWithoutSecrets(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "marker")
+	t.Setenv("TW_MCP_SENTRY_DSN_COMMAND", "touch "+marker+" && echo https://key@sentry.example.com/1")
+
+	resources := NewResources(nil)
+	if resources.Info.Log.SentryDSNCommand == "" {
+		t.Fatal("expected the Sentry DSN command in the configuration")
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("unexpected run of the secret command: %v", err)
+	}
+}
+
+func TestLoadSecretsFileOverriddenByEnv(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("env-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte("bearer_token: file-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv("TW_MCP_BEARER_TOKEN_FILE", tokenFile)
+
+	file, err := ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	resources := NewResources(file)
+	if err := resources.loadSecrets(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, err := resources.BearerToken(t.Context()); err != nil || token != "env-token" {
+		t.Errorf("unexpected bearer token %q (%v)", token, err)
+	}
+
+	// the token of the environment file is rotated
+	if err := os.WriteFile(tokenFile, []byte("rotated-env-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	if token, err := resources.BearerToken(t.Context()); err != nil || token != "rotated-env-token" {
+		t.Errorf("unexpected bearer token %q (%v)", token, err)
+	}
+}
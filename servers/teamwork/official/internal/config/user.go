@@ -0,0 +1,23 @@
+package config
+
+import "context"
+
+type userKey struct{}
+
+type user struct {
+	id             int64
+	installationID int64
+}
+
+// WithUser returns a new context with the authenticated user and its
+// installation.
+func WithUser(ctx context.Context, userID, installationID int64) context.Context {
+	return context.WithValue(ctx, userKey{}, user{id: userID, installationID: installationID})
+}
+
+// UserFromContext returns the authenticated user and its installation from the
+// context, if any.
+func UserFromContext(ctx context.Context) (userID, installationID int64, ok bool) {
+	u, ok := ctx.Value(userKey{}).(user)
+	return u.id, u.installationID, ok
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+// argumentViolation describes a tool argument that doesn't match the input
+// schema of the tool. The pointer is the JSON pointer (RFC 6901) of the
+// offending value inside the arguments, where an empty pointer refers to the
+// arguments object itself.
+type argumentViolation struct {
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+// argumentValidator validates a value against a schema node. The jsonschema
+// package stops at the first error, so the schema is split in nodes: each node
+// validates only its own keywords, and the properties and items are validated
+// by the child nodes. This allows to report every violation.
+type argumentValidator struct {
+	local      *jsonschema.Resolved
+	required   []string
+	properties map[string]*argumentValidator
+	items      *argumentValidator
+}
+
+func newArgumentValidator(schema *jsonschema.Schema) (*argumentValidator, error) {
+	// references can't be resolved once the schema is split, so they are
+	// validated as a whole
+	if schema.Ref != "" || schema.DynamicRef != "" || len(schema.Defs) > 0 {
+		local, err := schema.Resolve(nil)
+		if err != nil {
+			return nil, err
+		}
+		return &argumentValidator{local: local}, nil
+	}
+
+	validator := &argumentValidator{
+		required: schema.Required,
+	}
+
+	localSchema := *schema
+	localSchema.Required = nil
+	localSchema.Items = nil
+	localSchema.Properties = nil
+	if len(schema.Properties) > 0 {
+		validator.properties = make(map[string]*argumentValidator, len(schema.Properties))
+		// keep the declared properties, so they aren't considered additional
+		localSchema.Properties = make(map[string]*jsonschema.Schema, len(schema.Properties))
+		for name, propertySchema := range schema.Properties {
+			propertyValidator, err := newArgumentValidator(propertySchema)
+			if err != nil {
+				return nil, fmt.Errorf("property %q: %w", name, err)
+			}
+			validator.properties[name] = propertyValidator
+			localSchema.Properties[name] = &jsonschema.Schema{}
+		}
+	}
+	if schema.Items != nil {
+		itemsValidator, err := newArgumentValidator(schema.Items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		validator.items = itemsValidator
+	}
+
+	local, err := localSchema.Resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	validator.local = local
+	return validator, nil
+}
+
+func (v *argumentValidator) validate(pointer string, instance any, violations []argumentViolation) []argumentViolation {
+	if err := v.local.Validate(instance); err != nil {
+		violations = append(violations, argumentViolation{
+			Pointer: pointer,
+			Message: strings.TrimPrefix(err.Error(), "validating root: "),
+		})
+	}
+
+	switch value := instance.(type) {
+	case map[string]any:
+		for _, name := range v.required {
+			if _, ok := value[name]; !ok {
+				violations = append(violations, argumentViolation{
+					Pointer: pointer + "/" + escapeJSONPointer(name),
+					Message: "required property is missing",
+				})
+			}
+		}
+		// sort the properties so the violations are reported in a stable order
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if propertyValidator, ok := v.properties[name]; ok {
+				violations = propertyValidator.validate(pointer+"/"+escapeJSONPointer(name), value[name], violations)
+			}
+		}
+	case []any:
+		if v.items != nil {
+			for i, item := range value {
+				violations = v.items.validate(fmt.Sprintf("%s/%d", pointer, i), item, violations)
+			}
+		}
+	}
+	return violations
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// validateArguments validates the tool call arguments, returning all the
+// violations found.
+func (v *argumentValidator) validateArguments(arguments json.RawMessage) []argumentViolation {
+	if len(arguments) == 0 || string(arguments) == "null" {
+		arguments = json.RawMessage("{}")
+	}
+	var instance any
+	if err := json.Unmarshal(arguments, &instance); err != nil {
+		return []argumentViolation{{Message: fmt.Sprintf("failed to decode arguments: %v", err)}}
+	}
+	return v.validate("", instance, nil)
+}
+
+// inputSchema returns the input schema of the tool. Tools can define it with
+// any value that encodes to a JSON schema.
+func inputSchema(tool *mcp.Tool) (*jsonschema.Schema, error) {
+	if schema, ok := tool.InputSchema.(*jsonschema.Schema); ok {
+		return schema, nil
+	}
+	encoded, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(encoded, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// ValidateToolArguments validates the arguments of a tool call against the
+// input schema of the tool, so a client can reject an invalid call before
+// sending it. The error lists every violation.
+func ValidateToolArguments(tool *mcp.Tool, arguments map[string]any) error {
+	if tool.InputSchema == nil {
+		return nil
+	}
+	schema, err := inputSchema(tool)
+	if err != nil {
+		return fmt.Errorf("failed to decode input schema for tool %q: %w", tool.Name, err)
+	}
+	validator, err := newArgumentValidator(schema)
+	if err != nil {
+		return fmt.Errorf("failed to resolve input schema for tool %q: %w", tool.Name, err)
+	}
+	encoded, err := json.Marshal(arguments)
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	violations := validator.validateArguments(encoded)
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		messages = append(messages, pointer+": "+violation.Message)
+	}
+	return fmt.Errorf("invalid arguments for tool %q: %s", tool.Name, strings.Join(messages, "; "))
+}
+
+// validationMiddleware validates the arguments of tool calls against the input
+// schema of the tool before dispatching them, once the numeric strings are
+// coerced to the declared types (see toolsets.CoerceArguments). Invalid calls
+// are rejected with an invalid params error listing every violation, so the
+// handlers don't need to check the types of the arguments.
+//
+// It panics if the input schema of a tool can't be resolved, as this is a
+// programming error.
+func validationMiddleware(tools map[string]*mcp.Tool) mcp.Middleware {
+	validators := make(map[string]*argumentValidator, len(tools))
+	schemas := make(map[string]*jsonschema.Schema, len(tools))
+	for name, tool := range tools {
+		if tool.InputSchema == nil {
+			continue
+		}
+		schema, err := inputSchema(tool)
+		if err != nil {
+			panic(fmt.Sprintf("failed to decode input schema for tool %q: %v", name, err))
+		}
+		validator, err := newArgumentValidator(schema)
+		if err != nil {
+			panic(fmt.Sprintf("failed to resolve input schema for tool %q: %v", name, err))
+		}
+		validators[name] = validator
+		schemas[name] = schema
+	}
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callToolParams, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			// unknown tools are handled by the MCP server
+			validator, ok := validators[callToolParams.Name]
+			if !ok {
+				return next(ctx, method, req)
+			}
+			// numeric strings are accepted for compatibility, and the handlers
+			// receive the coerced arguments
+			if arguments, err := coerceArguments(schemas[callToolParams.Name], callToolParams.Arguments); err == nil {
+				callToolParams.Arguments = arguments
+			}
+			if violations := validator.validateArguments(callToolParams.Arguments); len(violations) > 0 {
+				return nil, NewJSONRPCError(JSONRPCErrorCodeInvalidParams,
+					fmt.Sprintf("invalid arguments for tool %q", callToolParams.Name),
+					map[string]any{
+						"tool":       callToolParams.Name,
+						"violations": violations,
+					},
+				)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// coerceArguments coerces the raw arguments of a tool call to the types of the
+// input schema. Invalid JSON is returned as an error, to be reported by the
+// validation.
+func coerceArguments(schema *jsonschema.Schema, arguments json.RawMessage) (json.RawMessage, error) {
+	if len(arguments) == 0 || string(arguments) == "null" {
+		return arguments, nil
+	}
+	var instance any
+	if err := json.Unmarshal(arguments, &instance); err != nil {
+		return nil, err
+	}
+	return json.Marshal(toolsets.CoerceArguments(schema, instance))
+}
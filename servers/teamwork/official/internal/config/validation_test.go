@@ -0,0 +1,266 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestArgumentValidator(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"id":     {Type: "integer"},
+			"name":   {Type: "string", MinLength: jsonschema.Ptr(1)},
+			"status": {Type: "string", Enum: []any{"active", "archived"}},
+			"tags": {
+				Type:  "array",
+				Items: &jsonschema.Schema{Type: "integer"},
+			},
+			"parent": {
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"a/b": {Type: "integer"},
+				},
+				Required: []string{"a/b"},
+			},
+		},
+		Required: []string{"id"},
+	}
+	validator, err := newArgumentValidator(schema)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		arguments string
+		want      []string
+	}{{
+		name:      "valid",
+		arguments: `{"id":1,"name":"a","status":"active","tags":[1,2],"parent":{"a/b":1}}`,
+	}, {
+		name:      "unknown properties",
+		arguments: `{"id":1,"unknown":"a"}`,
+	}, {
+		name:      "missing arguments",
+		arguments: ``,
+		want:      []string{"/id"},
+	}, {
+		name:      "every violation",
+		arguments: `{"id":"1","name":"","status":"deleted","tags":[1,"2",3,"4"],"parent":{}}`,
+		want:      []string{"/id", "/name", "/parent/a~1b", "/status", "/tags/1", "/tags/3"},
+	}, {
+		name:      "not an object",
+		arguments: `[1]`,
+		want:      []string{""},
+	}, {
+		name:      "malformed",
+		arguments: `{"id":`,
+		want:      []string{""},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pointers []string
+			for _, violation := range validator.validateArguments(json.RawMessage(tt.arguments)) {
+				if violation.Message == "" {
+					t.Errorf("missing message for pointer %q", violation.Pointer)
+				}
+				pointers = append(pointers, violation.Pointer)
+			}
+			if !slices.Equal(pointers, tt.want) {
+				t.Errorf("unexpected violations %q, want %q", pointers, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateToolArguments(t *testing.T) {
+	// clients receive the input schema as a generic JSON value
+	tool := &mcp.Tool{
+		Name: "create_task",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":        map[string]any{"type": "string"},
+				"tasklist_id": map[string]any{"type": "integer"},
+			},
+			"required": []any{"name", "tasklist_id"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		wantErr   string
+	}{{
+		name:      "valid",
+		arguments: map[string]any{"name": "a", "tasklist_id": 1},
+	}, {
+		name:      "every violation",
+		arguments: map[string]any{"tasklist_id": "1"},
+		wantErr: `invalid arguments for tool "create_task": /name: required property is missing; ` +
+			`/tasklist_id: type: 1 has type "string", want "integer"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToolArguments(tool, tt.arguments)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("unexpected error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidationMiddleware(t *testing.T) {
+	var received json.RawMessage
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset("example", "Example toolset.").
+		AddReadTools(toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name: "twprojects-get_project",
+				Annotations: &mcp.ToolAnnotations{
+					ReadOnlyHint: true,
+				},
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"id": {Type: "integer"},
+					},
+					Required: []string{"id"},
+				},
+			},
+			Handler: func(_ context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				received = request.Params.Arguments
+				return &mcp.CallToolResult{}, nil
+			},
+		}))
+	if err := group.EnableToolsets(toolsets.MethodAll); err != nil {
+		t.Fatalf("failed to enable toolsets: %v", err)
+	}
+
+	mcpServer := NewMCPServer(Resources{}, group)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect to server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	t.Run("valid arguments", func(t *testing.T) {
+		_, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+			Name:      "twprojects-get_project",
+			Arguments: map[string]any{"id": 1},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("numeric string", func(t *testing.T) {
+		_, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+			Name:      "twprojects-get_project",
+			Arguments: map[string]any{"id": "1"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(received) != `{"id":1}` {
+			t.Errorf("unexpected arguments received by the tool %s", received)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+			Name:      "twprojects-get_project",
+			Arguments: map[string]any{"id": "one"},
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		expected := NewJSONRPCError(JSONRPCErrorCodeInvalidParams, "", nil)
+		if !errors.Is(err, expected) {
+			t.Errorf("expected invalid params error, got: %v", err)
+		}
+	})
+}
+
+func TestValidationMiddlewareErrorData(t *testing.T) {
+	tools := map[string]*mcp.Tool{
+		"twprojects-get_project": {
+			Name: "twprojects-get_project",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id":   {Type: "integer"},
+					"name": {Type: "string"},
+				},
+				Required: []string{"id"},
+			},
+		},
+	}
+	var called bool
+	handler := validationMiddleware(tools)(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		called = true
+		return &mcp.CallToolResult{}, nil
+	})
+
+	_, err := handler(t.Context(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "twprojects-get_project",
+			Arguments: json.RawMessage(`{"name":1}`),
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if called {
+		t.Error("the tool should not be called with invalid arguments")
+	}
+
+	// the JSON-RPC error is sent to the client in its wire format
+	encoded, err := json.Marshal(err)
+	if err != nil {
+		t.Fatalf("failed to encode error: %v", err)
+	}
+	var wireError struct {
+		Code int64 `json:"code"`
+		Data struct {
+			Tool       string              `json:"tool"`
+			Violations []argumentViolation `json:"violations"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(encoded, &wireError); err != nil {
+		t.Fatalf("failed to decode error: %v", err)
+	}
+	if wireError.Code != JSONRPCErrorCodeInvalidParams {
+		t.Errorf("unexpected error code %d", wireError.Code)
+	}
+	if wireError.Data.Tool != "twprojects-get_project" {
+		t.Errorf("unexpected tool %q", wireError.Data.Tool)
+	}
+	var pointers []string
+	for _, violation := range wireError.Data.Violations {
+		pointers = append(pointers, violation.Pointer)
+	}
+	if !slices.Equal(pointers, []string{"/id", "/name"}) {
+		t.Errorf("unexpected violations: %+v", wireError.Data.Violations)
+	}
+}
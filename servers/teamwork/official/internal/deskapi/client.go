@@ -0,0 +1,75 @@
+// Package deskapi sends the requests to the Teamwork Desk API that the Desk
+// SDK doesn't support yet.
+package deskapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	deskclient "github.com/teamwork/desksdkgo/client"
+)
+
+// Client sends the requests to the Teamwork Desk API that the Desk SDK doesn't
+// support yet, such as deleting resources. The requests go through the
+// same middleware as the SDK client, so they are authenticated and observed in
+// the same way.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	middleware []deskclient.MiddlewareFunc
+}
+
+// NewClient creates a client for the Teamwork Desk API at the given base
+// URL (e.g. https://example.teamwork.com/desk/api/v2).
+func NewClient(baseURL string, httpClient *http.Client, middleware ...deskclient.MiddlewareFunc) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		middleware: middleware,
+	}
+}
+
+// Delete deletes the resource with the given ID, where resource is the path of
+// the collection (e.g. "tickets").
+func (c *Client) Delete(ctx context.Context, resource string, id int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		fmt.Sprintf("%s/%s/%d.json", c.baseURL, resource, id), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	handler := func(_ context.Context, req *http.Request) (*http.Response, error) {
+		return c.httpClient.Do(req)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		middleware, next := c.middleware[i], handler
+		handler = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return middleware(ctx, req, next)
+		}
+	}
+	return handler(ctx, req)
+}
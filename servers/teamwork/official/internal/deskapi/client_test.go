@@ -0,0 +1,66 @@
+package deskapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	deskclient "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/deskapi"
+)
+
+func TestClientDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{{
+		name:   "no content",
+		status: http.StatusNoContent,
+	}, {
+		name:   "ok",
+		status: http.StatusOK,
+		body:   `{}`,
+	}, {
+		name:    "not found",
+		status:  http.StatusNotFound,
+		body:    `{"errors":["not found"]}`,
+		wantErr: `unexpected status code: 404, body: {"errors":["not found"]}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path, authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path, authorization = r.Method, r.URL.Path, r.Header.Get("Authorization")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			middleware := func(ctx context.Context, req *http.Request, next deskclient.RequestHandler) (*http.Response, error) {
+				req.Header.Set("Authorization", "Bearer test-token")
+				return next(ctx, req)
+			}
+			client := deskapi.NewClient(server.URL+"/desk/api/v2/", server.Client(), middleware)
+
+			err := client.Delete(context.Background(), "tickets", 123)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("unexpected error %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if method != http.MethodDelete || path != "/desk/api/v2/tickets/123.json" {
+				t.Errorf("unexpected request %s %s", method, path)
+			}
+			if authorization != "Bearer test-token" {
+				t.Errorf("unexpected authorization %q, the middleware was not applied", authorization)
+			}
+		})
+	}
+}
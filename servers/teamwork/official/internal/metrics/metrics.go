@@ -0,0 +1,182 @@
+// Package metrics exposes Prometheus metrics of the MCP servers: the MCP
+// requests and tool calls, the Teamwork API requests and any other value
+// registered by the servers (e.g. the authentication cache statistics).
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tw_mcp"
+
+// List of request statuses.
+const (
+	// StatusSuccess is the status of a request that succeeded.
+	StatusSuccess = "success"
+	// StatusError is the status of a request that failed with a protocol error
+	// (e.g. invalid arguments or missing scopes).
+	StatusError = "error"
+	// StatusToolError is the status of a tool call that returned an error
+	// result (e.g. the Teamwork API rejected the request).
+	StatusToolError = "tool_error"
+)
+
+// DefaultAddress is the default address of the dedicated metrics listener. It
+// only listens on the loopback interface, as the metrics aren't authenticated.
+const DefaultAddress = "localhost:9464"
+
+// UnknownTool is the label to use for the calls of unknown tools, so clients
+// can't create an unbounded number of series.
+const UnknownTool = "unknown"
+
+// Metrics collects the metrics of an MCP server. It's safe for concurrent use,
+// and a nil Metrics discards all observations.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	toolCalls        *prometheus.CounterVec
+	toolCallDuration *prometheus.HistogramVec
+	upstream         *prometheus.CounterVec
+	upstreamDuration *prometheus.HistogramVec
+}
+
+// New creates the metrics in a new registry, which also contains the Go
+// runtime and process metrics.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of MCP requests by method and status.",
+		}, []string{"method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the MCP requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Number of tool calls by tool and status.",
+		}, []string{"tool", "status"}),
+		toolCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Duration of the tool calls by tool.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"tool"}),
+		upstream: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_requests_total",
+			Help:      "Number of Teamwork API requests by API, method and status code.",
+		}, []string{"api", "method", "code"}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Duration of the Teamwork API requests by API and method, including retries.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"api", "method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.toolCalls,
+		m.toolCallDuration,
+		m.upstream,
+		m.upstreamDuration,
+	)
+	return m
+}
+
+// Handler returns the HTTP handler exposing the metrics in the Prometheus text
+// format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// NewServer creates an HTTP server exposing the metrics in the "/metrics" path
+// of the given address, for a dedicated metrics listener.
+func (m *Metrics) NewServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	return &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// CounterFunc registers a counter whose value is read from fn when the metrics
+// are collected.
+func (m *Metrics) CounterFunc(name, help string, fn func() float64) {
+	m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// GaugeFunc registers a gauge whose value is read from fn when the metrics are
+// collected.
+func (m *Metrics) GaugeFunc(name, help string, fn func() float64) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// ObserveRequest records an MCP request started at the given time. The tool is
+// the name of the called tool, empty for other methods.
+func (m *Metrics) ObserveRequest(method, tool string, result mcp.Result, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+	duration := time.Since(start).Seconds()
+
+	status := StatusSuccess
+	if err != nil {
+		status = StatusError
+	} else if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult.IsError {
+		status = StatusToolError
+	}
+	m.requests.WithLabelValues(method, status).Inc()
+	m.requestDuration.WithLabelValues(method).Observe(duration)
+
+	if tool != "" {
+		m.toolCalls.WithLabelValues(tool, status).Inc()
+		m.toolCallDuration.WithLabelValues(tool).Observe(duration)
+	}
+}
+
+// ObserveUpstream records a Teamwork API request, started at the given time,
+// of the given API (e.g. "projects" or "desk"). Requests failing without a
+// response are recorded with the "error" code.
+func (m *Metrics) ObserveUpstream(api string, req *http.Request, resp *http.Response, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+	code := "error"
+	if err == nil && resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	} else if errors.Is(err, context.Canceled) {
+		code = "canceled"
+	}
+	m.upstream.WithLabelValues(api, req.Method, code).Inc()
+	m.upstreamDuration.WithLabelValues(api, req.Method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	start := time.Now()
+
+	m.ObserveRequest("tools/call", "twprojects-get_project", &mcp.CallToolResult{}, nil, start)
+	m.ObserveRequest("tools/call", "twprojects-get_project", &mcp.CallToolResult{IsError: true}, nil, start)
+	m.ObserveRequest("tools/call", metrics.UnknownTool, nil, errors.New("unknown tool"), start)
+	m.ObserveRequest("tools/list", "", &mcp.ListToolsResult{}, nil, start)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/projects/api/v3/projects.json", nil)
+	m.ObserveUpstream("projects", req, &http.Response{StatusCode: http.StatusOK}, nil, start)
+	m.ObserveUpstream("projects", req, nil, errors.New("connection refused"), start)
+
+	m.CounterFunc("custom_total", "A custom counter.", func() float64 { return 42 })
+	m.GaugeFunc("custom_gauge", "A custom gauge.", func() float64 { return 7 })
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+
+	for _, want := range []string{
+		`tw_mcp_requests_total{method="tools/call",status="success"} 1`,
+		`tw_mcp_requests_total{method="tools/call",status="tool_error"} 1`,
+		`tw_mcp_requests_total{method="tools/call",status="error"} 1`,
+		`tw_mcp_requests_total{method="tools/list",status="success"} 1`,
+		`tw_mcp_tool_calls_total{status="success",tool="twprojects-get_project"} 1`,
+		`tw_mcp_tool_calls_total{status="error",tool="unknown"} 1`,
+		`tw_mcp_tool_call_duration_seconds_count{tool="twprojects-get_project"} 2`,
+		`tw_mcp_upstream_requests_total{api="projects",code="200",method="GET"} 1`,
+		`tw_mcp_upstream_requests_total{api="projects",code="error",method="GET"} 1`,
+		`tw_mcp_upstream_request_duration_seconds_count{api="projects",method="GET"} 2`,
+		`tw_mcp_custom_total 42`,
+		`tw_mcp_custom_gauge 7`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected %q in metrics:\n%s", want, body)
+		}
+	}
+	if strings.Contains(string(body), `tool=""`) {
+		t.Errorf("unexpected tool call metric for other methods:\n%s", body)
+	}
+}
+
+func TestMetricsNil(t *testing.T) {
+	var m *metrics.Metrics
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// a nil Metrics discards the observations
+	m.ObserveRequest("tools/list", "", nil, nil, time.Now())
+	m.ObserveUpstream("desk", req, nil, nil, time.Now())
+}
@@ -0,0 +1,132 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the sensitive values in the logs.
+const redactedValue = "REDACTED"
+
+// DefaultRedactHeaders are the HTTP headers that are always redacted.
+var DefaultRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// DefaultRedactFields are the JSON fields that are always redacted. A field is
+// redacted when its name, ignoring case, underscores and dashes, contains any
+// of them (e.g. "access_token" and "refreshToken" match "token").
+var DefaultRedactFields = []string{
+	"token",
+	"password",
+	"secret",
+	"email",
+	"apikey",
+	"cookie",
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// jsonStringFieldPattern matches string fields of a JSON document, used for
+	// the bodies that can't be decoded (e.g. truncated).
+	jsonStringFieldPattern = regexp.MustCompile(`"([^"\\]+)"\s*:\s*"(?:[^"\\]|\\.)*"?`)
+)
+
+// Redactor removes sensitive information from the HTTP headers and JSON
+// bodies before logging them. String values that look like email addresses are
+// redacted everywhere.
+type Redactor struct {
+	headers map[string]struct{}
+	fields  []string
+}
+
+// NewRedactor creates a Redactor for the default rules plus the given headers
+// and JSON fields.
+func NewRedactor(headers, fields []string) *Redactor {
+	r := &Redactor{
+		headers: make(map[string]struct{}),
+	}
+	for _, header := range slices.Concat(DefaultRedactHeaders, headers) {
+		r.headers[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+	for _, field := range slices.Concat(DefaultRedactFields, fields) {
+		r.fields = append(r.fields, normalizeFieldName(field))
+	}
+	return r
+}
+
+// Header returns a copy of the headers with the sensitive values redacted.
+func (r *Redactor) Header(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key := range redacted {
+		if _, ok := r.headers[http.CanonicalHeaderKey(key)]; ok {
+			redacted[key] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
+
+// Body returns the body with the sensitive values redacted. JSON bodies are
+// redacted field by field, other bodies only have the email addresses removed.
+func (r *Redactor) Body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var document any
+	if err := json.Unmarshal(body, &document); err == nil {
+		if encoded, err := json.Marshal(r.redactValue(document)); err == nil {
+			return string(encoded)
+		}
+	}
+
+	redacted := jsonStringFieldPattern.ReplaceAllStringFunc(string(body), func(field string) string {
+		name := jsonStringFieldPattern.FindStringSubmatch(field)[1]
+		if r.sensitiveField(name) {
+			return `"` + name + `":"` + redactedValue + `"`
+		}
+		return field
+	})
+	return emailPattern.ReplaceAllString(redacted, redactedValue)
+}
+
+func (r *Redactor) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, fieldValue := range v {
+			if r.sensitiveField(name) {
+				v[name] = redactedValue
+				continue
+			}
+			v[name] = r.redactValue(fieldValue)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactValue(item)
+		}
+	case string:
+		return emailPattern.ReplaceAllString(v, redactedValue)
+	}
+	return value
+}
+
+func (r *Redactor) sensitiveField(name string) bool {
+	name = normalizeFieldName(name)
+	for _, field := range r.fields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,237 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// List of default values for the RetryRoundTripper.
+const (
+	DefaultRetryMaxRetries = 3
+	DefaultRetryMinBackoff = 250 * time.Millisecond
+	DefaultRetryMaxBackoff = 10 * time.Second
+	DefaultRetryBudget     = 30 * time.Second
+)
+
+// rateLimitResetEpoch is the threshold used to detect if the X-RateLimit-Reset
+// header contains a Unix timestamp instead of a number of seconds.
+const rateLimitResetEpoch = 1_000_000_000
+
+// DefaultRetryMethods are the idempotent HTTP methods, which are safe to retry.
+var DefaultRetryMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// DefaultRetryStatusCodes are the transient HTTP status codes that are
+// retried.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryRoundTripper is an http.RoundTripper that retries requests failing with
+// a transient error. The wait between attempts uses exponential backoff with
+// full jitter, unless the server tells when to retry with the Retry-After or
+// the X-RateLimit-* headers.
+//
+// Only requests with a retryable method, or with an Idempotency-Key header, are
+// retried. The time spent on a call, including the waits, is limited by the
+// budget and by the deadline of the request context. When the next attempt
+// wouldn't fit, the last response is returned as is.
+type RetryRoundTripper struct {
+	Base http.RoundTripper
+	Log  *slog.Logger
+
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the base wait time of the exponential backoff.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait time between attempts.
+	MaxBackoff time.Duration
+	// Budget is the maximum time spent on a call, including all attempts and
+	// waits. Zero means that only the request context limits it.
+	Budget time.Duration
+	// Methods are the HTTP methods that are retried.
+	Methods []string
+	// StatusCodes are the HTTP status codes that are retried.
+	StatusCodes []int
+}
+
+// NewRetryRoundTripper creates a new RetryRoundTripper with the given logger
+// and the default retry policy.
+func NewRetryRoundTripper(logger *slog.Logger, base http.RoundTripper) *RetryRoundTripper {
+	return &RetryRoundTripper{
+		Base:        base,
+		Log:         logger,
+		MaxRetries:  DefaultRetryMaxRetries,
+		MinBackoff:  DefaultRetryMinBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		Budget:      DefaultRetryBudget,
+		Methods:     DefaultRetryMethods,
+		StatusCodes: DefaultRetryStatusCodes,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (rrt *RetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := rrt.Base
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if rrt.MaxRetries <= 0 || !rrt.retryable(req) {
+		return transport.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	deadline, hasDeadline := ctx.Deadline()
+	if rrt.Budget > 0 {
+		if budgetDeadline := time.Now().Add(rrt.Budget); !hasDeadline || budgetDeadline.Before(deadline) {
+			deadline, hasDeadline = budgetDeadline, true
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := transport.RoundTrip(attemptReq)
+		if attempt >= rrt.MaxRetries || !rrt.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := rrt.backoff(attempt)
+		if resp != nil {
+			if serverDelay, ok := retryDelay(resp.Header, time.Now()); ok {
+				delay = serverDelay
+			}
+		}
+		if hasDeadline && time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+
+		if rrt.Log != nil {
+			attrs := []any{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Int("attempt", attempt+1),
+				slog.Duration("delay", delay),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			} else {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			}
+			rrt.Log.Warn("retrying HTTP request", attrs...)
+		}
+
+		if resp != nil {
+			drainBody(resp.Body)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable checks if the request can be sent more than once. The body must be
+// replayable for requests with content.
+func (rrt *RetryRoundTripper) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	return slices.Contains(rrt.Methods, req.Method) || req.Header.Get("Idempotency-Key") != ""
+}
+
+func (rrt *RetryRoundTripper) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return slices.Contains(rrt.StatusCodes, resp.StatusCode)
+}
+
+// backoff returns the wait time before the next attempt using exponential
+// backoff with full jitter.
+func (rrt *RetryRoundTripper) backoff(attempt int) time.Duration {
+	maxBackoff := rrt.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	backoff := rrt.MinBackoff
+	for range attempt {
+		if backoff >= maxBackoff/2 {
+			backoff = maxBackoff
+			break
+		}
+		backoff *= 2
+	}
+	backoff = min(backoff, maxBackoff)
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff + 1) //nolint:gosec
+}
+
+// retryDelay returns the wait time requested by the server. The Retry-After
+// header can contain a number of seconds or an HTTP date. When the rate limit
+// is exhausted, the X-RateLimit-Reset header contains the number of seconds
+// until the reset or its Unix timestamp.
+func retryDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset >= 0 {
+			if reset >= rateLimitResetEpoch {
+				return max(time.Unix(reset, 0).Sub(now), 0), true
+			}
+			return time.Duration(reset) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// drainBody reads a bounded part of the body before closing it, so the
+// connection can be reused.
+func drainBody(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096))
+	_ = body.Close()
+}
@@ -0,0 +1,177 @@
+package network_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/teamwork/mcp/internal/network"
+)
+
+func TestRetryRoundTripper(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		header       http.Header
+		body         string
+		responses    []int
+		respHeader   http.Header
+		budget       time.Duration
+		wantStatus   int
+		wantAttempts int32
+	}{{
+		name:         "success",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusOK},
+		wantStatus:   http.StatusOK,
+		wantAttempts: 1,
+	}, {
+		name:         "transient errors",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+		wantStatus:   http.StatusOK,
+		wantAttempts: 3,
+	}, {
+		name:         "retries exhausted",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusTooManyRequests},
+		wantStatus:   http.StatusTooManyRequests,
+		wantAttempts: 4,
+	}, {
+		name:         "permanent error",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusNotFound},
+		wantStatus:   http.StatusNotFound,
+		wantAttempts: 1,
+	}, {
+		name:         "non-idempotent method",
+		method:       http.MethodPost,
+		body:         `{"name":"test"}`,
+		responses:    []int{http.StatusServiceUnavailable, http.StatusOK},
+		wantStatus:   http.StatusServiceUnavailable,
+		wantAttempts: 1,
+	}, {
+		name:         "idempotency key",
+		method:       http.MethodPost,
+		header:       http.Header{"Idempotency-Key": []string{"123"}},
+		body:         `{"name":"test"}`,
+		responses:    []int{http.StatusServiceUnavailable, http.StatusOK},
+		wantStatus:   http.StatusOK,
+		wantAttempts: 2,
+	}, {
+		name:         "retry after exceeds budget",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+		respHeader:   http.Header{"Retry-After": []string{"60"}},
+		budget:       time.Second,
+		wantStatus:   http.StatusTooManyRequests,
+		wantAttempts: 1,
+	}, {
+		name:         "rate limit reset exceeds budget",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+		respHeader:   http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"60"}},
+		budget:       time.Second,
+		wantStatus:   http.StatusTooManyRequests,
+		wantAttempts: 1,
+	}, {
+		name:         "retry after within budget",
+		method:       http.MethodGet,
+		responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+		respHeader:   http.Header{"Retry-After": []string{"0"}},
+		budget:       time.Second,
+		wantStatus:   http.StatusOK,
+		wantAttempts: 2,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.body {
+					t.Errorf("attempt %d: unexpected body %q", attempt, body)
+				}
+				for key, values := range tt.respHeader {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(tt.responses[min(int(attempt), len(tt.responses))-1])
+			}))
+			defer server.Close()
+
+			roundTripper := network.NewRetryRoundTripper(nil, nil)
+			roundTripper.MinBackoff = time.Millisecond
+			roundTripper.MaxBackoff = 5 * time.Millisecond
+			if tt.budget > 0 {
+				roundTripper.Budget = tt.budget
+			}
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, server.URL, body)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+
+			resp, err := roundTripper.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close() //nolint:errcheck
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("unexpected status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("unexpected attempts %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripperDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	roundTripper := network.NewRetryRoundTripper(nil, nil)
+	roundTripper.MinBackoff = time.Hour
+	roundTripper.MaxBackoff = time.Hour
+	roundTripper.Budget = 0
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	start := time.Now()
+	resp, err := roundTripper.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	// the backoff wouldn't fit in the context deadline, so the last response
+	// is returned without waiting
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the request took too long: %s", elapsed)
+	}
+}
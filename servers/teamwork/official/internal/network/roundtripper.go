@@ -4,80 +4,165 @@ import (
 	"bytes"
 	"io"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"time"
 )
 
+// LoggingMode defines what is logged for each HTTP request.
+type LoggingMode string
+
+// List of logging modes.
+const (
+	// LoggingModeOff disables the logging of the HTTP requests.
+	LoggingModeOff LoggingMode = "off"
+	// LoggingModeHeaders logs the request line, the status and the headers.
+	LoggingModeHeaders LoggingMode = "headers"
+	// LoggingModeFull also logs the request and response bodies.
+	LoggingModeFull LoggingMode = "full"
+)
+
+// DefaultLoggingMaxBodySize is the default maximum number of bytes of a body
+// that are logged.
+const DefaultLoggingMaxBodySize = 4096
+
 // LoggingRoundTripper is an http.RoundTripper that logs requests and responses
+// at debug level. Sensitive headers and JSON fields are redacted, and the
+// bodies are truncated to MaxBodySize.
 type LoggingRoundTripper struct {
 	Base http.RoundTripper
 	Log  *slog.Logger
+
+	// Mode defines what is logged. An empty mode is the same as
+	// LoggingModeHeaders.
+	Mode LoggingMode
+	// MaxBodySize is the maximum number of bytes of a body that are logged.
+	MaxBodySize int
+	// SampleRate is the fraction of requests that are logged, between 0 and 1.
+	SampleRate float64
+	// Redactor removes the sensitive information from the logs. When nil, the
+	// default redaction rules are used.
+	Redactor *Redactor
 }
 
-// NewLoggingRoundTripper creates a new LoggingRoundTripper with the given logger
+// NewLoggingRoundTripper creates a new LoggingRoundTripper with the given
+// logger, logging the headers of all requests.
 func NewLoggingRoundTripper(logger *slog.Logger, base http.RoundTripper) *LoggingRoundTripper {
 	return &LoggingRoundTripper{
-		Log:  logger,
-		Base: base,
+		Log:         logger,
+		Base:        base,
+		Mode:        LoggingModeHeaders,
+		MaxBodySize: DefaultLoggingMaxBodySize,
+		SampleRate:  1,
+		Redactor:    NewRedactor(nil, nil),
 	}
 }
 
 // RoundTrip implements the RoundTripper interface
 func (lrt *LoggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	start := time.Now()
-
-	var reqBody []byte
-	if req.Body != nil {
-		var err error
-		reqBody, err = io.ReadAll(req.Body)
-		if err != nil {
-			lrt.Log.Error("failed to read request body", "error", err)
-		}
-		req.Body = io.NopCloser(bytes.NewBuffer(reqBody))
+	transport := lrt.Base
+	if transport == nil {
+		transport = http.DefaultTransport
 	}
-
-	headers := req.Header.Clone()
-	if headers.Get("Authorization") != "" {
-		headers.Set("Authorization", "REDACTED")
+	if !lrt.shouldLog(req) {
+		return transport.RoundTrip(req)
 	}
 
-	// Log the request
-	lrt.Log.Info("HTTP request",
-		"url", req.URL.String(),
-		"method", req.Method,
-		"headers", headers,
-		"body", string(reqBody),
-	)
+	redactor := lrt.Redactor
+	if redactor == nil {
+		redactor = NewRedactor(nil, nil)
+	}
+	start := time.Now()
 
-	transport := lrt.Base
-	if transport == nil {
-		transport = http.DefaultTransport
+	requestAttrs := []any{
+		slog.String("url", req.URL.String()),
+		slog.String("method", req.Method),
+		slog.Any("headers", redactor.Header(req.Header)),
+	}
+	if lrt.Mode == LoggingModeFull {
+		body := lrt.requestBody(req)
+		requestAttrs = append(requestAttrs, slog.String("body", lrt.formatBody(redactor, body)))
 	}
+	lrt.Log.DebugContext(req.Context(), "HTTP request", requestAttrs...)
 
 	resp, err := transport.RoundTrip(req)
 	if err != nil {
-		lrt.Log.Error("HTTP request failed", "error", err)
+		lrt.Log.DebugContext(req.Context(), "HTTP request failed",
+			slog.String("url", req.URL.String()),
+			slog.String("method", req.Method),
+			slog.String("error", err.Error()),
+			slog.String("duration", time.Since(start).String()),
+		)
 		return resp, err
 	}
 
-	var respBody []byte
-	if resp.Body != nil {
-		respBody, err = io.ReadAll(resp.Body)
-		if err != nil {
-			lrt.Log.Error("failed to read response body", "error", err)
-		}
+	responseAttrs := []any{
+		slog.String("url", req.URL.String()),
+		slog.String("method", req.Method),
+		slog.String("status", resp.Status),
+		slog.Any("headers", redactor.Header(resp.Header)),
+	}
+	if lrt.Mode == LoggingModeFull && resp.Body != nil {
+		var body []byte
+		body, resp.Body = peekBody(resp.Body, lrt.MaxBodySize)
+		responseAttrs = append(responseAttrs, slog.String("body", lrt.formatBody(redactor, body)))
+	}
+	responseAttrs = append(responseAttrs, slog.String("duration", time.Since(start).String()))
+	lrt.Log.DebugContext(req.Context(), "HTTP response", responseAttrs...)
 
-		resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
+	return resp, nil
+}
+
+// shouldLog checks if the request is logged, according to the mode, the log
+// level and the sample rate.
+func (lrt *LoggingRoundTripper) shouldLog(req *http.Request) bool {
+	if lrt.Log == nil || lrt.Mode == LoggingModeOff || lrt.SampleRate <= 0 {
+		return false
+	}
+	if !lrt.Log.Enabled(req.Context(), slog.LevelDebug) {
+		return false
 	}
+	return lrt.SampleRate >= 1 || rand.Float64() < lrt.SampleRate //nolint:gosec
+}
 
-	lrt.Log.Info("HTTP response",
-		"url", req.URL.String(),
-		"method", req.Method,
-		"status", resp.Status,
-		"headers", resp.Header,
-		"body", string(respBody),
-		"duration", time.Since(start).String(),
-	)
+// requestBody returns the first bytes of the request body, leaving the request
+// ready to be sent.
+func (lrt *LoggingRoundTripper) requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			defer body.Close() //nolint:errcheck
+			peeked, _ := peekBody(body, lrt.MaxBodySize)
+			return peeked
+		}
+	}
+	var peeked []byte
+	peeked, req.Body = peekBody(req.Body, lrt.MaxBodySize)
+	return peeked
+}
 
-	return resp, nil
+// formatBody redacts the body, adding a marker when it was truncated.
+func (lrt *LoggingRoundTripper) formatBody(redactor *Redactor, body []byte) string {
+	if lrt.MaxBodySize >= 0 && len(body) > lrt.MaxBodySize {
+		return redactor.Body(body[:lrt.MaxBodySize]) + "...(truncated)"
+	}
+	return redactor.Body(body)
+}
+
+// peekBody reads up to limit+1 bytes of the body, so it's possible to know if
+// it was truncated, and returns a body that still provides the full content.
+func peekBody(body io.ReadCloser, limit int) ([]byte, io.ReadCloser) {
+	limit = max(limit, 0)
+	// a read error is reported again when the body is consumed
+	peeked, _ := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+	return peeked, struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(peeked), body),
+		Closer: body,
+	}
 }
@@ -0,0 +1,184 @@
+package network_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/mcp/internal/network"
+)
+
+func TestRedactor(t *testing.T) {
+	redactor := network.NewRedactor([]string{"X-Custom"}, []string{"phone"})
+
+	t.Run("headers", func(t *testing.T) {
+		header := http.Header{
+			"Authorization": []string{"Bearer secret"},
+			"Set-Cookie":    []string{"session=secret"},
+			"X-Custom":      []string{"secret"},
+			"Content-Type":  []string{"application/json"},
+		}
+		redacted := redactor.Header(header)
+		for _, key := range []string{"Authorization", "Set-Cookie", "X-Custom"} {
+			if got := redacted.Get(key); got != "REDACTED" {
+				t.Errorf("header %s not redacted: %q", key, got)
+			}
+		}
+		if got := redacted.Get("Content-Type"); got != "application/json" {
+			t.Errorf("unexpected Content-Type %q", got)
+		}
+		if header.Get("Authorization") != "Bearer secret" {
+			t.Error("the original headers were modified")
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{{
+		name: "empty",
+	}, {
+		name: "json fields",
+		body: `{"id":1,"access_token":"abc","user":{"emailAddress":"a@b.com","phoneNumber":"123","name":"John"}}`,
+		want: `{"access_token":"REDACTED","id":1,"user":{"emailAddress":"REDACTED","name":"John","phoneNumber":"REDACTED"}}`,
+	}, {
+		name: "emails in values",
+		body: `{"notes":["contact john@example.com"]}`,
+		want: `{"notes":["contact REDACTED"]}`,
+	}, {
+		name: "truncated json",
+		body: `{"id":1,"password":"abc","name":"x@y.io","descr`,
+		want: `{"id":1,"password":"REDACTED","name":"REDACTED","descr`,
+	}, {
+		name: "plain text",
+		body: `hello john@example.com`,
+		want: `hello REDACTED`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactor.Body([]byte(tt.body)); got != tt.want {
+				t.Errorf("unexpected body %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggingRoundTripper(t *testing.T) {
+	const (
+		requestBody  = `{"name":"test","email":"john@example.com"}`
+		responseBody = `{"id":1,"token":"secret","description":"a long description"}`
+	)
+
+	tests := []struct {
+		name        string
+		level       slog.Level
+		mode        network.LoggingMode
+		maxBodySize int
+		sampleRate  float64
+		want        []string
+		notWant     []string
+	}{{
+		name:       "headers",
+		level:      slog.LevelDebug,
+		mode:       network.LoggingModeHeaders,
+		sampleRate: 1,
+		want:       []string{"HTTP request", "HTTP response", "REDACTED"},
+		notWant:    []string{"Bearer", "session=", "body="},
+	}, {
+		name:        "full",
+		level:       slog.LevelDebug,
+		mode:        network.LoggingModeFull,
+		maxBodySize: 1024,
+		sampleRate:  1,
+		want:        []string{`\"name\":\"test\"`, `\"token\":\"REDACTED\"`},
+		notWant:     []string{"john@example.com", "secret", "truncated"},
+	}, {
+		name:        "truncated body",
+		level:       slog.LevelDebug,
+		mode:        network.LoggingModeFull,
+		maxBodySize: 10,
+		sampleRate:  1,
+		want:        []string{"...(truncated)"},
+		notWant:     []string{"a long description"},
+	}, {
+		name:       "off",
+		level:      slog.LevelDebug,
+		mode:       network.LoggingModeOff,
+		sampleRate: 1,
+		notWant:    []string{"HTTP request"},
+	}, {
+		name:       "not sampled",
+		level:      slog.LevelDebug,
+		mode:       network.LoggingModeHeaders,
+		sampleRate: 0,
+		notWant:    []string{"HTTP request"},
+	}, {
+		name:       "info level",
+		level:      slog.LevelInfo,
+		mode:       network.LoggingModeFull,
+		sampleRate: 1,
+		notWant:    []string{"HTTP request"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != requestBody {
+					t.Errorf("unexpected request body %q", body)
+				}
+				w.Header().Set("Set-Cookie", "session=abc")
+				_, _ = w.Write([]byte(responseBody))
+			}))
+			defer server.Close()
+
+			var logs bytes.Buffer
+			roundTripper := network.NewLoggingRoundTripper(
+				slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: tt.level})),
+				nil,
+			)
+			roundTripper.Mode = tt.mode
+			roundTripper.MaxBodySize = tt.maxBodySize
+			roundTripper.SampleRate = tt.sampleRate
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL,
+				strings.NewReader(requestBody))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer abc")
+
+			resp, err := roundTripper.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close() //nolint:errcheck
+
+			// the body must be intact after logging
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response body: %v", err)
+			}
+			if string(body) != responseBody {
+				t.Errorf("unexpected response body %q", body)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(logs.String(), want) {
+					t.Errorf("expected %q in logs:\n%s", want, logs.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(logs.String(), notWant) {
+					t.Errorf("unexpected %q in logs:\n%s", notWant, logs.String())
+				}
+			}
+		})
+	}
+}
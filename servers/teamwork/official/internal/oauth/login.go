@@ -0,0 +1,137 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// DefaultRedirectAddress is the default address of the listener receiving the
+// redirect of the login page. The redirect URI of the Teamwork app must be
+// "http://<address>/callback".
+const DefaultRedirectAddress = "localhost:8765"
+
+// callbackPath is the path of the redirect URI.
+const callbackPath = "/callback"
+
+// Login runs the app login flow: it listens on the given address for the
+// redirect of the login page, calls open with the URL of the login page, and
+// waits for the user to log in. The code received in the redirect is exchanged
+// for a token. Redirects without the state of the login are rejected without
+// ending the login.
+func (c Config) Login(ctx context.Context, address string, open func(loginURL string) error) (*Token, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the login redirect: %w", err)
+	}
+	defer listener.Close() //nolint:errcheck
+
+	// the address may use a random port
+	host, _, _ := net.SplitHostPort(address)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	redirectURI := "http://" + net.JoinHostPort(host, port) + callbackPath
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != callbackPath {
+				http.NotFound(w, r)
+				return
+			}
+			query := r.URL.Query()
+			if query.Get("state") != state {
+				// not the redirect of this login (e.g. a browser prefetch or a request
+				// of another site), so the login keeps waiting
+				http.Error(w, "invalid state in the login redirect", http.StatusBadRequest)
+				return
+			}
+			var result callbackResult
+			switch {
+			case query.Get("error") != "":
+				result.err = fmt.Errorf("login failed: %s", query.Get("error"))
+			case query.Get("code") == "":
+				result.err = errors.New("missing code in the login redirect")
+			default:
+				result.code = query.Get("code")
+			}
+			if result.err != nil {
+				http.Error(w, result.err.Error(), http.StatusBadRequest)
+			} else {
+				_, _ = fmt.Fprintln(w, "Logged in to Teamwork.com, you can close this window.")
+			}
+			select {
+			case results <- result:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Close()      //nolint:errcheck
+
+	loginURL, err := url.Parse(c.AuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid login URL: %w", err)
+	}
+	query := loginURL.Query()
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	loginURL.RawQuery = query.Encode()
+	if err := open(loginURL.String()); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return c.Exchange(ctx, result.code, redirectURI)
+	}
+}
+
+// OpenBrowser opens the given URL in the default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	go cmd.Wait() //nolint:errcheck
+	return nil
+}
+
+func randomState() (string, error) {
+	state := make([]byte, 16)
+	if _, err := rand.Read(state); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(state), nil
+}
@@ -0,0 +1,157 @@
+// Package oauth implements the Teamwork app login flow, used to authenticate
+// the MCP server in STDIO mode without a long-lived bearer token. The tokens
+// are stored in a file and refreshed automatically.
+//
+// https://apidocs.teamwork.com/guides/teamwork/app-login-flow
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// expiryDelta is how long before its expiration an access token is refreshed,
+// so it doesn't expire while a request is in flight.
+const expiryDelta = time.Minute
+
+// ErrLoginRequired is returned when there's no valid token and the user needs
+// to log in again.
+var ErrLoginRequired = errors.New("login required")
+
+// Config is the configuration of the Teamwork app.
+type Config struct {
+	// ClientID is the client ID of the Teamwork app.
+	ClientID string
+	// ClientSecret is the client secret of the Teamwork app.
+	ClientSecret string
+	// AuthURL is the URL of the login page.
+	AuthURL string
+	// TokenURL is the URL of the endpoint exchanging the codes and the refresh
+	// tokens for access tokens.
+	TokenURL string
+	// HTTPClient is the client used to request the tokens. When nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// NewConfig creates the configuration of a Teamwork app, using the login
+// endpoints of the given Teamwork API URL (e.g. "https://teamwork.com").
+func NewConfig(apiURL, clientID, clientSecret string) Config {
+	return Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		AuthURL:      apiURL + "/launchpad/login",
+		TokenURL:     apiURL + "/launchpad/v1/token.json",
+	}
+}
+
+// Token contains the tokens obtained with the app login flow.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Expiry is when the access token expires. The zero value means the token
+	// doesn't expire.
+	Expiry time.Time `json:"expiry,omitzero"`
+}
+
+// expired returns true if the access token expired, or is about to.
+func (t *Token) expired(now time.Time) bool {
+	return !t.Expiry.IsZero() && now.Add(expiryDelta).After(t.Expiry)
+}
+
+// Exchange exchanges the code received in the redirect of the login page for a
+// token.
+func (c Config) Exchange(ctx context.Context, code, redirectURI string) (*Token, error) {
+	return c.requestToken(ctx, map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"redirect_uri":  redirectURI,
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+	})
+}
+
+// Refresh obtains a new access token with the refresh token of the given
+// token. The refresh token is kept when the response doesn't include a new
+// one.
+func (c Config) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: the token can't be refreshed", ErrLoginRequired)
+	}
+	refreshed, err := c.requestToken(ctx, map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": token.RefreshToken,
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	return refreshed, nil
+}
+
+func (c Config) requestToken(ctx context.Context, params map[string]string) (*Token, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token request: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform token request: %w", err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	switch {
+	case response.StatusCode == http.StatusBadRequest || response.StatusCode == http.StatusUnauthorized:
+		// the code or the refresh token is invalid or expired
+		return nil, fmt.Errorf("%w: token request failed with status %d: %s",
+			ErrLoginRequired, response.StatusCode, bytes.TrimSpace(content))
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("token request failed with status %d: %s",
+			response.StatusCode, bytes.TrimSpace(content))
+	}
+
+	var tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(content, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errors.New("token response without access token")
+	}
+	token := &Token{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+	}
+	if tokenResponse.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,216 @@
+package oauth_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/teamwork/mcp/internal/oauth"
+)
+
+// newTokenServer creates a token endpoint issuing numbered access tokens. The
+// "expired-refresh" refresh token is rejected.
+func newTokenServer(t *testing.T, expiresIn int) (oauth.Config, *atomic.Int64) {
+	t.Helper()
+
+	var issued atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if params["client_id"] != "client" || params["client_secret"] != "secret" {
+			http.Error(w, "invalid client", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case params["grant_type"] == "authorization_code" && params["code"] == "code":
+		case params["grant_type"] == "refresh_token" && params["refresh_token"] == "refresh":
+		default:
+			http.Error(w, "invalid grant", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  fmt.Sprintf("access-%d", issued.Add(1)),
+			"refresh_token": "refresh",
+			"expires_in":    expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	config := oauth.NewConfig(server.URL, "client", "secret")
+	return config, &issued
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(state string) url.Values
+		// stray are the requests received before the redirect, which must be
+		// rejected without ending the login
+		stray   []url.Values
+		want    string
+		wantErr bool
+	}{{
+		name: "success",
+		query: func(state string) url.Values {
+			return url.Values{"code": {"code"}, "state": {state}}
+		},
+		want: "access-1",
+	}, {
+		name: "invalid state ignored",
+		query: func(state string) url.Values {
+			return url.Values{"code": {"code"}, "state": {state}}
+		},
+		stray: []url.Values{
+			{"code": {"code"}, "state": {"other"}},
+			{"error": {"access_denied"}},
+			{},
+		},
+		want: "access-1",
+	}, {
+		name: "denied",
+		query: func(state string) url.Values {
+			return url.Values{"error": {"access_denied"}, "state": {state}}
+		},
+		wantErr: true,
+	}, {
+		name: "invalid code",
+		query: func(state string) url.Values {
+			return url.Values{"code": {"other"}, "state": {state}}
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := newTokenServer(t, 3600)
+
+			// the browser follows the redirect of the login page
+			token, err := config.Login(t.Context(), "127.0.0.1:0", func(loginURL string) error {
+				parsed, err := url.Parse(loginURL)
+				if err != nil {
+					return err
+				}
+				query := parsed.Query()
+				if query.Get("client_id") != "client" {
+					return fmt.Errorf("unexpected login URL %s", loginURL)
+				}
+				go func() {
+					for _, stray := range tt.stray {
+						response, err := http.Get(query.Get("redirect_uri") + "?" + stray.Encode()) //nolint:noctx
+						if err != nil {
+							t.Errorf("failed to send stray request: %v", err)
+							return
+						}
+						_ = response.Body.Close()
+						if response.StatusCode != http.StatusBadRequest {
+							t.Errorf("unexpected status %d of stray request %v", response.StatusCode, stray)
+						}
+					}
+					redirectURL := query.Get("redirect_uri") + "?" + tt.query(query.Get("state")).Encode()
+					if response, err := http.Get(redirectURL); err == nil { //nolint:noctx
+						_ = response.Body.Close()
+					}
+				}()
+				return nil
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.AccessToken != tt.want || token.RefreshToken != "refresh" {
+				t.Errorf("unexpected token %+v", token)
+			}
+			if time.Until(token.Expiry) < 59*time.Minute {
+				t.Errorf("unexpected expiry %s", token.Expiry)
+			}
+		})
+	}
+}
+
+func TestTokenSource(t *testing.T) {
+	config, issued := newTokenServer(t, 3600)
+	path := filepath.Join(t.TempDir(), "teamwork-mcp", "token.json")
+	source := oauth.NewTokenSource(config, path)
+
+	if _, err := source.AccessToken(t.Context()); !errors.Is(err, oauth.ErrLoginRequired) {
+		t.Fatalf("expected login required error, got %v", err)
+	}
+
+	// logging in while the server is running
+	err := oauth.SaveToken(path, &oauth.Token{
+		AccessToken:  "access-0",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("unexpected token file %v (%v)", info, err)
+	}
+	if token, err := source.AccessToken(t.Context()); err != nil || token != "access-0" {
+		t.Fatalf("unexpected token %q (%v)", token, err)
+	}
+
+	// the rejected token is refreshed and stored
+	source.Invalidate("access-0")
+	if token, err := source.AccessToken(t.Context()); err != nil || token != "access-1" {
+		t.Fatalf("unexpected token %q (%v)", token, err)
+	}
+	if stored, err := oauth.LoadToken(path); err != nil || stored.AccessToken != "access-1" {
+		t.Errorf("unexpected stored token %+v (%v)", stored, err)
+	}
+	if token, err := source.AccessToken(t.Context()); err != nil || token != "access-1" || issued.Load() != 1 {
+		t.Errorf("unexpected token %q (%v) after %d refreshes", token, err, issued.Load())
+	}
+
+	// the expired token is refreshed
+	err = oauth.SaveToken(path, &oauth.Token{
+		AccessToken:  "expired",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	modTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to change modification time: %v", err)
+	}
+	if token, err := source.AccessToken(t.Context()); err != nil || token != "access-2" {
+		t.Fatalf("unexpected token %q (%v)", token, err)
+	}
+
+	// the rejected refresh token requires a new login
+	err = oauth.SaveToken(path, &oauth.Token{
+		AccessToken:  "expired",
+		RefreshToken: "expired-refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	modTime = modTime.Add(time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to change modification time: %v", err)
+	}
+	if _, err := source.AccessToken(t.Context()); !errors.Is(err, oauth.ErrLoginRequired) {
+		t.Errorf("expected login required error, got %v", err)
+	}
+}
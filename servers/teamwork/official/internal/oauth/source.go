@@ -0,0 +1,152 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultTokenFile returns the default path of the token file, in the user
+// configuration directory.
+func DefaultTokenFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, "teamwork-mcp", "token.json"), nil
+}
+
+// LoadToken reads a token file. It returns ErrLoginRequired when the file
+// doesn't exist.
+func LoadToken(path string) (*Token, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: token file %s not found", ErrLoginRequired, path)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	var token Token
+	if err := json.Unmarshal(content, &token); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", path, err)
+	}
+	return &token, nil
+}
+
+// SaveToken writes a token file, only readable by the current user. The file
+// is replaced atomically, so a running server never reads a partial token.
+func SaveToken(path string, token *Token) error {
+	content, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to create token file: %w", err)
+	}
+	defer os.Remove(file.Name()) //nolint:errcheck
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	return nil
+}
+
+// TokenSource provides the access token stored in a token file, refreshing it
+// when expired. The file is read again when modified (e.g. after logging in
+// again), so the server recovers without a restart. It's safe for concurrent
+// use.
+type TokenSource struct {
+	config Config
+	path   string
+
+	mu      sync.Mutex
+	token   *Token
+	modTime time.Time
+	invalid bool
+}
+
+// NewTokenSource creates a TokenSource of the given token file.
+func NewTokenSource(config Config, path string) *TokenSource {
+	return &TokenSource{
+		config: config,
+		path:   path,
+	}
+}
+
+// AccessToken returns a valid access token, refreshing it when needed. It
+// returns ErrLoginRequired when the user needs to log in.
+func (s *TokenSource) AccessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return "", err
+	}
+	if !s.invalid && !s.token.expired(time.Now()) {
+		return s.token.AccessToken, nil
+	}
+
+	token, err := s.config.Refresh(ctx, s.token)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if err := SaveToken(s.path, token); err != nil {
+		return "", err
+	}
+	s.token, s.invalid = token, false
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return s.token.AccessToken, nil
+}
+
+// LoggedIn returns true if the token file exists.
+func (s *TokenSource) LoggedIn() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// Invalidate marks the given access token as rejected, so it's refreshed on the
+// next call to AccessToken.
+func (s *TokenSource) Invalidate(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == accessToken {
+		s.invalid = true
+	}
+}
+
+func (s *TokenSource) reload() error {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: token file %s not found", ErrLoginRequired, s.path)
+	} else if err != nil {
+		return fmt.Errorf("failed to read token file: %w", err)
+	}
+	if s.token != nil && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	token, err := LoadToken(s.path)
+	if err != nil {
+		return err
+	}
+	s.token, s.modTime, s.invalid = token, info.ModTime(), false
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package ratelimit implements token bucket rate limiting keyed by an
+// arbitrary identifier (e.g. a user or an installation).
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// sweepInterval is how often the idle buckets are removed.
+const sweepInterval = time.Minute
+
+// Limiter keeps a token bucket for each key. Each bucket holds up to a minute
+// worth of requests and is refilled continuously, so a key can burst up to the
+// per-minute limit and then continue at the sustained rate. It's safe for
+// concurrent use, and a nil Limiter allows every request.
+type Limiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimiter creates a Limiter allowing the given number of requests per
+// minute for each key. It returns nil, which allows every request, when the
+// limit is lower or equal to zero.
+func NewLimiter(perMinute int) *Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &Limiter{
+		limit:   rate.Limit(float64(perMinute) / time.Minute.Seconds()),
+		burst:   perMinute,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// reserve takes a token from the bucket of the key.
+func (l *Limiter) reserve(key string, now time.Time) *rate.Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter.ReserveN(now, 1)
+}
+
+// sweep removes the buckets that were refilled since they were last used, as
+// they are equivalent to new buckets.
+func (l *Limiter) sweep(now time.Time) {
+	refill := time.Duration(float64(l.burst) / float64(l.limit) * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Len returns the number of keys being tracked.
+func (l *Limiter) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// Key identifies the bucket of a key in a Limiter.
+type Key struct {
+	Limiter *Limiter
+	Key     string
+}
+
+// Allow takes a token from the bucket of each key, only when all of them have
+// one available, so a request rejected by one limiter doesn't consume the
+// budget of the others. When rejected, it returns how long to wait until all
+// the buckets have a token.
+func Allow(keys ...Key) (bool, time.Duration) {
+	return AllowAt(time.Now(), keys...)
+}
+
+// AllowAt is like Allow, for a request made at the given time.
+func AllowAt(now time.Time, keys ...Key) (bool, time.Duration) {
+	reservations := make([]*rate.Reservation, 0, len(keys))
+	var retryAfter time.Duration
+	for _, key := range keys {
+		if key.Limiter == nil {
+			continue
+		}
+		reservation := key.Limiter.reserve(key.Key, now)
+		reservations = append(reservations, reservation)
+		retryAfter = max(retryAfter, reservation.DelayFrom(now))
+	}
+	if retryAfter == 0 {
+		return true, 0
+	}
+	for _, reservation := range reservations {
+		reservation.CancelAt(now)
+	}
+	return false, retryAfter
+}
@@ -0,0 +1,85 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/teamwork/mcp/internal/ratelimit"
+)
+
+func TestAllow(t *testing.T) {
+	start := time.Now()
+	user := ratelimit.NewLimiter(2)
+	installation := ratelimit.NewLimiter(3)
+
+	tests := []struct {
+		name         string
+		at           time.Duration
+		keys         []ratelimit.Key
+		wantAllowed  bool
+		wantRetryMin time.Duration
+		wantRetryMax time.Duration
+	}{{
+		name:        "first call",
+		keys:        []ratelimit.Key{{Limiter: user, Key: "1"}, {Limiter: installation, Key: "10"}},
+		wantAllowed: true,
+	}, {
+		name:        "burst",
+		keys:        []ratelimit.Key{{Limiter: user, Key: "1"}, {Limiter: installation, Key: "10"}},
+		wantAllowed: true,
+	}, {
+		name:         "user limit",
+		keys:         []ratelimit.Key{{Limiter: user, Key: "1"}, {Limiter: installation, Key: "10"}},
+		wantRetryMin: 29 * time.Second,
+		wantRetryMax: 30 * time.Second,
+	}, {
+		name:        "other user of the installation",
+		keys:        []ratelimit.Key{{Limiter: user, Key: "2"}, {Limiter: installation, Key: "10"}},
+		wantAllowed: true,
+	}, {
+		name:         "installation limit",
+		keys:         []ratelimit.Key{{Limiter: user, Key: "3"}, {Limiter: installation, Key: "10"}},
+		wantRetryMin: 19 * time.Second,
+		wantRetryMax: 20 * time.Second,
+	}, {
+		// the rejected call didn't consume the budget of the user
+		name:        "user refilled",
+		at:          30 * time.Second,
+		keys:        []ratelimit.Key{{Limiter: user, Key: "3"}, {Limiter: installation, Key: "10"}},
+		wantAllowed: true,
+	}, {
+		name:        "disabled limiter",
+		keys:        []ratelimit.Key{{Limiter: ratelimit.NewLimiter(0), Key: "1"}},
+		wantAllowed: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, retryAfter := ratelimit.AllowAt(start.Add(tt.at), tt.keys...)
+			if allowed != tt.wantAllowed {
+				t.Fatalf("unexpected allowed %t, want %t", allowed, tt.wantAllowed)
+			}
+			if retryAfter < tt.wantRetryMin || retryAfter > tt.wantRetryMax {
+				t.Errorf("unexpected retry after %s, want between %s and %s",
+					retryAfter, tt.wantRetryMin, tt.wantRetryMax)
+			}
+		})
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	start := time.Now()
+	limiter := ratelimit.NewLimiter(60)
+
+	ratelimit.AllowAt(start, ratelimit.Key{Limiter: limiter, Key: "1"})
+	ratelimit.AllowAt(start.Add(30*time.Second), ratelimit.Key{Limiter: limiter, Key: "2"})
+	if limiter.Len() != 2 {
+		t.Fatalf("unexpected %d keys, want 2", limiter.Len())
+	}
+
+	// the first key was refilled, so its bucket is removed
+	ratelimit.AllowAt(start.Add(75*time.Second), ratelimit.Key{Limiter: limiter, Key: "3"})
+	if limiter.Len() != 2 {
+		t.Errorf("unexpected %d keys after sweep, want 2", limiter.Len())
+	}
+}
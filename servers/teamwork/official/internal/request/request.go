@@ -6,6 +6,9 @@ import (
 	"net"
 	"net/http"
 	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 type key struct{}
@@ -39,8 +42,12 @@ func WithInfo(ctx context.Context, info Info) context.Context {
 	return context.WithValue(ctx, key{}, info)
 }
 
-// SetProxyHeaders sets the proxy headers in the request.
+// SetProxyHeaders sets the proxy headers in the request, including the W3C
+// trace context of the active span, if any.
 func SetProxyHeaders(r *http.Request) {
+	// https://www.w3.org/TR/trace-context/
+	otel.GetTextMapPropagator().Inject(r.Context(), propagation.HeaderCarrier(r.Header))
+
 	info, ok := r.Context().Value(key{}).(Info)
 	if !ok {
 		return
@@ -70,14 +77,6 @@ func SetProxyHeaders(r *http.Request) {
 		if headerValue := r.Header.Get("X-Amzn-Trace-ID"); headerValue != "" {
 			r.Header.Set("X-Amzn-Trace-ID", headerValue)
 		}
-
-		// https://www.w3.org/TR/trace-context/
-		if headerValue := r.Header.Get("Traceparent"); headerValue != "" {
-			r.Header.Set("Traceparent", headerValue)
-		}
-		if headerValue := r.Header.Get("Tracestate"); headerValue != "" {
-			r.Header.Set("Tracestate", headerValue)
-		}
 	}
 
 	// RFC 7239
@@ -0,0 +1,145 @@
+// Package secret loads secrets (e.g. the bearer token) from a value, a file or
+// the output of a command, so they don't need to be set in plain environment
+// variables, where they show up in process listings and MCP client
+// configurations.
+package secret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCommandTTL is the default time the output of a command is reused
+// before running the command again.
+const DefaultCommandTTL = 5 * time.Minute
+
+// Source is where a secret is loaded from. When several are set, the value
+// takes precedence over the file, and the file over the command.
+type Source struct {
+	// Value is the secret itself.
+	Value string
+	// File is the path of a file containing the secret (e.g. a Docker or
+	// Kubernetes secret). The file is read again when modified.
+	File string
+	// Command is a shell command printing the secret (e.g. "pass show
+	// teamwork/token"). The command runs again when its output expires.
+	Command string
+	// CommandTTL is how long the output of the command is reused. Zero uses
+	// DefaultCommandTTL.
+	CommandTTL time.Duration
+}
+
+// IsSet returns true if the source has a value, a file or a command.
+func (s Source) IsSet() bool {
+	return s.Value != "" || s.File != "" || s.Command != ""
+}
+
+// Secret loads a secret from its source, caching it until the source changes.
+// It's safe for concurrent use.
+type Secret struct {
+	source Source
+
+	mu        sync.Mutex
+	value     string
+	modTime   time.Time
+	size      int64
+	expiresAt time.Time
+}
+
+// New creates a Secret loaded from the given source.
+func New(source Source) *Secret {
+	if source.CommandTTL <= 0 {
+		source.CommandTTL = DefaultCommandTTL
+	}
+	return &Secret{source: source}
+}
+
+// IsSet returns true if the secret has a source.
+func (s *Secret) IsSet() bool {
+	return s != nil && s.source.IsSet()
+}
+
+// Get returns the current value of the secret. Files are read again when their
+// modification time or size changes, and commands run again when their output
+// expires, so rotated secrets are picked up without restarting. The
+// surrounding whitespace (e.g. a trailing newline) is removed. It returns an
+// empty value when the secret has no source.
+func (s *Secret) Get(ctx context.Context) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	switch {
+	case s.source.Value != "":
+		return s.source.Value, nil
+	case s.source.File != "":
+		return s.fromFile()
+	case s.source.Command != "":
+		return s.fromCommand(ctx)
+	default:
+		return "", nil
+	}
+}
+
+func (s *Secret) fromFile() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.source.File)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	if s.value != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.value, nil
+	}
+
+	content, err := os.ReadFile(s.source.File)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	value := strings.TrimSpace(string(content))
+	if value == "" {
+		return "", fmt.Errorf("secret file %s is empty", s.source.File)
+	}
+	s.value, s.modTime, s.size = value, info.ModTime(), info.Size()
+	return s.value, nil
+}
+
+func (s *Secret) fromCommand(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.value != "" && now.Before(s.expiresAt) {
+		return s.value, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.source.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.source.Command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			err = fmt.Errorf("%w: %s", err, message)
+		}
+		return "", fmt.Errorf("failed to run secret command: %w", err)
+	}
+	value := strings.TrimSpace(string(output))
+	if value == "" {
+		return "", errors.New("secret command printed an empty value")
+	}
+	s.value, s.expiresAt = value, now.Add(s.source.CommandTTL)
+	return s.value, nil
+}
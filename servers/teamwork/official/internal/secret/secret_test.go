@@ -0,0 +1,143 @@
+package secret_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/teamwork/mcp/internal/secret"
+)
+
+func TestSecret(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		source  secret.Source
+		want    string
+		wantErr bool
+	}{{
+		name: "no source",
+	}, {
+		name:   "value",
+		source: secret.Source{Value: "from-value", File: file, Command: "echo from-command"},
+		want:   "from-value",
+	}, {
+		name:   "file takes precedence over command",
+		source: secret.Source{File: file, Command: "echo from-command"},
+		want:   "from-file",
+	}, {
+		name:   "command",
+		source: secret.Source{Command: "echo '  from-command  '"},
+		want:   "from-command",
+	}, {
+		name:    "missing file",
+		source:  secret.Source{File: filepath.Join(dir, "missing")},
+		wantErr: true,
+	}, {
+		name:    "failing command",
+		source:  secret.Source{Command: "echo failed >&2; exit 1"},
+		wantErr: true,
+	}, {
+		name:    "empty command output",
+		source:  secret.Source{Command: "true"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.source.Command != "" {
+				t.Skip("commands use a POSIX shell")
+			}
+			s := secret.New(tt.source)
+			if s.IsSet() != tt.source.IsSet() {
+				t.Errorf("unexpected IsSet %t", s.IsSet())
+			}
+			got, err := s.Get(t.Context())
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretFileRotation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("first"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	s := secret.New(secret.Source{File: file})
+	if got, err := s.Get(t.Context()); err != nil || got != "first" {
+		t.Fatalf("got %q (%v), want %q", got, err, "first")
+	}
+
+	if err := os.WriteFile(file, []byte("second"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	// make sure the modification time changes, even on coarse file systems
+	modTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatalf("failed to change modification time: %v", err)
+	}
+	if got, err := s.Get(t.Context()); err != nil || got != "second" {
+		t.Errorf("got %q (%v), want %q", got, err, "second")
+	}
+}
+
+func TestSecretCommandTTL(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands use a POSIX shell")
+	}
+	counter := filepath.Join(t.TempDir(), "counter")
+	// prints the number of times the command ran
+	command := "echo x >> " + counter + " && wc -l < " + counter
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want string
+	}{{
+		name: "cached",
+		ttl:  time.Hour,
+		want: "1",
+	}, {
+		name: "expired",
+		ttl:  time.Nanosecond,
+		want: "3",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Remove(counter); err != nil && !os.IsNotExist(err) {
+				t.Fatalf("failed to reset counter: %v", err)
+			}
+			s := secret.New(secret.Source{Command: command, CommandTTL: tt.ttl})
+			var got string
+			for range 3 {
+				var err error
+				if got, err = s.Get(t.Context()); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package streamable
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const sessionIDHeader = "Mcp-Session-Id"
+
+// Handler serves the MCP streamable HTTP transport in stateful mode. Each
+// client gets a session on initialization, identified by the Mcp-Session-Id
+// header, so the server can send notifications and progress, and the client
+// can resume a dropped stream with the Last-Event-ID header.
+//
+// A session can only be used with the same Authorization header that created
+// it. Sessions idle for longer than the TTL are closed.
+type Handler struct {
+	logger    *slog.Logger
+	getServer func(*http.Request) *mcp.Server
+	store     mcp.EventStore
+	ttl       time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*session
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type session struct {
+	id            string
+	owner         [sha256.Size]byte
+	transport     *mcp.StreamableServerTransport
+	serverSession *mcp.ServerSession
+
+	// lastSeen and active are protected by the handler mutex.
+	lastSeen time.Time
+	active   int
+}
+
+// NewHandler creates a stateful streamable HTTP handler. The events of the
+// sessions are kept in the given store, so the streams can be resumed. A zero
+// TTL disables the eviction of idle sessions.
+func NewHandler(
+	logger *slog.Logger,
+	getServer func(*http.Request) *mcp.Server,
+	store mcp.EventStore,
+	ttl time.Duration,
+) *Handler {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	h := &Handler{
+		logger:    logger,
+		getServer: getServer,
+		store:     store,
+		ttl:       ttl,
+		sessions:  make(map[string]*session),
+		done:      make(chan struct{}),
+	}
+	if ttl > 0 {
+		go h.evictLoop()
+	}
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(sessionIDHeader)
+	if sessionID == "" {
+		switch r.Method {
+		case http.MethodPost:
+			h.connect(w, r)
+		case http.MethodDelete:
+			http.Error(w, "DELETE requires an Mcp-Session-Id header", http.StatusBadRequest)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "GET requires an active session", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	s := h.acquire(sessionID, sessionOwner(r))
+	if s == nil {
+		// the client must start a new session
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	defer h.release(s)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodPost:
+		s.transport.ServeHTTP(w, r)
+	case http.MethodDelete:
+		h.closeSession(s, "deleted")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// SessionCount returns the number of active sessions.
+func (h *Handler) SessionCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.sessions)
+}
+
+// Close closes all sessions and stops the eviction of idle sessions. It's
+// safe to call it more than once.
+func (h *Handler) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+
+		h.mu.Lock()
+		sessions := make([]*session, 0, len(h.sessions))
+		for _, s := range h.sessions {
+			sessions = append(sessions, s)
+		}
+		h.mu.Unlock()
+
+		for _, s := range sessions {
+			h.closeSession(s, "shutdown")
+		}
+	})
+}
+
+// connect starts a new session for the request.
+func (h *Handler) connect(w http.ResponseWriter, r *http.Request) {
+	server := h.getServer(r)
+	if server == nil {
+		http.Error(w, "no server available", http.StatusBadRequest)
+		return
+	}
+
+	transport := &mcp.StreamableServerTransport{
+		SessionID:  rand.Text(),
+		EventStore: h.store,
+	}
+	// the request context is detached by the transport for the long-running
+	// session, keeping its values (e.g. the bearer token)
+	serverSession, err := server.Connect(r.Context(), transport, nil)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to connect session",
+			slog.String("error", err.Error()),
+		)
+		http.Error(w, "failed connection", http.StatusInternalServerError)
+		return
+	}
+
+	s := &session{
+		id:            transport.SessionID,
+		owner:         sessionOwner(r),
+		transport:     transport,
+		serverSession: serverSession,
+		lastSeen:      time.Now(),
+		active:        1,
+	}
+	h.mu.Lock()
+	h.sessions[s.id] = s
+	h.mu.Unlock()
+	defer h.release(s)
+
+	go func() {
+		_ = serverSession.Wait()
+		h.remove(s)
+	}()
+
+	h.logger.DebugContext(r.Context(), "session started",
+		slog.String("session_id", s.id),
+	)
+	transport.ServeHTTP(w, r)
+}
+
+// acquire returns the session with the given ID and owner, marking it as in
+// use.
+func (h *Handler) acquire(sessionID string, owner [sha256.Size]byte) *session {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.sessions[sessionID]
+	if !ok || s.owner != owner {
+		return nil
+	}
+	s.active++
+	s.lastSeen = time.Now()
+	return s
+}
+
+// release marks the end of a request of the session.
+func (h *Handler) release(s *session) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s.active--
+	s.lastSeen = time.Now()
+}
+
+func (h *Handler) remove(s *session) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.sessions[s.id] != s {
+		return false
+	}
+	delete(h.sessions, s.id)
+	return true
+}
+
+// closeSession closes the session, which also removes its events from the
+// store.
+func (h *Handler) closeSession(s *session, reason string) {
+	if h.remove(s) {
+		h.shutdown(s, reason)
+	}
+}
+
+func (h *Handler) shutdown(s *session, reason string) {
+	if err := s.serverSession.Close(); err != nil {
+		h.logger.Error("failed to close session",
+			slog.String("session_id", s.id),
+			slog.String("error", err.Error()),
+		)
+	}
+	h.logger.Debug("session closed",
+		slog.String("session_id", s.id),
+		slog.String("reason", reason),
+	)
+}
+
+func (h *Handler) evictLoop() {
+	ticker := time.NewTicker(min(h.ttl/2, time.Minute))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case now := <-ticker.C:
+			h.evict(now)
+		}
+	}
+}
+
+// evict closes the sessions without ongoing requests that were idle for longer
+// than the TTL. A session with an open stream (e.g. a hanging GET) is never
+// idle.
+func (h *Handler) evict(now time.Time) {
+	var expired []*session
+	h.mu.Lock()
+	for _, s := range h.sessions {
+		if s.active == 0 && now.Sub(s.lastSeen) > h.ttl {
+			// removed while locked, so no request can acquire it anymore
+			delete(h.sessions, s.id)
+			expired = append(expired, s)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, s := range expired {
+		h.shutdown(s, "expired")
+	}
+}
+
+// sessionOwner identifies the credentials that created a session, so it can't
+// be hijacked by another client knowing the session ID.
+func sessionOwner(r *http.Request) [sha256.Size]byte {
+	return sha256.Sum256([]byte(r.Header.Get("Authorization")))
+}
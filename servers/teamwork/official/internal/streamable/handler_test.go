@@ -0,0 +1,214 @@
+package streamable_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/streamable"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{` +
+	`"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+
+func TestHandler(t *testing.T) {
+	server := newServer()
+	handler := streamable.NewHandler(nil, func(*http.Request) *mcp.Server { return server },
+		mcp.NewMemoryEventStore(nil), time.Minute)
+	defer handler.Close()
+
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	toolListChanged := make(chan struct{}, 1)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case toolListChanged <- struct{}{}:
+			default:
+			}
+		},
+	})
+	clientSession, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:   httpServer.URL,
+		HTTPClient: &http.Client{Transport: authorizationTransport("Bearer owner")},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	sessionID := clientSession.ID()
+	if sessionID == "" {
+		t.Fatal("expected a session ID")
+	}
+
+	result, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{Name: "ping"})
+	if err != nil {
+		t.Fatalf("failed to call tool: %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "pong" {
+		t.Errorf("unexpected tool result %q", text)
+	}
+
+	// notifications reach the client through the session
+	mcp.AddTool(server, &mcp.Tool{Name: "new_tool"}, pingTool)
+	select {
+	case <-toolListChanged:
+	case <-time.After(5 * time.Second):
+		t.Error("expected a tools/list_changed notification")
+	}
+
+	t.Run("other owner", func(t *testing.T) {
+		resp := doRequest(t, httpServer.URL, http.MethodDelete, sessionID, "Bearer other", "")
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status %d", resp.StatusCode)
+		}
+	})
+
+	if err := clientSession.Close(); err != nil {
+		t.Fatalf("failed to close session: %v", err)
+	}
+
+	t.Run("closed session", func(t *testing.T) {
+		resp := doRequest(t, httpServer.URL, http.MethodPost, sessionID, "Bearer owner",
+			`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestHandlerRequests(t *testing.T) {
+	server := newServer()
+	handler := streamable.NewHandler(nil, func(*http.Request) *mcp.Server { return server },
+		mcp.NewMemoryEventStore(nil), time.Minute)
+	defer handler.Close()
+
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		sessionID  string
+		wantStatus int
+	}{{
+		name:       "get without session",
+		method:     http.MethodGet,
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:       "delete without session",
+		method:     http.MethodDelete,
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "unknown session",
+		method:     http.MethodGet,
+		sessionID:  "unknown",
+		wantStatus: http.StatusNotFound,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, httpServer.URL, tt.method, tt.sessionID, "", "")
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("unexpected status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("delete session", func(t *testing.T) {
+		sessionID := initialize(t, httpServer.URL)
+		resp := doRequest(t, httpServer.URL, http.MethodDelete, sessionID, "", "")
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		resp = doRequest(t, httpServer.URL, http.MethodDelete, sessionID, "", "")
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestHandlerEviction(t *testing.T) {
+	server := newServer()
+	handler := streamable.NewHandler(nil, func(*http.Request) *mcp.Server { return server },
+		mcp.NewMemoryEventStore(nil), 50*time.Millisecond)
+	defer handler.Close()
+
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	sessionID := initialize(t, httpServer.URL)
+	time.Sleep(300 * time.Millisecond)
+
+	resp := doRequest(t, httpServer.URL, http.MethodPost, sessionID, "",
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the idle session to be evicted, got status %d", resp.StatusCode)
+	}
+}
+
+func newServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "ping"}, pingTool)
+	return server
+}
+
+func pingTool(context.Context, *mcp.CallToolRequest, any) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: "pong"}},
+	}, nil, nil
+}
+
+// initialize starts a new session, returning its ID.
+func initialize(t *testing.T, url string) string {
+	t.Helper()
+
+	resp := doRequest(t, url, http.MethodPost, "", "", initializeRequest)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to initialize: status %d", resp.StatusCode)
+	}
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	if sessionID == "" {
+		t.Fatal("expected a session ID")
+	}
+	return sessionID
+}
+
+func doRequest(t *testing.T, url, method, sessionID, authorization, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	// the responses are small, so they are fully read to close the streams
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return resp
+}
+
+type authorizationTransport string
+
+func (a authorizationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", string(a))
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,143 @@
+package streamable
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS streams (
+	session_id TEXT NOT NULL,
+	stream_id  TEXT NOT NULL,
+	PRIMARY KEY (session_id, stream_id)
+);
+CREATE TABLE IF NOT EXISTS events (
+	session_id TEXT NOT NULL,
+	stream_id  TEXT NOT NULL,
+	idx        INTEGER NOT NULL,
+	data       BLOB NOT NULL,
+	PRIMARY KEY (session_id, stream_id, idx)
+);
+DELETE FROM events;
+DELETE FROM streams;
+`
+
+// SQLiteEventStore stores the events in a SQLite database, which is useful to
+// keep the memory bounded in a single node.
+type SQLiteEventStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteEventStore opens or creates the SQLite database in the given file,
+// removing the sessions left behind by a previous run.
+func NewSQLiteEventStore(path string) (*SQLiteEventStore, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event store database: %w", err)
+	}
+	// SQLite supports a single writer, so the connections are serialized to
+	// avoid locking errors
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize event store database: %w", err)
+	}
+	return &SQLiteEventStore{db: db}, nil
+}
+
+// Open implements the mcp.EventStore interface.
+func (s *SQLiteEventStore) Open(ctx context.Context, sessionID, streamID string) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR IGNORE INTO streams (session_id, stream_id) VALUES (?, ?)", sessionID, streamID)
+	if err != nil {
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	return nil
+}
+
+// Append implements the mcp.EventStore interface.
+func (s *SQLiteEventStore) Append(ctx context.Context, sessionID, streamID string, data []byte) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO events (session_id, stream_id, idx, data)
+		SELECT ?, ?, COALESCE(MAX(idx) + 1, 0), ?
+		FROM events WHERE session_id = ? AND stream_id = ?`,
+		sessionID, streamID, data, sessionID, streamID)
+	if err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+	return nil
+}
+
+// After implements the mcp.EventStore interface.
+func (s *SQLiteEventStore) After(ctx context.Context, sessionID, streamID string, index int) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		// the events are read before yielding them, so the single connection
+		// isn't held while new events are appended
+		events, err := s.readEvents(ctx, sessionID, streamID, index)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, event := range events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// SessionClosed implements the mcp.EventStore interface.
+func (s *SQLiteEventStore) SessionClosed(ctx context.Context, sessionID string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM events WHERE session_id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to remove session events: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM streams WHERE session_id = ?", sessionID); err != nil {
+		return fmt.Errorf("failed to remove session streams: %w", err)
+	}
+	return nil
+}
+
+// Close implements the io.Closer interface.
+func (s *SQLiteEventStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteEventStore) readEvents(ctx context.Context, sessionID, streamID string, index int) ([][]byte, error) {
+	var exists int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT 1 FROM streams WHERE session_id = ? AND stream_id = ?", sessionID, streamID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("unknown stream %q in session %q", streamID, sessionID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM events
+		WHERE session_id = ? AND stream_id = ? AND idx > ?
+		ORDER BY idx`,
+		sessionID, streamID, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var events [][]byte
+	for rows.Next() {
+		var event []byte
+		if err := rows.Scan(&event); err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	return events, nil
+}
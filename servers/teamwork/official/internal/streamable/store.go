@@ -0,0 +1,200 @@
+package streamable
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// List of event store kinds.
+const (
+	// EventStoreMemory keeps the events in memory, dropping the oldest ones when
+	// the memory limit is reached.
+	EventStoreMemory = "memory"
+	// EventStoreFile keeps the events in append-only files, one per stream.
+	EventStoreFile = "file"
+	// EventStoreSQLite keeps the events in a SQLite database.
+	EventStoreSQLite = "sqlite"
+)
+
+// EventStore stores the events of the sessions, so the streams can be resumed.
+type EventStore interface {
+	mcp.EventStore
+	io.Closer
+}
+
+// NewEventStore creates the event store of the given kind. The path is the
+// directory of the file store, or the database file of the SQLite store, and
+// defaults to a location in the temporary directory. Sessions don't survive a
+// restart, so the persistent stores are emptied when they are opened.
+func NewEventStore(kind, path string) (EventStore, error) {
+	switch strings.ToLower(kind) {
+	case "", EventStoreMemory:
+		return memoryEventStore{MemoryEventStore: mcp.NewMemoryEventStore(nil)}, nil
+	case EventStoreFile:
+		if path == "" {
+			path = filepath.Join(os.TempDir(), "tw-mcp-events")
+		}
+		return NewFileEventStore(path)
+	case EventStoreSQLite:
+		if path == "" {
+			path = filepath.Join(os.TempDir(), "tw-mcp-events.db")
+		}
+		return NewSQLiteEventStore(path)
+	default:
+		return nil, fmt.Errorf("unknown event store %q", kind)
+	}
+}
+
+type memoryEventStore struct {
+	*mcp.MemoryEventStore
+}
+
+func (memoryEventStore) Close() error {
+	return nil
+}
+
+// sessionDirSuffix identifies the directories created by the FileEventStore,
+// so it never removes anything else when cleaning up.
+const sessionDirSuffix = ".session"
+
+// FileEventStore stores the events in the filesystem. Each session has its own
+// directory, with an append-only file per stream where each event is stored as
+// a length-prefixed record.
+type FileEventStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileEventStore creates a FileEventStore in the given directory, removing
+// the sessions left behind by a previous run.
+func NewFileEventStore(dir string) (*FileEventStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event store directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event store directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasSuffix(entry.Name(), sessionDirSuffix) {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return nil, fmt.Errorf("failed to remove stale session: %w", err)
+			}
+		}
+	}
+	return &FileEventStore{dir: dir}, nil
+}
+
+// Open implements the mcp.EventStore interface.
+func (s *FileEventStore) Open(_ context.Context, sessionID, streamID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.sessionDir(sessionID), 0o700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	file, err := os.OpenFile(s.streamFile(sessionID, streamID), os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	return file.Close()
+}
+
+// Append implements the mcp.EventStore interface.
+func (s *FileEventStore) Append(_ context.Context, sessionID, streamID string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.streamFile(sessionID, streamID), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	record := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(data)), uint32(len(data))) //nolint:gosec
+	if _, err := file.Write(append(record, data...)); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+	return file.Close()
+}
+
+// After implements the mcp.EventStore interface.
+func (s *FileEventStore) After(_ context.Context, sessionID, streamID string, index int) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		// the events are read before yielding them, so the lock isn't held while
+		// new events are appended
+		events, err := s.readEvents(sessionID, streamID)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, event := range events[min(index+1, len(events)):] {
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// SessionClosed implements the mcp.EventStore interface.
+func (s *FileEventStore) SessionClosed(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.RemoveAll(s.sessionDir(sessionID)); err != nil {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
+
+// Close implements the io.Closer interface.
+func (s *FileEventStore) Close() error {
+	return nil
+}
+
+func (s *FileEventStore) readEvents(sessionID, streamID string) ([][]byte, error) {
+	s.mu.Lock()
+	content, err := os.ReadFile(s.streamFile(sessionID, streamID))
+	s.mu.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unknown stream %q in session %q", streamID, sessionID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	var events [][]byte
+	reader := bytes.NewReader(content)
+	for reader.Len() > 0 {
+		var size uint32
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			return nil, fmt.Errorf("corrupted stream %q in session %q: %w", streamID, sessionID, err)
+		}
+		event := make([]byte, size)
+		if _, err := io.ReadFull(reader, event); err != nil {
+			return nil, fmt.Errorf("corrupted stream %q in session %q: %w", streamID, sessionID, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// sessionDir returns the directory of the session. The IDs are hex encoded, as
+// they come from the clients and aren't safe to be used as file names.
+func (s *FileEventStore) sessionDir(sessionID string) string {
+	return filepath.Join(s.dir, hex.EncodeToString([]byte(sessionID))+sessionDirSuffix)
+}
+
+func (s *FileEventStore) streamFile(sessionID, streamID string) string {
+	return filepath.Join(s.sessionDir(sessionID), hex.EncodeToString([]byte(streamID))+".events")
+}
@@ -0,0 +1,144 @@
+package streamable_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/teamwork/mcp/internal/streamable"
+)
+
+func TestEventStore(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		path func(dir string) string
+	}{{
+		name: "memory",
+		kind: streamable.EventStoreMemory,
+	}, {
+		name: "file",
+		kind: streamable.EventStoreFile,
+		path: func(dir string) string { return dir },
+	}, {
+		name: "sqlite",
+		kind: streamable.EventStoreSQLite,
+		path: func(dir string) string { return filepath.Join(dir, "events.db") },
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			if tt.path != nil {
+				path = tt.path(t.TempDir())
+			}
+			store, err := streamable.NewEventStore(tt.kind, path)
+			if err != nil {
+				t.Fatalf("failed to create event store: %v", err)
+			}
+			defer store.Close() //nolint:errcheck
+
+			ctx := t.Context()
+			if err := store.Open(ctx, "session", "stream"); err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+			if err := store.Open(ctx, "other/session", "stream"); err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+			for _, event := range []string{"first", "second", "third"} {
+				if err := store.Append(ctx, "session", "stream", []byte(event)); err != nil {
+					t.Fatalf("failed to append event: %v", err)
+				}
+			}
+			if err := store.Append(ctx, "other/session", "stream", []byte("other")); err != nil {
+				t.Fatalf("failed to append event: %v", err)
+			}
+
+			collect := func(sessionID, streamID string, index int) ([]string, error) {
+				var events []string
+				for event, err := range store.After(ctx, sessionID, streamID, index) {
+					if err != nil {
+						return nil, err
+					}
+					events = append(events, string(event))
+				}
+				return events, nil
+			}
+
+			events, err := collect("session", "stream", -1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := []string{"first", "second", "third"}; !slices.Equal(events, want) {
+				t.Errorf("unexpected events %v, want %v", events, want)
+			}
+
+			events, err = collect("session", "stream", 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := []string{"second", "third"}; !slices.Equal(events, want) {
+				t.Errorf("unexpected events %v, want %v", events, want)
+			}
+
+			if _, err := collect("session", "unknown", -1); err == nil {
+				t.Error("expected an error for an unknown stream")
+			}
+
+			if err := store.SessionClosed(ctx, "session"); err != nil {
+				t.Fatalf("failed to close session: %v", err)
+			}
+			if _, err := collect("session", "stream", -1); err == nil {
+				t.Error("expected an error for a closed session")
+			}
+
+			events, err = collect("other/session", "stream", -1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := []string{"other"}; !slices.Equal(events, want) {
+				t.Errorf("unexpected events %v, want %v", events, want)
+			}
+		})
+	}
+}
+
+func TestEventStoreUnknownKind(t *testing.T) {
+	if _, err := streamable.NewEventStore("redis", ""); err == nil {
+		t.Error("expected an error for an unknown event store")
+	}
+}
+
+func TestEventStoreRestart(t *testing.T) {
+	for _, kind := range []string{streamable.EventStoreFile, streamable.EventStoreSQLite} {
+		t.Run(kind, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "events")
+
+			store, err := streamable.NewEventStore(kind, path)
+			if err != nil {
+				t.Fatalf("failed to create event store: %v", err)
+			}
+			if err := store.Open(t.Context(), "session", "stream"); err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+			if err := store.Close(); err != nil {
+				t.Fatalf("failed to close event store: %v", err)
+			}
+
+			// sessions don't survive a restart
+			store, err = streamable.NewEventStore(kind, path)
+			if err != nil {
+				t.Fatalf("failed to reopen event store: %v", err)
+			}
+			defer store.Close() //nolint:errcheck
+
+			var stale bool
+			for _, err := range store.After(t.Context(), "session", "stream", -1) {
+				stale = err != nil
+			}
+			if !stale {
+				t.Error("expected an error for a stale session")
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/toolsets"
 	"github.com/teamwork/mcp/internal/twdesk"
 	"github.com/teamwork/mcp/internal/twprojects"
@@ -90,7 +91,7 @@ func DeskMCPServerMock(t *testing.T, status int, response []byte) (*mcp.Server,
 		testServer.Close()
 	}
 
-	toolsetGroup := twdesk.DefaultToolsetGroup(client)
+	toolsetGroup := twdesk.DefaultToolsetGroup(false, true, client, deskapi.NewClient(testServer.URL, nil))
 	if err := toolsetGroup.EnableToolsets(toolsets.MethodAll); err != nil {
 		cleanup()
 		t.Fatalf("failed to enable toolsets: %v", err)
@@ -0,0 +1,51 @@
+package toolsets
+
+import (
+	"strconv"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// CoerceArguments converts the string values of the tool arguments to the
+// integer, number or boolean type declared by the input schema, when the
+// string can be parsed as such (e.g. "123" for an integer ID). The arguments
+// are the decoded JSON value, and are modified in place for nested objects and
+// arrays. Values that can't be parsed are kept, so the validation reports
+// them.
+//
+// The tools accepted numeric strings before their arguments were typed, and
+// some clients still send them, so they are accepted for compatibility.
+func CoerceArguments(schema *jsonschema.Schema, value any) any {
+	if schema == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for name, propertyValue := range v {
+			if propertySchema, ok := schema.Properties[name]; ok {
+				v[name] = CoerceArguments(propertySchema, propertyValue)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = CoerceArguments(schema.Items, item)
+		}
+	case string:
+		switch schema.Type {
+		case "integer":
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+	}
+	return value
+}
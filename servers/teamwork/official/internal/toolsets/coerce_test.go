@@ -0,0 +1,66 @@
+package toolsets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestCoerceArguments(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"id":       {Type: "integer"},
+			"rate":     {Type: "number"},
+			"archived": {Type: "boolean"},
+			"name":     {Type: "string"},
+			"tagIds":   {Type: "array", Items: &jsonschema.Schema{Type: "integer"}},
+			"owner": {
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {Type: "integer"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		arguments string
+		want      string
+	}{{
+		name:      "numeric strings",
+		arguments: `{"id":"123","rate":"1.5","archived":"true","name":"123"}`,
+		want:      `{"archived":true,"id":123,"name":"123","rate":1.5}`,
+	}, {
+		name:      "nested values",
+		arguments: `{"tagIds":["1",2],"owner":{"id":"3"}}`,
+		want:      `{"owner":{"id":3},"tagIds":[1,2]}`,
+	}, {
+		name:      "values that can't be parsed are kept",
+		arguments: `{"id":"one","rate":"fast","archived":"maybe","unknown":"1"}`,
+		want:      `{"archived":"maybe","id":"one","rate":"fast","unknown":"1"}`,
+	}, {
+		name:      "typed values are kept",
+		arguments: `{"id":123,"archived":false}`,
+		want:      `{"archived":false,"id":123}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arguments any
+			if err := json.Unmarshal([]byte(tt.arguments), &arguments); err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(toolsets.CoerceArguments(schema, arguments))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected arguments %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
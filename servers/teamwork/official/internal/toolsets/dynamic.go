@@ -0,0 +1,296 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// List of meta-tools available to discover and enable Toolsets at runtime.
+// They don't belong to any product, so they are not prefixed like the other
+// methods.
+const (
+	MethodListAvailableToolsets Method = "list_available_toolsets"
+	MethodGetToolsetTools       Method = "get_toolset_tools"
+	MethodEnableToolset         Method = "enable_toolset"
+)
+
+// MethodDynamic is the name of the Toolset that groups the meta-tools used to
+// discover and enable other Toolsets at runtime.
+const MethodDynamic Method = "dynamic"
+
+const dynamicDescription = "Toolsets are groups of related tools that are disabled by default to keep the " +
+	"list of tools small. Use these tools to discover the available toolsets and enable only the ones needed " +
+	"for the current task. Once a toolset is enabled its tools are immediately available."
+
+var (
+	toolsetListOutputSchema      *jsonschema.Schema
+	toolsetToolsListOutputSchema *jsonschema.Schema
+)
+
+func init() {
+	// register the toolset methods
+	RegisterMethod(MethodListAvailableToolsets)
+	RegisterMethod(MethodGetToolsetTools)
+	RegisterMethod(MethodEnableToolset)
+
+	var err error
+
+	// generate the output schemas only once
+	toolsetListOutputSchema, err = jsonschema.For[toolsetListResponse](&jsonschema.ForOptions{})
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate JSON schema for toolsetListResponse: %v", err))
+	}
+	toolsetToolsListOutputSchema, err = jsonschema.For[toolsetToolsListResponse](&jsonschema.ForOptions{})
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate JSON schema for toolsetToolsListResponse: %v", err))
+	}
+}
+
+type toolsetInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Tools       int    `json:"tools"`
+}
+
+type toolsetListResponse struct {
+	Toolsets []toolsetInfo `json:"toolsets"`
+}
+
+type toolInfo struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description"`
+	ReadOnly    bool   `json:"readOnly"`
+}
+
+type toolsetToolsListResponse struct {
+	Toolset string     `json:"toolset"`
+	Enabled bool       `json:"enabled"`
+	Tools   []toolInfo `json:"tools"`
+}
+
+// NewDynamicToolsetGroup creates a ToolsetGroup containing the meta-tools that
+// allow the LLM to list, inspect and enable the Toolsets of the given groups at
+// runtime. The returned group is already enabled, and as the meta-tools don't
+// change any data they are also available in read-only mode.
+func NewDynamicToolsetGroup(groups ...*ToolsetGroup) *ToolsetGroup {
+	group := NewToolsetGroup(false)
+	group.AddToolset(NewToolset(MethodDynamic, dynamicDescription).
+		AddReadTools(
+			ListAvailableToolsets(groups...),
+			GetToolsetTools(groups...),
+			EnableToolsetTool(groups...),
+		))
+	if err := group.EnableToolset(MethodDynamic); err != nil {
+		// should never happen, the toolset was just added
+		panic(fmt.Sprintf("failed to enable dynamic toolset: %v", err))
+	}
+	return group
+}
+
+// ListAvailableToolsets lists all Toolsets from the given groups.
+func ListAvailableToolsets(groups ...*ToolsetGroup) ToolWrapper {
+	return ToolWrapper{
+		Tool: &mcp.Tool{
+			Name: string(MethodListAvailableToolsets),
+			Description: "List all available toolsets, indicating which ones are already enabled in the current " +
+				"session. " + dynamicDescription,
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Available Toolsets",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			OutputSchema: toolsetListOutputSchema,
+		},
+		Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			response := toolsetListResponse{
+				Toolsets: []toolsetInfo{},
+			}
+			for _, group := range groups {
+				group.mutex.RLock()
+				for _, toolset := range group.Toolsets {
+					response.Toolsets = append(response.Toolsets, toolsetInfo{
+						Name:        toolset.Method.String(),
+						Description: toolset.Description,
+						Enabled:     toolset.Enabled,
+						Tools:       len(toolset.GetAvailableTools()),
+					})
+				}
+				group.mutex.RUnlock()
+			}
+			slices.SortFunc(response.Toolsets, func(a, b toolsetInfo) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+			return newToolResultJSON(response)
+		},
+	}
+}
+
+// GetToolsetTools lists the tools available in a specific Toolset from the
+// given groups.
+func GetToolsetTools(groups ...*ToolsetGroup) ToolWrapper {
+	return ToolWrapper{
+		Tool: &mcp.Tool{
+			Name: string(MethodGetToolsetTools),
+			Description: "List the tools available in a specific toolset. Use this to decide if a toolset " +
+				"should be enabled. " + dynamicDescription,
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Get Toolset Tools",
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"toolset": {
+						Type:        "string",
+						Description: "The name of the toolset, as returned by " + string(MethodListAvailableToolsets) + ".",
+					},
+				},
+				Required: []string{"toolset"},
+			},
+			OutputSchema: toolsetToolsListOutputSchema,
+		},
+		Handler: func(_ context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			method, err := toolsetArgument(request)
+			if err != nil {
+				return newToolResultTextError(err.Error()), nil
+			}
+
+			group, toolset, err := findToolset(method, groups...)
+			if err != nil {
+				return newToolResultTextError(err.Error()), nil
+			}
+
+			group.mutex.RLock()
+			response := toolsetToolsListResponse{
+				Toolset: toolset.Method.String(),
+				Enabled: toolset.Enabled,
+				Tools:   []toolInfo{},
+			}
+			for _, tool := range toolset.GetAvailableTools() {
+				info := toolInfo{
+					Name:        tool.Tool.Name,
+					Description: tool.Tool.Description,
+				}
+				if tool.Tool.Annotations != nil {
+					info.Title = tool.Tool.Annotations.Title
+					info.ReadOnly = tool.Tool.Annotations.ReadOnlyHint
+				}
+				response.Tools = append(response.Tools, info)
+			}
+			group.mutex.RUnlock()
+
+			return newToolResultJSON(response)
+		},
+	}
+}
+
+// EnableToolsetTool enables a Toolset from the given groups in the current
+// session. The MCP server notifies the client that the list of tools changed.
+func EnableToolsetTool(groups ...*ToolsetGroup) ToolWrapper {
+	return ToolWrapper{
+		Tool: &mcp.Tool{
+			Name: string(MethodEnableToolset),
+			Description: "Enable a toolset in the current session, making all its tools available. " +
+				dynamicDescription,
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Enable Toolset",
+				// enabling a toolset doesn't change any data, it only changes the tools
+				// exposed in the session
+				ReadOnlyHint:   true,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"toolset": {
+						Type:        "string",
+						Description: "The name of the toolset to enable, as returned by " + string(MethodListAvailableToolsets) + ".",
+					},
+				},
+				Required: []string{"toolset"},
+			},
+		},
+		Handler: func(_ context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			method, err := toolsetArgument(request)
+			if err != nil {
+				return newToolResultTextError(err.Error()), nil
+			}
+
+			group, toolset, err := findToolset(method, groups...)
+			if err != nil {
+				return newToolResultTextError(err.Error()), nil
+			}
+			if group.IsEnabled(method) {
+				return newToolResultText(fmt.Sprintf("Toolset %q is already enabled", method)), nil
+			}
+			if err := group.EnableToolset(method); err != nil {
+				return newToolResultTextError(fmt.Sprintf("failed to enable toolset: %s", err)), nil
+			}
+			return newToolResultText(fmt.Sprintf("Toolset %q enabled successfully with %d tools",
+				method, len(toolset.GetAvailableTools()))), nil
+		},
+	}
+}
+
+func toolsetArgument(request *mcp.CallToolRequest) (Method, error) {
+	var arguments struct {
+		Toolset string `json:"toolset"`
+	}
+	if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
+		return "", fmt.Errorf("failed to decode request: %w", err)
+	}
+	if arguments.Toolset == "" {
+		return "", errors.New("invalid parameters: parameter toolset is required")
+	}
+	return Method(arguments.Toolset), nil
+}
+
+func findToolset(method Method, groups ...*ToolsetGroup) (*ToolsetGroup, *Toolset, error) {
+	for _, group := range groups {
+		if toolset, err := group.GetToolset(method); err == nil {
+			return group, toolset, nil
+		}
+	}
+	return nil, nil, NewToolsetDoesNotExistError(method)
+}
+
+// The helpers package can't be used here as it would create an import cycle,
+// so we keep some minimal result builders.
+
+func newToolResultText(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: text,
+			},
+		},
+	}
+}
+
+func newToolResultTextError(text string) *mcp.CallToolResult {
+	result := newToolResultText(text)
+	result.IsError = true
+	return result
+}
+
+func newToolResultJSON(v any) (*mcp.CallToolResult, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	result := newToolResultText(string(encoded))
+	result.StructuredContent = v
+	return result, nil
+}
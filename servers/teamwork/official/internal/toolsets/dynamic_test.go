@@ -0,0 +1,128 @@
+package toolsets_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestDynamicToolsetGroup(t *testing.T) {
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset("example", "Example toolset.").
+		AddReadTools(toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name: "example-get_item",
+				Annotations: &mcp.ToolAnnotations{
+					Title:        "Get Item",
+					ReadOnlyHint: true,
+				},
+				InputSchema: &jsonschema.Schema{Type: "object"},
+			},
+			Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			},
+		}))
+
+	mcpServer := mcp.NewServer(&mcp.Implementation{
+		Name:    "test-server",
+		Version: "1.0.0",
+	}, &mcp.ServerOptions{HasTools: true})
+	group.RegisterAll(mcpServer)
+	toolsets.NewDynamicToolsetGroup(group).RegisterAll(mcpServer)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect to server: %v", err)
+	}
+
+	listChanged := make(chan struct{}, 1)
+	client := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case listChanged <- struct{}{}:
+			default:
+			}
+		},
+	})
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	toolNames := func() []string {
+		result, err := clientSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+		if err != nil {
+			t.Fatalf("failed to list tools: %v", err)
+		}
+		var names []string
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+
+	if names := toolNames(); slices.Contains(names, "example-get_item") {
+		t.Fatalf("tool should not be available before enabling the toolset: %v", names)
+	}
+
+	result, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name: toolsets.MethodListAvailableToolsets.String(),
+	})
+	if err != nil {
+		t.Fatalf("failed to call tool: %v", err)
+	}
+	var listResponse struct {
+		Toolsets []struct {
+			Name    string `json:"name"`
+			Enabled bool   `json:"enabled"`
+		} `json:"toolsets"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &listResponse); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listResponse.Toolsets) != 1 || listResponse.Toolsets[0].Name != "example" || listResponse.Toolsets[0].Enabled {
+		t.Errorf("unexpected toolsets: %+v", listResponse.Toolsets)
+	}
+
+	result, err = clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      toolsets.MethodEnableToolset.String(),
+		Arguments: map[string]any{"toolset": "example"},
+	})
+	if err != nil {
+		t.Fatalf("failed to call tool: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("failed to enable toolset: %v", result.Content)
+	}
+
+	select {
+	case <-listChanged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a tools/list_changed notification")
+	}
+
+	if names := toolNames(); !slices.Contains(names, "example-get_item") {
+		t.Errorf("tool should be available after enabling the toolset: %v", names)
+	}
+
+	result, err = clientSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      toolsets.MethodEnableToolset.String(),
+		Arguments: map[string]any{"toolset": "unknown"},
+	})
+	if err != nil {
+		t.Fatalf("failed to call tool: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected an error when enabling an unknown toolset")
+	}
+}
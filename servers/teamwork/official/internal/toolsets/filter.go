@@ -0,0 +1,91 @@
+package toolsets
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ToolFilter restricts the tools of a Toolset using glob patterns over the
+// method names (e.g. "twprojects-list_*"). The patterns follow the syntax of
+// path.Match.
+type ToolFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewToolFilter creates a new ToolFilter. When include patterns are provided,
+// only the tools matching at least one of them are kept. Tools matching any
+// exclude pattern are always removed.
+//
+// Every pattern is validated against the registered methods, so it returns an
+// error for malformed patterns or patterns that don't match any known method.
+func NewToolFilter(include, exclude []string) (*ToolFilter, error) {
+	registeredMethodsMutex.RLock()
+	methods := make([]string, 0, len(registeredMethods))
+	for method := range registeredMethods {
+		methods = append(methods, method.String())
+	}
+	registeredMethodsMutex.RUnlock()
+
+	var errs error
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid tool pattern %q: %w", pattern, err))
+			continue
+		}
+		if !slices.ContainsFunc(methods, func(method string) bool {
+			matched, _ := path.Match(pattern, method)
+			return matched
+		}) {
+			errs = errors.Join(errs, fmt.Errorf("tool pattern %q doesn't match any tool", pattern))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &ToolFilter{
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// Allowed checks if the tool with the given name passes the filter. A nil
+// filter allows every tool.
+func (f *ToolFilter) Allowed(name string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	return !matchAny(f.exclude, name)
+}
+
+// String returns a human-readable representation of the filter.
+func (f *ToolFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("include: [%s], exclude: [%s]",
+		strings.Join(f.include, ", "), strings.Join(f.exclude, ", "))
+}
+
+func (f *ToolFilter) apply(tools []ToolWrapper) []ToolWrapper {
+	if f == nil {
+		return tools
+	}
+	return slices.DeleteFunc(slices.Clone(tools), func(tool ToolWrapper) bool {
+		return !f.Allowed(tool.Tool.Name)
+	})
+}
+
+func matchAny(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, _ := path.Match(pattern, name)
+		return matched
+	})
+}
@@ -0,0 +1,113 @@
+package toolsets_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func init() {
+	toolsets.RegisterMethod("filter-get_item")
+	toolsets.RegisterMethod("filter-list_items")
+	toolsets.RegisterMethod("filter-create_item")
+}
+
+func TestNewToolFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		wantErr bool
+	}{{
+		name: "no patterns",
+	}, {
+		name:    "exact method",
+		include: []string{"filter-get_item"},
+	}, {
+		name:    "glob patterns",
+		include: []string{"filter-*"},
+		exclude: []string{"filter-list_*"},
+	}, {
+		name:    "malformed pattern",
+		include: []string{"filter-[get"},
+		wantErr: true,
+	}, {
+		name:    "unknown method",
+		exclude: []string{"unknown-*"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := toolsets.NewToolFilter(tt.include, tt.exclude)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewToolFilter() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToolsetGroupToolFilter(t *testing.T) {
+	newTool := func(name string, readOnly bool) toolsets.ToolWrapper {
+		return toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name:        name,
+				Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+				InputSchema: &jsonschema.Schema{Type: "object"},
+			},
+			Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			},
+		}
+	}
+
+	filter, err := toolsets.NewToolFilter([]string{"filter-*_item*"}, []string{"filter-list_*"})
+	if err != nil {
+		t.Fatalf("failed to create tool filter: %v", err)
+	}
+
+	group := toolsets.NewToolsetGroup(false)
+	group.SetToolFilter(filter)
+	group.AddToolset(toolsets.NewToolset("filter", "Filter toolset.").
+		AddReadTools(
+			newTool("filter-get_item", true),
+			newTool("filter-list_items", true),
+		).
+		AddWriteTools(
+			newTool("filter-create_item", false),
+		))
+	if err := group.EnableToolsets(toolsets.MethodAll); err != nil {
+		t.Fatalf("failed to enable toolsets: %v", err)
+	}
+
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+	group.RegisterAll(mcpServer)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect to server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect to client: %v", err)
+	}
+	defer clientSession.Close() //nolint:errcheck
+
+	result, err := clientSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+	if err != nil {
+		t.Fatalf("failed to list tools: %v", err)
+	}
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	if expected := []string{"filter-create_item", "filter-get_item"}; !slices.Equal(names, expected) {
+		t.Errorf("expected tools %v, got %v", expected, names)
+	}
+}
@@ -5,9 +5,11 @@
 package toolsets
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -113,9 +115,9 @@ func NewServerPrompt(prompt *mcp.Prompt, handler mcp.PromptHandler) ServerPrompt
 type ToolWrapper struct {
 	Tool *mcp.Tool
 
-	// Ideally we would use mcp.TooHandlerFor for easier parsing of parameters and
-	// error handling, but it would require loads of changes from the existing
-	// structure. So for now we just use the raw handler.
+	// Handler is the raw handler of the tool. Use NewTypedToolWrapper to decode
+	// and validate the arguments into a Go struct, similar to mcp.ToolHandlerFor
+	// but keeping the same structure for all tools.
 	//
 	// https://pkg.go.dev/github.com/modelcontextprotocol/go-sdk@v1.0.0/mcp#ToolHandlerFor
 	Handler mcp.ToolHandler
@@ -128,6 +130,7 @@ type Toolset struct {
 	Description string
 	Enabled     bool
 	readOnly    bool
+	filter      *ToolFilter
 	writeTools  []ToolWrapper
 	readTools   []ToolWrapper
 	// resources are not tools, but the community seems to be moving towards
@@ -154,35 +157,35 @@ func NewToolset(method Method, description string) *Toolset {
 // If the Toolset is not enabled, it returns nil.
 func (t *Toolset) GetActiveTools() []ToolWrapper {
 	if t.Enabled {
-		if t.readOnly {
-			return t.readTools
-		}
-		return append(t.readTools, t.writeTools...)
+		return t.GetAvailableTools()
 	}
 	return nil
 }
 
-// GetAvailableTools returns the tools that are available in the Toolset.
+// GetAvailableTools returns the tools that are available in the Toolset. Tools
+// rejected by the Toolset's filter are not included.
 func (t *Toolset) GetAvailableTools() []ToolWrapper {
 	if t.readOnly {
-		return t.readTools
+		return t.filter.apply(t.readTools)
 	}
-	return append(t.readTools, t.writeTools...)
+	return t.filter.apply(append(t.readTools, t.writeTools...))
 }
 
-// RegisterTools registers the tools in the Toolset with the MCP server.
+// RegisterTools registers the tools in the Toolset with the MCP server. Tools
+// rejected by the Toolset's filter are not registered.
 func (t *Toolset) RegisterTools(s *mcp.Server) {
 	if !t.Enabled {
 		return
 	}
-	for _, toolWrapper := range t.readTools {
+	for _, toolWrapper := range t.GetAvailableTools() {
 		s.AddTool(toolWrapper.Tool, toolWrapper.Handler)
 	}
-	if !t.readOnly {
-		for _, tool := range t.writeTools {
-			s.AddTool(tool.Tool, tool.Handler)
-		}
-	}
+}
+
+func (t *Toolset) register(s *mcp.Server) {
+	t.RegisterTools(s)
+	t.RegisterResourcesTemplates(s)
+	t.RegisterPrompts(s)
 }
 
 // AddResourceTemplates adds resource templates to the Toolset. These templates
@@ -244,6 +247,12 @@ func (t *Toolset) SetReadOnly() {
 	t.readOnly = true
 }
 
+// SetToolFilter sets the filter used to restrict the tools of the Toolset. A
+// nil filter allows every tool.
+func (t *Toolset) SetToolFilter(filter *ToolFilter) {
+	t.filter = filter
+}
+
 // AddWriteTools adds write tools to the Toolset. If the Toolset is read-only,
 // this method will silently ignore the tools to avoid breaching the read-only
 // contract. If a tool is incorrectly annotated as read-only, it will panic.
@@ -279,6 +288,11 @@ type ToolsetGroup struct {
 	Toolsets     map[Method]*Toolset
 	everythingOn bool
 	readOnly     bool
+	filter       *ToolFilter
+	// servers keeps track of the MCP servers where the group was registered, so
+	// Toolsets enabled at runtime can also be registered there.
+	servers []*mcp.Server
+	mutex   sync.RWMutex
 }
 
 // NewToolsetGroup creates a new ToolsetGroup. If readOnly is true, all Toolsets
@@ -298,12 +312,31 @@ func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
 	if tg.readOnly {
 		ts.SetReadOnly()
 	}
+	if tg.filter != nil {
+		ts.SetToolFilter(tg.filter)
+	}
 	tg.Toolsets[ts.Method] = ts
 }
 
+// SetToolFilter sets the filter used to restrict the tools of every Toolset in
+// the ToolsetGroup, including the ones added later. It must be called before
+// registering the ToolsetGroup with the MCP server.
+func (tg *ToolsetGroup) SetToolFilter(filter *ToolFilter) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	tg.filter = filter
+	for _, toolset := range tg.Toolsets {
+		toolset.SetToolFilter(filter)
+	}
+}
+
 // IsEnabled checks if a Toolset with the given method is enabled in the
 // ToolsetGroup.
 func (tg *ToolsetGroup) IsEnabled(method Method) bool {
+	tg.mutex.RLock()
+	defer tg.mutex.RUnlock()
+
 	// If everythingOn is true, all features are enabled
 	if tg.everythingOn {
 		return true
@@ -343,22 +376,65 @@ func (tg *ToolsetGroup) EnableToolsets(methods ...Method) error {
 
 // EnableToolset enables a Toolset by its method. If the Toolset does not exist,
 // it returns a ToolsetDoesNotExistError.
+//
+// When the ToolsetGroup was already registered with MCP servers, the Toolset is
+// also registered with them. The MCP server takes care of sending the
+// "notifications/tools/list_changed" notification to the connected clients.
 func (tg *ToolsetGroup) EnableToolset(method Method) error {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
 	toolset, exists := tg.Toolsets[method]
 	if !exists {
 		return NewToolsetDoesNotExistError(method)
 	}
+	if toolset.Enabled {
+		return nil
+	}
 	toolset.Enabled = true
-	tg.Toolsets[method] = toolset
+
+	for _, s := range tg.servers {
+		toolset.register(s)
+	}
 	return nil
 }
 
+// AddInputProperty adds a property to the input schema of every tool in the
+// ToolsetGroup, for arguments handled by a middleware instead of the tools
+// (e.g. selecting the installation). It must be called before registering the
+// ToolsetGroup with the MCP server.
+func (tg *ToolsetGroup) AddInputProperty(name string, schema *jsonschema.Schema) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	for _, toolset := range tg.Toolsets {
+		for _, tools := range [][]ToolWrapper{toolset.readTools, toolset.writeTools} {
+			for _, tool := range tools {
+				inputSchema, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+				if !ok || inputSchema == nil {
+					continue
+				}
+				// the schema could be shared with other tools
+				inputSchema = inputSchema.CloneSchemas()
+				if inputSchema.Properties == nil {
+					inputSchema.Properties = make(map[string]*jsonschema.Schema)
+				}
+				inputSchema.Properties[name] = schema
+				tool.Tool.InputSchema = inputSchema
+			}
+		}
+	}
+}
+
 // RegisterAll registers all Toolsets in the ToolsetGroup with the MCP server.
+// Only the tools allowed by the ToolsetGroup's filter are registered.
 func (tg *ToolsetGroup) RegisterAll(s *mcp.Server) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	tg.servers = append(tg.servers, s)
 	for _, toolset := range tg.Toolsets {
-		toolset.RegisterTools(s)
-		toolset.RegisterResourcesTemplates(s)
-		toolset.RegisterPrompts(s)
+		toolset.register(s)
 	}
 }
 
@@ -383,3 +459,36 @@ func (tg *ToolsetGroup) HasTools() bool {
 	}
 	return false
 }
+
+// EnableToolsetsInGroups enables the Toolsets with the given methods across
+// multiple ToolsetGroups. Each method only needs to exist in one of the groups.
+// If "all" is included in the methods, it will enable all Toolsets in every
+// group. If a method doesn't exist in any group, it returns a
+// ToolsetDoesNotExistError.
+func EnableToolsetsInGroups(methods []Method, groups ...*ToolsetGroup) error {
+	for _, method := range methods {
+		if method == MethodAll {
+			for _, group := range groups {
+				if err := group.EnableToolsets(MethodAll); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		var found bool
+		for _, group := range groups {
+			err := group.EnableToolset(method)
+			if errors.Is(err, NewToolsetDoesNotExistError(method)) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			found = true
+		}
+		if !found {
+			return NewToolsetDoesNotExistError(method)
+		}
+	}
+	return nil
+}
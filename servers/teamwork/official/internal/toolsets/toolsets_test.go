@@ -0,0 +1,52 @@
+package toolsets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+func TestToolsetGroupAddInputProperty(t *testing.T) {
+	// both tools share the same input schema
+	sharedSchema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"id": {Type: "integer"},
+		},
+	}
+	newTool := func(name string, readOnly bool) toolsets.ToolWrapper {
+		return toolsets.ToolWrapper{
+			Tool: &mcp.Tool{
+				Name:        name,
+				Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+				InputSchema: sharedSchema,
+			},
+			Handler: func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			},
+		}
+	}
+	readTool, writeTool := newTool("property-get_item", true), newTool("property-update_item", false)
+
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolsets.NewToolset("property", "Property toolset.").
+		AddReadTools(readTool).
+		AddWriteTools(writeTool))
+	group.AddInputProperty("installation", &jsonschema.Schema{Type: "string"})
+
+	for _, tool := range []*mcp.Tool{readTool.Tool, writeTool.Tool} {
+		inputSchema := tool.InputSchema.(*jsonschema.Schema)
+		if inputSchema.Properties["installation"] == nil || inputSchema.Properties["id"] == nil {
+			t.Errorf("unexpected input schema of %s: %v", tool.Name, inputSchema.Properties)
+		}
+		if inputSchema == sharedSchema {
+			t.Errorf("expected a copy of the input schema of %s", tool.Name)
+		}
+	}
+	if _, ok := sharedSchema.Properties["installation"]; ok {
+		t.Error("unexpected change of the shared input schema")
+	}
+}
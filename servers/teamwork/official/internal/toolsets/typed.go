@@ -0,0 +1,190 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	typeSchemas      = make(map[reflect.Type]*jsonschema.Schema)
+	typeSchemasMutex sync.RWMutex
+)
+
+// RegisterTypeSchema registers the JSON schema used for the type T when
+// building the input schema of typed tools. This is useful for types with a
+// custom JSON encoding, like dates encoded as strings.
+func RegisterTypeSchema[T any](schema *jsonschema.Schema) {
+	typeSchemasMutex.Lock()
+	defer typeSchemasMutex.Unlock()
+	typeSchemas[reflect.TypeFor[T]()] = schema
+}
+
+// TypedToolHandler handles a tool call with the arguments already decoded and
+// validated into the input type.
+type TypedToolHandler[In any] func(ctx context.Context, request *mcp.CallToolRequest, input In) (*mcp.CallToolResult, error)
+
+// InputSchemaOption allows to adjust the input schema generated for a typed
+// tool, for constraints that can't be expressed with struct tags.
+type InputSchemaOption func(*jsonschema.Schema)
+
+// WithInputSchemaEnum restricts the values of a property of the input schema.
+// Nested properties are separated by dots (e.g. "object.type"). For array
+// properties the restriction applies to the items.
+func WithInputSchemaEnum[T any](property string, values ...T) InputSchemaOption {
+	return func(schema *jsonschema.Schema) {
+		propertySchema := lookupProperty(schema, property)
+		if propertySchema.Type == "array" && propertySchema.Items != nil {
+			propertySchema = propertySchema.Items
+		}
+		propertySchema.Enum = make([]any, len(values))
+		for i, value := range values {
+			// store the JSON representation, as the validation compares the values
+			// decoded from the tool arguments
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				panic(fmt.Sprintf("failed to encode enum value %v: %v", value, err))
+			}
+			if err := json.Unmarshal(encoded, &propertySchema.Enum[i]); err != nil {
+				panic(fmt.Sprintf("failed to decode enum value %v: %v", value, err))
+			}
+		}
+	}
+}
+
+// WithInputSchemaProperty allows to adjust the schema of a property of the
+// input schema, for constraints like ranges or patterns. Nested properties are
+// separated by dots (e.g. "object.type").
+func WithInputSchemaProperty(property string, adjust func(*jsonschema.Schema)) InputSchemaOption {
+	return func(schema *jsonschema.Schema) {
+		adjust(lookupProperty(schema, property))
+	}
+}
+
+func lookupProperty(schema *jsonschema.Schema, property string) *jsonschema.Schema {
+	propertySchema := schema
+	for name := range strings.SplitSeq(property, ".") {
+		if propertySchema.Type == "array" && propertySchema.Items != nil {
+			propertySchema = propertySchema.Items
+		}
+		var ok bool
+		if propertySchema, ok = propertySchema.Properties[name]; !ok {
+			panic(fmt.Sprintf("property %q not found in input schema", property))
+		}
+	}
+	return propertySchema
+}
+
+// NewTypedToolWrapper creates a ToolWrapper where the input schema is derived
+// from the In type, which must be a struct. The properties are named after the
+// JSON field names, the "jsonschema" struct tag is used as the description and
+// fields without "omitempty" or "omitzero" are required.
+//
+// When the tool is called the arguments are coerced (see CoerceArguments),
+// validated against the input schema and decoded into the In type before
+// calling the handler. Invalid arguments are reported to the LLM as a tool
+// error, so it can fix the call.
+//
+// It panics if the input schema can't be generated, as this is a programming
+// error.
+func NewTypedToolWrapper[In any](
+	tool *mcp.Tool,
+	handler TypedToolHandler[In],
+	options ...InputSchemaOption,
+) ToolWrapper {
+	inputSchema, err := inputSchemaFor[In]()
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate input schema for %s: %v", tool.Name, err))
+	}
+	for _, option := range options {
+		option(inputSchema)
+	}
+	resolvedInputSchema, err := inputSchema.Resolve(nil)
+	if err != nil {
+		panic(fmt.Sprintf("failed to resolve input schema for %s: %v", tool.Name, err))
+	}
+	tool.InputSchema = inputSchema
+
+	return ToolWrapper{
+		Tool: tool,
+		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var input In
+			if err := decodeArguments(request.Params.Arguments, resolvedInputSchema, &input); err != nil {
+				return newToolResultTextError(err.Error()), nil
+			}
+			return handler(ctx, request, input)
+		},
+	}
+}
+
+func inputSchemaFor[In any]() (*jsonschema.Schema, error) {
+	typeSchemasMutex.RLock()
+	options := &jsonschema.ForOptions{
+		TypeSchemas: make(map[reflect.Type]*jsonschema.Schema, len(typeSchemas)),
+	}
+	for t, schema := range typeSchemas {
+		options.TypeSchemas[t] = schema
+	}
+	typeSchemasMutex.RUnlock()
+
+	schema, err := jsonschema.For[In](options)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("input type must be a struct, got %q", schema.Type)
+	}
+	relaxSchema(schema)
+	return schema, nil
+}
+
+// relaxSchema adapts the generated schema to the way LLMs call tools. Optional
+// fields are represented as absent properties instead of null values, and
+// unknown properties are ignored instead of failing the tool call.
+func relaxSchema(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+	if len(schema.Types) == 2 && slices.Contains(schema.Types, "null") {
+		schema.Type = schema.Types[slices.IndexFunc(schema.Types, func(t string) bool { return t != "null" })]
+		schema.Types = nil
+	}
+	// structs disallow additional properties with a "false" schema
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Not != nil {
+		schema.AdditionalProperties = nil
+	}
+	for _, propertySchema := range schema.Properties {
+		relaxSchema(propertySchema)
+	}
+	relaxSchema(schema.Items)
+}
+
+func decodeArguments(arguments json.RawMessage, schema *jsonschema.Resolved, target any) error {
+	if len(arguments) == 0 || string(arguments) == "null" {
+		arguments = json.RawMessage("{}")
+	}
+
+	var instance map[string]any
+	if err := json.Unmarshal(arguments, &instance); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+	CoerceArguments(schema.Schema(), instance)
+	if err := schema.Validate(instance); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	coerced, err := json.Marshal(instance)
+	if err != nil {
+		return fmt.Errorf("failed to encode parameters: %w", err)
+	}
+	if err := json.Unmarshal(coerced, target); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package toolsets_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/teamwork/mcp/internal/toolsets"
+)
+
+type typedItem struct {
+	Name string `json:"name" jsonschema:"The name of the item."`
+}
+
+type typedInput struct {
+	ID     int64       `json:"id" jsonschema:"The ID of the item."`
+	Status string      `json:"status,omitempty" jsonschema:"The status of the item."`
+	Notes  *string     `json:"notes,omitempty" jsonschema:"Notes about the item."`
+	Items  []typedItem `json:"items,omitempty" jsonschema:"The sub-items."`
+}
+
+func TestNewTypedToolWrapperInputSchema(t *testing.T) {
+	wrapper := toolsets.NewTypedToolWrapper(
+		&mcp.Tool{Name: "typed-get_item"},
+		func(context.Context, *mcp.CallToolRequest, typedInput) (*mcp.CallToolResult, error) {
+			return &mcp.CallToolResult{}, nil
+		},
+		toolsets.WithInputSchemaEnum("status", "active", "archived"),
+		toolsets.WithInputSchemaProperty("items.name", func(schema *jsonschema.Schema) {
+			schema.MinLength = jsonschema.Ptr(1)
+		}),
+	)
+
+	schema, ok := wrapper.Tool.InputSchema.(*jsonschema.Schema)
+	if !ok {
+		t.Fatalf("unexpected input schema type %T", wrapper.Tool.InputSchema)
+	}
+	if schema.Type != "object" {
+		t.Errorf("unexpected schema type %q", schema.Type)
+	}
+	if !slices.Equal(schema.Required, []string{"id"}) {
+		t.Errorf("unexpected required properties %v", schema.Required)
+	}
+	if schema.AdditionalProperties != nil {
+		t.Errorf("expected additional properties to be allowed")
+	}
+	if got := schema.Properties["id"]; got.Type != "integer" || got.Description != "The ID of the item." {
+		t.Errorf("unexpected id property: type %q description %q", got.Type, got.Description)
+	}
+	if got := schema.Properties["notes"]; got.Type != "string" || len(got.Types) > 0 {
+		t.Errorf("expected notes property to be a non-nullable string, got %q %v", got.Type, got.Types)
+	}
+	if got := schema.Properties["status"].Enum; !slices.Equal(got, []any{"active", "archived"}) {
+		t.Errorf("unexpected status enum %v", got)
+	}
+	if got := schema.Properties["items"].Items.Properties["name"].MinLength; got == nil || *got != 1 {
+		t.Errorf("unexpected items.name min length %v", got)
+	}
+}
+
+func TestNewTypedToolWrapperHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments string
+		want      typedInput
+		wantErr   string
+	}{{
+		name:      "all fields",
+		arguments: `{"id":10,"status":"active","notes":"note","items":[{"name":"a"}]}`,
+		want: typedInput{
+			ID:     10,
+			Status: "active",
+			Notes:  jsonschema.Ptr("note"),
+			Items:  []typedItem{{Name: "a"}},
+		},
+	}, {
+		name:      "unknown properties are ignored",
+		arguments: `{"id":10,"unknown":true}`,
+		want:      typedInput{ID: 10},
+	}, {
+		name:      "missing required property",
+		arguments: `{"status":"active"}`,
+		wantErr:   "invalid parameters",
+	}, {
+		name:      "numeric strings",
+		arguments: `{"id":"10","items":[{"name":"a"}]}`,
+		want:      typedInput{ID: 10, Items: []typedItem{{Name: "a"}}},
+	}, {
+		name:      "wrong type",
+		arguments: `{"id":"ten"}`,
+		wantErr:   "invalid parameters",
+	}, {
+		name:      "value not in enum",
+		arguments: `{"id":10,"status":"deleted"}`,
+		wantErr:   "invalid parameters",
+	}, {
+		name:      "no arguments",
+		arguments: ``,
+		wantErr:   "invalid parameters",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got typedInput
+			var called bool
+			wrapper := toolsets.NewTypedToolWrapper(
+				&mcp.Tool{Name: "typed-get_item"},
+				func(_ context.Context, _ *mcp.CallToolRequest, input typedInput) (*mcp.CallToolResult, error) {
+					got, called = input, true
+					return &mcp.CallToolResult{}, nil
+				},
+				toolsets.WithInputSchemaEnum("status", "active", "archived"),
+			)
+
+			result, err := wrapper.Handler(t.Context(), &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{
+					Name:      "typed-get_item",
+					Arguments: json.RawMessage(tt.arguments),
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if called {
+					t.Fatalf("handler should not be called with invalid arguments")
+				}
+				if !result.IsError || len(result.Content) == 0 {
+					t.Fatalf("expected a tool error result")
+				}
+				text := result.Content[0].(*mcp.TextContent).Text
+				if !strings.Contains(text, tt.wantErr) {
+					t.Errorf("expected error containing %q, got %q", tt.wantErr, text)
+				}
+				return
+			}
+
+			if !called {
+				t.Fatalf("handler was not called")
+			}
+			gotEncoded, _ := json.Marshal(got)
+			wantEncoded, _ := json.Marshal(tt.want)
+			if string(gotEncoded) != string(wantEncoded) {
+				t.Errorf("unexpected input %s, want %s", gotEncoded, wantEncoded)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodCompanyCreate toolsets.Method = "twdesk-create_company"
 	MethodCompanyUpdate toolsets.Method = "twdesk-update_company"
+	MethodCompanyDelete toolsets.Method = "twdesk-delete_company"
 	MethodCompanyGet    toolsets.Method = "twdesk-get_company"
 	MethodCompanyList   toolsets.Method = "twdesk-list_companies"
 )
@@ -31,6 +33,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodCompanyCreate)
 	toolsets.RegisterMethod(MethodCompanyUpdate)
+	toolsets.RegisterMethod(MethodCompanyDelete)
 	toolsets.RegisterMethod(MethodCompanyGet)
 	toolsets.RegisterMethod(MethodCompanyList)
 
@@ -43,10 +46,14 @@ func init() {
 	}
 }
 
+type companyGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the company to retrieve."`
+}
+
 // CompanyGet finds a company in Teamwork Desk.  This will find it by ID
 func CompanyGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCompanyGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Company",
@@ -55,57 +62,30 @@ func CompanyGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific company in Teamwork Desk by its ID. " +
 				"Useful for auditing company records, troubleshooting ticket associations, or " +
 				"integrating Desk company data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the company to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			company, err := client.Companies.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyGetInput) (*mcp.CallToolResult, error) {
+			company, err := client.Companies.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get company: %w", err)
 			}
 
 			return helpers.NewToolResultText("Company retrieved successfully: %s", company.Company.Name), nil
 		},
-	}
+	)
+}
+
+type companyListInput struct {
+	paginationInput
+
+	Name    string   `json:"name,omitempty" jsonschema:"The name of the company to filter by."`
+	Domains []string `json:"domains,omitempty" jsonschema:"The domains of the company to filter by."`
+	Kind    string   `json:"kind,omitempty" jsonschema:"The kind of the company to filter by."`
 }
 
 // CompanyList returns a list of companies that apply to the filters in Teamwork Desk
 func CompanyList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "string",
-			Description: "The name of the company to filter by.",
-		},
-		"domains": {
-			Type:        "array",
-			Description: "The domains of the company to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"kind": {
-			Type:        "string",
-			Description: "The kind of the company to filter by.",
-			Enum:        []any{"company", "group"},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCompanyList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Companies",
@@ -114,39 +94,26 @@ func CompanyList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all companies in Teamwork Desk, with optional filters for name, domains, and kind. " +
 				"Enables users to audit, analyze, or synchronize company configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: companyListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the company list
-			name := arguments.GetString("name", "")
-			domains := arguments.GetStringSlice("domains", []string{})
-			kind := arguments.GetString("kind", "")
-
 			filter := deskclient.NewFilter()
-			if name != "" {
-				filter = filter.Eq("name", name)
+			if input.Name != "" {
+				filter = filter.Eq("name", input.Name)
 			}
 
-			if kind != "" {
-				filter = filter.Eq("kind", kind)
+			if input.Kind != "" {
+				filter = filter.Eq("kind", input.Kind)
 			}
 
-			if len(domains) > 0 {
-				filter = filter.In("domains", helpers.SliceToAny(domains))
+			if len(input.Domains) > 0 {
+				filter = filter.In("domains", helpers.SliceToAny(input.Domains))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			companies, err := client.Companies.List(ctx, params)
 			if err != nil {
@@ -154,13 +121,26 @@ func CompanyList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(companies)
 		},
-	}
+		toolsets.WithInputSchemaEnum("kind", "company", "group"),
+	)
+}
+
+type companyCreateInput struct {
+	Name        string   `json:"name" jsonschema:"The name of the company."`
+	Description string   `json:"description,omitempty" jsonschema:"The description of the company."`
+	Details     string   `json:"details,omitempty" jsonschema:"The details of the company."`
+	Industry    string   `json:"industry,omitempty" jsonschema:"The industry of the company."`
+	Website     string   `json:"website,omitempty" jsonschema:"The website of the company."`
+	Permission  string   `json:"permission,omitempty" jsonschema:"The permission level of the company."`
+	Kind        string   `json:"kind,omitempty" jsonschema:"The kind of the company."`
+	Note        string   `json:"note,omitempty" jsonschema:"The note for the company."`
+	Domains     []string `json:"domains,omitempty" jsonschema:"The domains for the company."`
 }
 
 // CompanyCreate creates a company in Teamwork Desk
 func CompanyCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCompanyCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Company",
@@ -168,81 +148,21 @@ func CompanyCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Create a new company in Teamwork Desk by specifying its name, domains, and other attributes. " +
 				"Useful for onboarding new organizations, customizing Desk for business relationships, or " +
 				"adapting support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the company.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the company.",
-					},
-					"details": {
-						Type:        "string",
-						Description: "The details of the company.",
-					},
-					"industry": {
-						Type:        "string",
-						Description: "The industry of the company.",
-					},
-					"website": {
-						Type:        "string",
-						Description: "The website of the company.",
-					},
-					"permission": {
-						Type:        "string",
-						Description: "The permission level of the company.",
-						Enum:        []any{"own", "all"},
-					},
-					"kind": {
-						Type:        "string",
-						Description: "The kind of the company.",
-						Enum:        []any{"company", "group"},
-					},
-					"note": {
-						Type:        "string",
-						Description: "The note for the company.",
-					},
-					"domains": {
-						Type:        "array",
-						Description: "The domains for the company.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-						},
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			domains := arguments.GetStringSlice("domains", []string{})
-			domainEntities := make([]deskmodels.Domain, len(domains))
-			for i, domain := range domains {
-				domainEntities[i] = deskmodels.Domain{
-					Name: domain,
-				}
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyCreateInput) (*mcp.CallToolResult, error) {
 			company, err := client.Companies.Create(ctx, &deskmodels.CompanyResponse{
 				Company: deskmodels.Company{
-					Name:        arguments.GetString("name", ""),
-					Description: arguments.GetString("description", ""),
-					Details:     arguments.GetString("details", ""),
-					Industry:    arguments.GetString("industry", ""),
-					Website:     arguments.GetString("website", ""),
-					Permission:  arguments.GetString("permission", ""),
-					Kind:        arguments.GetString("kind", ""),
-					Note:        arguments.GetString("note", ""),
+					Name:        input.Name,
+					Description: input.Description,
+					Details:     input.Details,
+					Industry:    input.Industry,
+					Website:     input.Website,
+					Permission:  input.Permission,
+					Kind:        input.Kind,
+					Note:        input.Note,
 				},
 				Included: deskmodels.IncludedData{
-					Domains: domainEntities,
+					Domains: domainEntities(input.Domains),
 				},
 			})
 			if err != nil {
@@ -250,13 +170,28 @@ func CompanyCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Company created successfully with ID %d", company.Company.ID), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("permission", "own", "all"),
+		toolsets.WithInputSchemaEnum("kind", "company", "group"),
+	)
+}
+
+type companyUpdateInput struct {
+	ID          int      `json:"id" jsonschema:"The ID of the company to update."`
+	Name        string   `json:"name,omitempty" jsonschema:"The new name of the company."`
+	Description string   `json:"description,omitempty" jsonschema:"The new description of the company."`
+	Details     string   `json:"details,omitempty" jsonschema:"The new details of the company."`
+	Industry    string   `json:"industry,omitempty" jsonschema:"The new industry of the company."`
+	Website     string   `json:"website,omitempty" jsonschema:"The new website of the company."`
+	Permission  string   `json:"permission,omitempty" jsonschema:"The new permission level of the company."`
+	Kind        string   `json:"kind,omitempty" jsonschema:"The new kind of the company."`
+	Note        string   `json:"note,omitempty" jsonschema:"The new note for the company."`
+	Domains     []string `json:"domains,omitempty" jsonschema:"The new domains for the company."`
 }
 
 // CompanyUpdate updates a company in Teamwork Desk
 func CompanyUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCompanyUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Company",
@@ -264,84 +199,21 @@ func CompanyUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing company in Teamwork Desk by ID, allowing changes to its name, domains, and " +
 				"other attributes. Supports evolving business relationships, rebranding, or correcting company records for " +
 				"improved ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the company to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The new name of the company.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The new description of the company.",
-					},
-					"details": {
-						Type:        "string",
-						Description: "The new details of the company.",
-					},
-					"industry": {
-						Type:        "string",
-						Description: "The new industry of the company.",
-					},
-					"website": {
-						Type:        "string",
-						Description: "The new website of the company.",
-					},
-					"permission": {
-						Type:        "string",
-						Description: "The new permission level of the company.",
-						Enum:        []any{"own", "all"},
-					},
-					"kind": {
-						Type:        "string",
-						Description: "The new kind of the company.",
-						Enum:        []any{"company", "group"},
-					},
-					"note": {
-						Type:        "string",
-						Description: "The new note for the company.",
-					},
-					"domains": {
-						Type:        "array",
-						Description: "The new domains for the company.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			domains := arguments.GetStringSlice("domains", []string{})
-			domainEntities := make([]deskmodels.Domain, len(domains))
-			for i, domain := range domains {
-				domainEntities[i] = deskmodels.Domain{
-					Name: domain,
-				}
-			}
-			_, err = client.Companies.Update(ctx, arguments.GetInt("id", 0), &deskmodels.CompanyResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.Companies.Update(ctx, input.ID, &deskmodels.CompanyResponse{
 				Company: deskmodels.Company{
-					Name:        arguments.GetString("name", ""),
-					Description: arguments.GetString("description", ""),
-					Details:     arguments.GetString("details", ""),
-					Industry:    arguments.GetString("industry", ""),
-					Website:     arguments.GetString("website", ""),
-					Permission:  arguments.GetString("permission", ""),
-					Kind:        arguments.GetString("kind", ""),
-					Note:        arguments.GetString("note", ""),
+					Name:        input.Name,
+					Description: input.Description,
+					Details:     input.Details,
+					Industry:    input.Industry,
+					Website:     input.Website,
+					Permission:  input.Permission,
+					Kind:        input.Kind,
+					Note:        input.Note,
 				},
 				Included: deskmodels.IncludedData{
-					Domains: domainEntities,
+					Domains: domainEntities(input.Domains),
 				},
 			})
 			if err != nil {
@@ -350,5 +222,41 @@ func CompanyUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Company updated successfully"), nil
 		},
+		toolsets.WithInputSchemaEnum("permission", "own", "all"),
+		toolsets.WithInputSchemaEnum("kind", "company", "group"),
+	)
+}
+
+func domainEntities(domains []string) []deskmodels.Domain {
+	entities := make([]deskmodels.Domain, len(domains))
+	for i, domain := range domains {
+		entities[i] = deskmodels.Domain{
+			Name: domain,
+		}
 	}
+	return entities
+}
+
+type companyDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the company to delete."`
+}
+
+// CompanyDelete deletes a company in Teamwork Desk
+func CompanyDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodCompanyDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Company",
+			},
+			Description: "Delete an existing company in Teamwork Desk by ID. " +
+				"Useful for cleaning up duplicate or obsolete company records.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "companies", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete company: %w", err)
+			}
+			return helpers.NewToolResultText("Company deleted successfully"), nil
+		},
+	)
 }
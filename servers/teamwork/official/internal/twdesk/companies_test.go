@@ -73,3 +73,12 @@ func TestCompanyListMinimal(t *testing.T) {
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodCompanyList.String(), map[string]any{})
 }
+
+func TestCompanyDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodCompanyDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodCustomerCreate toolsets.Method = "twdesk-create_customer"
 	MethodCustomerUpdate toolsets.Method = "twdesk-update_customer"
+	MethodCustomerDelete toolsets.Method = "twdesk-delete_customer"
 	MethodCustomerGet    toolsets.Method = "twdesk-get_customer"
 	MethodCustomerList   toolsets.Method = "twdesk-list_customers"
 )
@@ -31,6 +33,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodCustomerCreate)
 	toolsets.RegisterMethod(MethodCustomerUpdate)
+	toolsets.RegisterMethod(MethodCustomerDelete)
 	toolsets.RegisterMethod(MethodCustomerGet)
 	toolsets.RegisterMethod(MethodCustomerList)
 
@@ -43,10 +46,14 @@ func init() {
 	}
 }
 
+type customerGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the customer to retrieve."`
+}
+
 // CustomerGet finds a customer in Teamwork Desk.  This will find it by ID
 func CustomerGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCustomerGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Customer",
@@ -55,24 +62,9 @@ func CustomerGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific customer in Teamwork Desk by their ID. " +
 				"Useful for auditing customer records, troubleshooting ticket associations, or " +
 				"integrating Desk customer data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the customer to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			customer, err := client.Customers.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input customerGetInput) (*mcp.CallToolResult, error) {
+			customer, err := client.Customers.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get customer: %w", err)
 			}
@@ -80,38 +72,21 @@ func CustomerGet(client *deskclient.Client) toolsets.ToolWrapper {
 			firstName := customer.Customer.FirstName
 			return helpers.NewToolResultText("Customer retrieved successfully: %s", firstName), nil
 		},
-	}
+	)
+}
+
+type customerListInput struct {
+	paginationInput
+
+	CompanyIDs   []int    `json:"companyIDs,omitempty" jsonschema:"The IDs of the companies to filter by."`
+	CompanyNames []string `json:"companyNames,omitempty" jsonschema:"The names of the companies to filter by."`
+	Emails       []string `json:"emails,omitempty" jsonschema:"The emails of the customers to filter by."`
 }
 
 // CustomerList returns a list of customers that apply to the filters in Teamwork Desk
 func CustomerList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"companyIDs": {
-			Type:        "array",
-			Description: "The IDs of the companies to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"companyNames": {
-			Type:        "array",
-			Description: "The names of the companies to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"emails": {
-			Type:        "array",
-			Description: "The emails of the customers to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCustomerList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Customers",
@@ -120,39 +95,26 @@ func CustomerList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all customers in Teamwork Desk, with optional filters for company, email, and other " +
 				"attributes. Enables users to audit, analyze, or synchronize customer configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: customerListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input customerListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the customer list
-			companyIDs := arguments.GetIntSlice("companyIDs", []int{})
-			companyNames := arguments.GetStringSlice("companyNames", []string{})
-			emails := arguments.GetStringSlice("emails", []string{})
-
 			filter := deskclient.NewFilter()
-			if len(companyIDs) > 0 {
-				filter = filter.In("companies.id", helpers.SliceToAny(companyIDs))
+			if len(input.CompanyIDs) > 0 {
+				filter = filter.In("companies.id", helpers.SliceToAny(input.CompanyIDs))
 			}
 
-			if len(companyNames) > 0 {
-				filter = filter.In("companies.name", helpers.SliceToAny(companyNames))
+			if len(input.CompanyNames) > 0 {
+				filter = filter.In("companies.name", helpers.SliceToAny(input.CompanyNames))
 			}
 
-			if len(emails) > 0 {
-				filter = filter.In("contacts.value", helpers.SliceToAny(emails))
+			if len(input.Emails) > 0 {
+				filter = filter.In("contacts.value", helpers.SliceToAny(input.Emails))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			customers, err := client.Customers.List(ctx, params)
 			if err != nil {
@@ -161,13 +123,31 @@ func CustomerList(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultJSON(customers)
 		},
-	}
+	)
+}
+
+type customerCreateInput struct {
+	FirstName     string   `json:"firstName,omitempty" jsonschema:"The first name of the customer."`
+	LastName      string   `json:"lastName,omitempty" jsonschema:"The last name of the customer."`
+	Email         string   `json:"email,omitempty" jsonschema:"The email of the customer."`
+	Organization  string   `json:"organization,omitempty" jsonschema:"The organization of the customer."`
+	ExtraData     string   `json:"extraData,omitempty" jsonschema:"The extra data of the customer."`
+	Notes         string   `json:"notes,omitempty" jsonschema:"The notes of the customer."`
+	LinkedinURL   string   `json:"linkedinURL,omitempty" jsonschema:"The LinkedIn URL of the customer."`
+	FacebookURL   string   `json:"facebookURL,omitempty" jsonschema:"The Facebook URL of the customer."`
+	TwitterHandle string   `json:"twitterHandle,omitempty" jsonschema:"The Twitter handle of the customer."`
+	JobTitle      string   `json:"jobTitle,omitempty" jsonschema:"The job title of the customer."`
+	Phone         string   `json:"phone,omitempty" jsonschema:"The phone number of the customer."`
+	Mobile        string   `json:"mobile,omitempty" jsonschema:"The mobile number of the customer."`
+	Address       string   `json:"address,omitempty" jsonschema:"The address of the customer."`
+	Trusted       bool     `json:"trusted,omitempty" jsonschema:"If true, the customer is trusted."`
+	Domains       []string `json:"domains,omitempty" jsonschema:"The domains of the customer."`
 }
 
 // CustomerCreate creates a customer in Teamwork Desk
 func CustomerCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCustomerCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Customer",
@@ -175,97 +155,27 @@ func CustomerCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Create a new customer in Teamwork Desk by specifying their name, contact details, and other " +
 				"attributes. Useful for onboarding new clients, customizing Desk for business relationships, or " +
 				"adapting support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"firstName": {
-						Type:        "string",
-						Description: "The first name of the customer.",
-					},
-					"lastName": {
-						Type:        "string",
-						Description: "The last name of the customer.",
-					},
-					"email": {
-						Type:        "string",
-						Description: "The email of the customer.",
-					},
-					"organization": {
-						Type:        "string",
-						Description: "The organization of the customer.",
-					},
-					"extraData": {
-						Type:        "string",
-						Description: "The extra data of the customer.",
-					},
-					"notes": {
-						Type:        "string",
-						Description: "The notes of the customer.",
-					},
-					"linkedinURL": {
-						Type:        "string",
-						Description: "The LinkedIn URL of the customer.",
-					},
-					"facebookURL": {
-						Type:        "string",
-						Description: "The Facebook URL of the customer.",
-					},
-					"twitterHandle": {
-						Type:        "string",
-						Description: "The Twitter handle of the customer.",
-					},
-					"jobTitle": {
-						Type:        "string",
-						Description: "The job title of the customer.",
-					},
-					"phone": {
-						Type:        "string",
-						Description: "The phone number of the customer.",
-					},
-					"mobile": {
-						Type:        "string",
-						Description: "The mobile number of the customer.",
-					},
-					"address": {
-						Type:        "string",
-						Description: "The address of the customer.",
-					},
-				},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			domains := arguments.GetStringSlice("domains", []string{})
-			domainEntities := make([]deskmodels.Domain, len(domains))
-			for i, domain := range domains {
-				domainEntities[i] = deskmodels.Domain{
-					Name: domain,
-				}
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input customerCreateInput) (*mcp.CallToolResult, error) {
 			customer, err := client.Customers.Create(ctx, &deskmodels.CustomerResponse{
 				Customer: deskmodels.Customer{
-					FirstName:     arguments.GetString("firstName", ""),
-					LastName:      arguments.GetString("lastName", ""),
-					Email:         arguments.GetString("email", ""),
-					Organization:  arguments.GetString("organization", ""),
-					ExtraData:     arguments.GetString("extraData", ""),
-					Notes:         arguments.GetString("notes", ""),
-					LinkedinURL:   arguments.GetString("linkedinURL", ""),
-					FacebookURL:   arguments.GetString("facebookURL", ""),
-					TwitterHandle: arguments.GetString("twitterHandle", ""),
-					JobTitle:      arguments.GetString("jobTitle", ""),
-					Phone:         arguments.GetString("phone", ""),
-					Mobile:        arguments.GetString("mobile", ""),
-					Address:       arguments.GetString("address", ""),
-					Trusted:       arguments.GetBool("trusted", false),
+					FirstName:     input.FirstName,
+					LastName:      input.LastName,
+					Email:         input.Email,
+					Organization:  input.Organization,
+					ExtraData:     input.ExtraData,
+					Notes:         input.Notes,
+					LinkedinURL:   input.LinkedinURL,
+					FacebookURL:   input.FacebookURL,
+					TwitterHandle: input.TwitterHandle,
+					JobTitle:      input.JobTitle,
+					Phone:         input.Phone,
+					Mobile:        input.Mobile,
+					Address:       input.Address,
+					Trusted:       input.Trusted,
 				},
 				Included: deskmodels.IncludedData{
-					Domains: domainEntities,
+					Domains: domainEntities(input.Domains),
 				},
 			})
 			if err != nil {
@@ -273,13 +183,32 @@ func CustomerCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Customer created successfully with ID %d", customer.Customer.ID), nil
 		},
-	}
+	)
+}
+
+type customerUpdateInput struct {
+	ID            int      `json:"id" jsonschema:"The ID of the customer to update."`
+	FirstName     string   `json:"firstName,omitempty" jsonschema:"The new first name of the customer."`
+	LastName      string   `json:"lastName,omitempty" jsonschema:"The new last name of the customer."`
+	Email         string   `json:"email,omitempty" jsonschema:"The new email of the customer."`
+	Organization  string   `json:"organization,omitempty" jsonschema:"The new organization of the customer."`
+	ExtraData     string   `json:"extraData,omitempty" jsonschema:"The new extra data of the customer."`
+	Notes         string   `json:"notes,omitempty" jsonschema:"The new notes of the customer."`
+	LinkedinURL   string   `json:"linkedinURL,omitempty" jsonschema:"The new LinkedIn URL of the customer."`
+	FacebookURL   string   `json:"facebookURL,omitempty" jsonschema:"The new Facebook URL of the customer."`
+	TwitterHandle string   `json:"twitterHandle,omitempty" jsonschema:"The new Twitter handle of the customer."`
+	JobTitle      string   `json:"jobTitle,omitempty" jsonschema:"The new job title of the customer."`
+	Phone         string   `json:"phone,omitempty" jsonschema:"The new phone number of the customer."`
+	Mobile        string   `json:"mobile,omitempty" jsonschema:"The new mobile number of the customer."`
+	Address       string   `json:"address,omitempty" jsonschema:"The new address of the customer."`
+	Trusted       bool     `json:"trusted,omitempty" jsonschema:"If true, the customer is trusted."`
+	Domains       []string `json:"domains,omitempty" jsonschema:"The new domains of the customer."`
 }
 
 // CustomerUpdate updates a customer in Teamwork Desk
 func CustomerUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodCustomerUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Customer",
@@ -287,101 +216,27 @@ func CustomerUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing customer in Teamwork Desk by ID, allowing changes to their name, " +
 				"contact details, and other attributes. Supports evolving business relationships, " +
 				"correcting customer records, or improving ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the customer to update.",
-					},
-					"firstName": {
-						Type:        "string",
-						Description: "The new first name of the customer.",
-					},
-					"lastName": {
-						Type:        "string",
-						Description: "The new last name of the customer.",
-					},
-					"email": {
-						Type:        "string",
-						Description: "The new email of the customer.",
-					},
-					"organization": {
-						Type:        "string",
-						Description: "The new organization of the customer.",
-					},
-					"extraData": {
-						Type:        "string",
-						Description: "The new extra data of the customer.",
-					},
-					"notes": {
-						Type:        "string",
-						Description: "The new notes of the customer.",
-					},
-					"linkedinURL": {
-						Type:        "string",
-						Description: "The new LinkedIn URL of the customer.",
-					},
-					"facebookURL": {
-						Type:        "string",
-						Description: "The new Facebook URL of the customer.",
-					},
-					"twitterHandle": {
-						Type:        "string",
-						Description: "The new Twitter handle of the customer.",
-					},
-					"jobTitle": {
-						Type:        "string",
-						Description: "The new job title of the customer.",
-					},
-					"phone": {
-						Type:        "string",
-						Description: "The new phone number of the customer.",
-					},
-					"mobile": {
-						Type:        "string",
-						Description: "The new mobile number of the customer.",
-					},
-					"address": {
-						Type:        "string",
-						Description: "The new address of the customer.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			domains := arguments.GetStringSlice("domains", []string{})
-			domainEntities := make([]deskmodels.Domain, len(domains))
-			for i, domain := range domains {
-				domainEntities[i] = deskmodels.Domain{
-					Name: domain,
-				}
-			}
-			_, err = client.Customers.Update(ctx, arguments.GetInt("id", 0), &deskmodels.CustomerResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input customerUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.Customers.Update(ctx, input.ID, &deskmodels.CustomerResponse{
 				Customer: deskmodels.Customer{
-					FirstName:     arguments.GetString("firstName", ""),
-					LastName:      arguments.GetString("lastName", ""),
-					Email:         arguments.GetString("email", ""),
-					Organization:  arguments.GetString("organization", ""),
-					ExtraData:     arguments.GetString("extraData", ""),
-					Notes:         arguments.GetString("notes", ""),
-					LinkedinURL:   arguments.GetString("linkedinURL", ""),
-					FacebookURL:   arguments.GetString("facebookURL", ""),
-					TwitterHandle: arguments.GetString("twitterHandle", ""),
-					JobTitle:      arguments.GetString("jobTitle", ""),
-					Phone:         arguments.GetString("phone", ""),
-					Mobile:        arguments.GetString("mobile", ""),
-					Address:       arguments.GetString("address", ""),
-					Trusted:       arguments.GetBool("trusted", false),
+					FirstName:     input.FirstName,
+					LastName:      input.LastName,
+					Email:         input.Email,
+					Organization:  input.Organization,
+					ExtraData:     input.ExtraData,
+					Notes:         input.Notes,
+					LinkedinURL:   input.LinkedinURL,
+					FacebookURL:   input.FacebookURL,
+					TwitterHandle: input.TwitterHandle,
+					JobTitle:      input.JobTitle,
+					Phone:         input.Phone,
+					Mobile:        input.Mobile,
+					Address:       input.Address,
+					Trusted:       input.Trusted,
 				},
 				Included: deskmodels.IncludedData{
-					Domains: domainEntities,
+					Domains: domainEntities(input.Domains),
 				},
 			})
 			if err != nil {
@@ -390,5 +245,29 @@ func CustomerUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Customer updated successfully"), nil
 		},
-	}
+	)
+}
+
+type customerDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the customer to delete."`
+}
+
+// CustomerDelete deletes a customer in Teamwork Desk
+func CustomerDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodCustomerDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Customer",
+			},
+			Description: "Delete an existing customer in Teamwork Desk by ID. " +
+				"Useful for removing duplicate or obsolete customer records, e.g. to comply with data removal requests.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input customerDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "customers", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete customer: %w", err)
+			}
+			return helpers.NewToolResultText("Customer deleted successfully"), nil
+		},
+	)
 }
@@ -81,3 +81,12 @@ func TestCustomerListMinimal(t *testing.T) {
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodCustomerList.String(), map[string]any{})
 }
+
+func TestCustomerDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodCustomerDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
@@ -1,11 +1,11 @@
 package twdesk
 
 import (
+	"cmp"
 	"context"
 	"encoding/base64"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
@@ -25,72 +25,42 @@ func init() {
 	toolsets.RegisterMethod(MethodFileCreate)
 }
 
+type fileCreateInput struct {
+	Name        string                 `json:"name" jsonschema:"The name of the file."`
+	MIMEType    string                 `json:"mimeType" jsonschema:"The MIME type of the file."`
+	Disposition deskmodels.Disposition `json:"disposition,omitempty" jsonschema:"The disposition of the file."`
+	Data        string                 `json:"data" jsonschema:"The content of the file as a base64-encoded string."`
+}
+
 // FileCreate creates a file in Teamwork Desk
 func FileCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodFileCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create File",
 			},
 			Description: "Upload a new file to Teamwork Desk, enabling attachment to tickets, articles, or " +
 				"other resources.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the file.",
-					},
-					"mimeType": {
-						Type:        "string",
-						Description: "The MIME type of the file.",
-					},
-					"disposition": {
-						Type:        "string",
-						Description: "The disposition of the file.",
-						Enum: []any{
-							string(deskmodels.DispositionAttachment),
-							string(deskmodels.DispositionAttachmentInline),
-						},
-					},
-					"data": {
-						Type:        "string",
-						Description: "The content of the file as a base64-encoded string.",
-					},
-				},
-				Required: []string{"name", "mimeType", "data"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input fileCreateInput) (*mcp.CallToolResult, error) {
 			file, err := client.Files.Create(ctx, &deskmodels.FileResponse{
 				File: deskmodels.File{
-					Filename: arguments.GetString("name", ""),
-					MIMEType: arguments.GetString("mimeType", "application/octet-stream"),
-					Disposition: deskmodels.Disposition(
-						arguments.GetString(
-							"disposition",
-							string(deskmodels.DispositionAttachment),
-						),
-					),
-					Type: deskmodels.FileTypeAttachment,
+					Filename:    input.Name,
+					MIMEType:    cmp.Or(input.MIMEType, "application/octet-stream"),
+					Disposition: cmp.Or(input.Disposition, deskmodels.DispositionAttachment),
+					Type:        deskmodels.FileTypeAttachment,
 				},
 			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to create file: %w", err)
 			}
 
-			dataStr := arguments.GetString("data", "")
-			if dataStr == "" {
+			if input.Data == "" {
 				return nil, fmt.Errorf("file data (base64 encoded) is required")
 			}
 
-			fileData, err := base64.StdEncoding.DecodeString(dataStr)
+			fileData, err := base64.StdEncoding.DecodeString(input.Data)
 			if err != nil {
 				return nil, fmt.Errorf("failed to decode base64 data: %w", err)
 			}
@@ -101,5 +71,9 @@ func FileCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("File created successfully with ID %d", file.File.ID), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("disposition",
+			deskmodels.DispositionAttachment,
+			deskmodels.DispositionAttachmentInline,
+		),
+	)
 }
@@ -37,10 +37,14 @@ func init() {
 	}
 }
 
+type inboxGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the inbox to retrieve."`
+}
+
 // InboxGet finds a inbox in Teamwork Desk.  This will find it by ID
 func InboxGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodInboxGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Inbox",
@@ -49,87 +53,49 @@ func InboxGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: `
 				Retrieve detailed information about a specific inbox in Teamwork Desk by its ID
 			`,
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the inbox to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			inbox, err := client.Inboxes.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input inboxGetInput) (*mcp.CallToolResult, error) {
+			inbox, err := client.Inboxes.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get inbox: %w", err)
 			}
 			return helpers.NewToolResultText("Inbox retrieved successfully: %s", inbox.Inbox.Name), nil
 		},
-	}
+	)
+}
+
+type inboxListInput struct {
+	paginationInput
+
+	Name  []string `json:"name,omitempty" jsonschema:"The name of the inbox to filter by."`
+	Email []string `json:"email,omitempty" jsonschema:"The email of the inbox to filter by."`
 }
 
 // InboxList returns a list of inboxes that apply to the filters in Teamwork Desk
 func InboxList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "array",
-			Description: "The name of the inbox to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"email": {
-			Type:        "array",
-			Description: "The email of the inbox to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodInboxList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Inboxes",
 				ReadOnlyHint: true,
 			},
-			Description: "List all inboxes in Teamwork Desk, with optional filters for name and email.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
+			Description:  "List all inboxes in Teamwork Desk, with optional filters for name and email.",
 			OutputSchema: inboxListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input inboxListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the inbox list
-			name := arguments.GetStringSlice("name", []string{})
-			email := arguments.GetStringSlice("email", []string{})
-
 			filter := deskclient.NewFilter()
-			if len(name) > 0 {
-				filter = filter.In("name", helpers.SliceToAny(name))
+			if len(input.Name) > 0 {
+				filter = filter.In("name", helpers.SliceToAny(input.Name))
 			}
-			if len(email) > 0 {
-				filter = filter.In("email", helpers.SliceToAny(email))
+			if len(input.Email) > 0 {
+				filter = filter.In("email", helpers.SliceToAny(input.Email))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			inboxes, err := client.Inboxes.List(ctx, params)
 			if err != nil {
@@ -137,5 +103,5 @@ func InboxList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(inboxes)
 		},
-	}
+	)
 }
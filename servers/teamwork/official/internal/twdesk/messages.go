@@ -3,7 +3,6 @@ package twdesk
 import (
 	"context"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	"github.com/teamwork/mcp/internal/helpers"
@@ -22,11 +21,16 @@ func init() {
 	toolsets.RegisterMethod(MethodMessageCreate)
 }
 
+type messageCreateInput struct {
+	TicketID int    `json:"ticketID" jsonschema:"The ID of the ticket that the message will be sent to."`
+	Body     string `json:"body" jsonschema:"The body of the message."`
+}
+
 // MessageCreate replies to a ticket in Teamwork Desk.  TODO: Still need to
 // define the client for this.
 func MessageCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodMessageCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Message",
@@ -34,31 +38,11 @@ func MessageCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Send a reply message to a ticket in Teamwork Desk by specifying the ticket ID and message body. " +
 				"Useful for automating ticket responses, integrating external communication systems, or " +
 				"customizing support workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"ticketID": {
-						Type:        "integer",
-						Description: "The ID of the ticket that the message will be sent to.",
-					},
-					"body": {
-						Type:        "string",
-						Description: "The body of the message.",
-					},
-				},
-				Required: []string{"ticketID", "body"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, _ messageCreateInput) (*mcp.CallToolResult, error) {
 			_ = client // TODO: use the client to create the message
 			_ = ctx
-			_ = arguments
 			return helpers.NewToolResultTextError("not implemented"), nil
 		},
-	}
+	)
 }
@@ -3,37 +3,34 @@ package twdesk
 import (
 	"fmt"
 	"net/url"
-
-	"github.com/google/jsonschema-go/jsonschema"
-	"github.com/teamwork/mcp/internal/helpers"
 )
 
-func paginationOptions(properties map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
-	if properties == nil {
-		properties = make(map[string]*jsonschema.Schema)
-	}
-	properties["page"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "The page number to retrieve.",
+// paginationInput contains the pagination and ordering arguments shared by the
+// tools that list entities.
+type paginationInput struct {
+	Page           int    `json:"page,omitempty" jsonschema:"The page number to retrieve."`
+	PageSize       int    `json:"pageSize,omitempty" jsonschema:"The number of results to retrieve per page."`
+	OrderBy        string `json:"orderBy,omitempty" jsonschema:"The field to order the results by."`
+	OrderDirection string `json:"orderDirection,omitempty" jsonschema:"The direction to order the results by (asc, desc)."`
+}
+
+func setPagination(v *url.Values, pagination paginationInput) {
+	page, pageSize := pagination.Page, pagination.PageSize
+	if page == 0 {
+		page = 1
 	}
-	properties["pageSize"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "The number of results to retrieve per page.",
+	if pageSize == 0 {
+		pageSize = 10
 	}
-	properties["orderBy"] = &jsonschema.Schema{
-		Type:        "string",
-		Description: "The field to order the results by.",
+	orderBy, orderDirection := pagination.OrderBy, pagination.OrderDirection
+	if orderBy == "" {
+		orderBy = "createdAt"
 	}
-	properties["orderDirection"] = &jsonschema.Schema{
-		Type:        "string",
-		Description: "The direction to order the results by (asc, desc).",
+	if orderDirection == "" {
+		orderDirection = "desc"
 	}
-	return properties
-}
-
-func setPagination(v *url.Values, arguments helpers.ToolArguments) {
-	v.Set("page", fmt.Sprintf("%d", arguments.GetInt("page", 1)))
-	v.Set("pageSize", fmt.Sprintf("%d", arguments.GetInt("pageSize", 10)))
-	v.Set("orderBy", arguments.GetString("orderBy", "createdAt"))
-	v.Set("orderMode", arguments.GetString("orderDirection", "desc"))
+	v.Set("page", fmt.Sprintf("%d", page))
+	v.Set("pageSize", fmt.Sprintf("%d", pageSize))
+	v.Set("orderBy", orderBy)
+	v.Set("orderMode", orderDirection)
 }
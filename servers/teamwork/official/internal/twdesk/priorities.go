@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodPriorityCreate toolsets.Method = "twdesk-create_priority"
 	MethodPriorityUpdate toolsets.Method = "twdesk-update_priority"
+	MethodPriorityDelete toolsets.Method = "twdesk-delete_priority"
 	MethodPriorityGet    toolsets.Method = "twdesk-get_priority"
 	MethodPriorityList   toolsets.Method = "twdesk-list_priorities"
 )
@@ -32,6 +34,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodPriorityCreate)
 	toolsets.RegisterMethod(MethodPriorityUpdate)
+	toolsets.RegisterMethod(MethodPriorityDelete)
 	toolsets.RegisterMethod(MethodPriorityGet)
 	toolsets.RegisterMethod(MethodPriorityList)
 
@@ -47,10 +50,14 @@ func init() {
 	}
 }
 
+type priorityGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the priority to retrieve."`
+}
+
 // PriorityGet finds a priority in Teamwork Desk.  This will find it by ID
 func PriorityGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodPriorityGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Priority",
@@ -59,55 +66,29 @@ func PriorityGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific priority in Teamwork Desk by its ID. " +
 				"Useful for inspecting priority attributes, troubleshooting ticket routing, or " +
 				"integrating Desk priority data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the priority to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: priorityGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			priority, err := client.TicketPriorities.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input priorityGetInput) (*mcp.CallToolResult, error) {
+			priority, err := client.TicketPriorities.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get priority: %w", err)
 			}
 			return helpers.NewToolResultJSON(priority)
 		},
-	}
+	)
+}
+
+type priorityListInput struct {
+	paginationInput
+
+	Name  []string `json:"name,omitempty" jsonschema:"The name of the priority to filter by."`
+	Color []string `json:"color,omitempty" jsonschema:"The color of the priority to filter by."`
 }
 
 // PriorityList returns a list of priorities that apply to the filters in Teamwork Desk
 func PriorityList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "array",
-			Description: "The name of the priority to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"color": {
-			Type:        "array",
-			Description: "The color of the priority to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodPriorityList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Priorities",
@@ -116,33 +97,21 @@ func PriorityList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all available priorities in Teamwork Desk, with optional filters for name and color. " +
 				"Enables users to audit, analyze, or synchronize priority configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: priorityListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input priorityListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the priority list
-			name := arguments.GetStringSlice("name", []string{})
-			color := arguments.GetStringSlice("color", []string{})
-
 			filter := deskclient.NewFilter()
-			if len(name) > 0 {
-				filter = filter.In("name", helpers.SliceToAny(name))
+			if len(input.Name) > 0 {
+				filter = filter.In("name", helpers.SliceToAny(input.Name))
 			}
-			if len(color) > 0 {
-				filter = filter.In("color", helpers.SliceToAny(color))
+			if len(input.Color) > 0 {
+				filter = filter.In("color", helpers.SliceToAny(input.Color))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			priorities, err := client.TicketPriorities.List(ctx, params)
 			if err != nil {
@@ -150,44 +119,30 @@ func PriorityList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(priorities)
 		},
-	}
+	)
+}
+
+type priorityCreateInput struct {
+	Name  string `json:"name" jsonschema:"The name of the priority."`
+	Color string `json:"color,omitempty" jsonschema:"The color of the priority."`
 }
 
 // PriorityCreate creates a priority in Teamwork Desk
 func PriorityCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodPriorityCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Priority",
 			},
 			Description: "Create a new priority in Teamwork Desk by specifying its name and color. Useful for customizing " +
 				"ticket workflows, introducing new escalation levels, or adapting Desk to evolving support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the priority.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the priority.",
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input priorityCreateInput) (*mcp.CallToolResult, error) {
 			priority, err := client.TicketPriorities.Create(ctx, &deskmodels.TicketPriorityResponse{
 				TicketPriority: deskmodels.TicketPriority{
-					Name:  arguments.GetString("name", ""),
-					Color: arguments.GetString("color", ""),
+					Name:  input.Name,
+					Color: input.Color,
 				},
 			})
 			if err != nil {
@@ -195,13 +150,19 @@ func PriorityCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Priority created successfully with ID %d", priority.TicketPriority.ID), nil
 		},
-	}
+	)
+}
+
+type priorityUpdateInput struct {
+	ID    int    `json:"id" jsonschema:"The ID of the priority to update."`
+	Name  string `json:"name,omitempty" jsonschema:"The new name of the priority."`
+	Color string `json:"color,omitempty" jsonschema:"The color of the priority."`
 }
 
 // PriorityUpdate updates a priority in Teamwork Desk
 func PriorityUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodPriorityUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Priority",
@@ -209,35 +170,12 @@ func PriorityUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing priority in Teamwork Desk by ID, allowing changes to its name and color. " +
 				"Supports evolving support policies, rebranding, or correcting priority attributes for improved " +
 				"ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the priority to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The new name of the priority.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the priority.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			_, err = client.TicketPriorities.Update(ctx, arguments.GetInt("id", 0), &deskmodels.TicketPriorityResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input priorityUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.TicketPriorities.Update(ctx, input.ID, &deskmodels.TicketPriorityResponse{
 				TicketPriority: deskmodels.TicketPriority{
-					Name:  arguments.GetString("name", ""),
-					Color: arguments.GetString("color", ""),
+					Name:  input.Name,
+					Color: input.Color,
 				},
 			})
 			if err != nil {
@@ -246,5 +184,29 @@ func PriorityUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Priority updated successfully"), nil
 		},
-	}
+	)
+}
+
+type priorityDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the priority to delete."`
+}
+
+// PriorityDelete deletes a priority in Teamwork Desk
+func PriorityDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodPriorityDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Priority",
+			},
+			Description: "Delete an existing priority in Teamwork Desk by ID. " +
+				"Useful for retiring ticket priorities that are no longer used.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input priorityDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "ticketpriorities", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete priority: %w", err)
+			}
+			return helpers.NewToolResultText("Priority deleted successfully"), nil
+		},
+	)
 }
@@ -57,3 +57,12 @@ func TestPriorityListMinimal(t *testing.T) {
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodPriorityList.String(), map[string]any{})
 }
+
+func TestPriorityDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodPriorityDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodStatusCreate toolsets.Method = "twdesk-create_status"
 	MethodStatusUpdate toolsets.Method = "twdesk-update_status"
+	MethodStatusDelete toolsets.Method = "twdesk-delete_status"
 	MethodStatusGet    toolsets.Method = "twdesk-get_status"
 	MethodStatusList   toolsets.Method = "twdesk-list_statuses"
 )
@@ -31,6 +33,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodStatusCreate)
 	toolsets.RegisterMethod(MethodStatusUpdate)
+	toolsets.RegisterMethod(MethodStatusDelete)
 	toolsets.RegisterMethod(MethodStatusGet)
 	toolsets.RegisterMethod(MethodStatusList)
 
@@ -41,10 +44,14 @@ func init() {
 	}
 }
 
+type statusGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the status to retrieve."`
+}
+
 // StatusGet finds a status in Teamwork Desk.  This will find it by ID
 func StatusGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodStatusGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Status",
@@ -53,62 +60,30 @@ func StatusGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific status in Teamwork Desk by its ID. " +
 				"Useful for auditing status usage, troubleshooting ticket workflows, or " +
 				"integrating Desk status data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the status to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			status, err := client.TicketStatuses.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input statusGetInput) (*mcp.CallToolResult, error) {
+			status, err := client.TicketStatuses.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get status: %w", err)
 			}
 
 			return helpers.NewToolResultText("Status retrieved successfully: %s", status.TicketStatus.Name), nil
 		},
-	}
+	)
+}
+
+type statusListInput struct {
+	paginationInput
+
+	Name  []string `json:"name,omitempty" jsonschema:"The name of the status to filter by."`
+	Color []string `json:"color,omitempty" jsonschema:"The color of the status to filter by."`
+	Code  []string `json:"code,omitempty" jsonschema:"The code of the status to filter by."`
 }
 
 // StatusList returns a list of statuses that apply to the filters in Teamwork Desk
 func StatusList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "array",
-			Description: "The name of the status to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"color": {
-			Type:        "array",
-			Description: "The color of the status to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"code": {
-			Type:        "array",
-			Description: "The code of the status to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodStatusList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Statuses",
@@ -117,37 +92,24 @@ func StatusList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all statuses in Teamwork Desk, with optional filters for name, color, and code. " +
 				"Enables users to audit, analyze, or synchronize status configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: statusListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input statusListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the status list
-			name := arguments.GetStringSlice("name", []string{})
-			color := arguments.GetStringSlice("color", []string{})
-			code := arguments.GetStringSlice("code", []string{})
-
 			filter := deskclient.NewFilter()
-			if len(name) > 0 {
-				filter = filter.In("name", helpers.SliceToAny(name))
+			if len(input.Name) > 0 {
+				filter = filter.In("name", helpers.SliceToAny(input.Name))
 			}
-			if len(color) > 0 {
-				filter = filter.In("color", helpers.SliceToAny(color))
+			if len(input.Color) > 0 {
+				filter = filter.In("color", helpers.SliceToAny(input.Color))
 			}
-			if len(code) > 0 {
-				filter = filter.In("code", helpers.SliceToAny(code))
+			if len(input.Code) > 0 {
+				filter = filter.In("code", helpers.SliceToAny(input.Code))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			statuses, err := client.TicketStatuses.List(ctx, params)
 			if err != nil {
@@ -155,13 +117,19 @@ func StatusList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(statuses)
 		},
-	}
+	)
+}
+
+type statusCreateInput struct {
+	Name         string `json:"name" jsonschema:"The name of the status."`
+	Color        string `json:"color,omitempty" jsonschema:"The color of the status."`
+	DisplayOrder int    `json:"displayOrder,omitempty" jsonschema:"The display order of the status."`
 }
 
 // StatusCreate creates a status in Teamwork Desk
 func StatusCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodStatusCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Status",
@@ -169,36 +137,13 @@ func StatusCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Create a new status in Teamwork Desk by specifying its name, color, and display order. " +
 				"Useful for customizing ticket workflows, introducing new resolution states, or " +
 				"adapting Desk to evolving support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the status.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the status.",
-					},
-					"displayOrder": {
-						Type:        "integer",
-						Description: "The display order of the status.",
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input statusCreateInput) (*mcp.CallToolResult, error) {
 			status, err := client.TicketStatuses.Create(ctx, &deskmodels.TicketStatusResponse{
 				TicketStatus: deskmodels.TicketStatus{
-					Name:         arguments.GetString("name", ""),
-					Color:        arguments.GetString("color", ""),
-					DisplayOrder: arguments.GetInt("displayOrder", 0),
+					Name:         input.Name,
+					Color:        input.Color,
+					DisplayOrder: input.DisplayOrder,
 				},
 			})
 			if err != nil {
@@ -206,13 +151,20 @@ func StatusCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Status created successfully with ID %d", status.TicketStatus.ID), nil
 		},
-	}
+	)
+}
+
+type statusUpdateInput struct {
+	ID           int    `json:"id" jsonschema:"The ID of the status to update."`
+	Name         string `json:"name,omitempty" jsonschema:"The new name of the status."`
+	Color        string `json:"color,omitempty" jsonschema:"The color of the status."`
+	DisplayOrder int    `json:"displayOrder,omitempty" jsonschema:"The display order of the status."`
 }
 
 // StatusUpdate updates a status in Teamwork Desk
 func StatusUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodStatusUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Status",
@@ -220,40 +172,13 @@ func StatusUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing status in Teamwork Desk by ID, allowing changes to its name, color, and " +
 				"display order. Supports evolving support policies, rebranding, or correcting status attributes for improved " +
 				"ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the status to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The new name of the status.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the status.",
-					},
-					"displayOrder": {
-						Type:        "integer",
-						Description: "The display order of the status.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			_, err = client.TicketStatuses.Update(ctx, arguments.GetInt("id", 0), &deskmodels.TicketStatusResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input statusUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.TicketStatuses.Update(ctx, input.ID, &deskmodels.TicketStatusResponse{
 				TicketStatus: deskmodels.TicketStatus{
-					Name:         arguments.GetString("name", ""),
-					Color:        arguments.GetString("color", ""),
-					DisplayOrder: arguments.GetInt("displayOrder", 0),
+					Name:         input.Name,
+					Color:        input.Color,
+					DisplayOrder: input.DisplayOrder,
 				},
 			})
 			if err != nil {
@@ -262,5 +187,29 @@ func StatusUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Status updated successfully"), nil
 		},
-	}
+	)
+}
+
+type statusDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the status to delete."`
+}
+
+// StatusDelete deletes a status in Teamwork Desk
+func StatusDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodStatusDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Status",
+			},
+			Description: "Delete an existing status in Teamwork Desk by ID. " +
+				"Useful for retiring ticket statuses that are no longer part of the support workflow.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input statusDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "ticketstatuses", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete status: %w", err)
+			}
+			return helpers.NewToolResultText("Status deleted successfully"), nil
+		},
+	)
 }
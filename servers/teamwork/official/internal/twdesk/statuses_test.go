@@ -60,3 +60,12 @@ func TestStatusListMinimal(t *testing.T) {
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodStatusList.String(), map[string]any{})
 }
+
+func TestStatusDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodStatusDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
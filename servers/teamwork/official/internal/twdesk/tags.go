@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodTagCreate toolsets.Method = "twdesk-create_tag"
 	MethodTagUpdate toolsets.Method = "twdesk-update_tag"
+	MethodTagDelete toolsets.Method = "twdesk-delete_tag"
 	MethodTagGet    toolsets.Method = "twdesk-get_tag"
 	MethodTagList   toolsets.Method = "twdesk-list_tags"
 )
@@ -32,6 +34,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodTagCreate)
 	toolsets.RegisterMethod(MethodTagUpdate)
+	toolsets.RegisterMethod(MethodTagDelete)
 	toolsets.RegisterMethod(MethodTagGet)
 	toolsets.RegisterMethod(MethodTagList)
 
@@ -47,10 +50,14 @@ func init() {
 	}
 }
 
+type tagGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the tag to retrieve."`
+}
+
 // TagGet finds a tag in Teamwork Desk.  This will find it by ID
 func TagGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTagGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Tag",
@@ -59,56 +66,30 @@ func TagGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific tag in Teamwork Desk by its ID. " +
 				"Useful for auditing tag usage, troubleshooting ticket categorization, or " +
 				"integrating Desk tag data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tag to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: tagGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			tag, err := client.Tags.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagGetInput) (*mcp.CallToolResult, error) {
+			tag, err := client.Tags.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get tag: %w", err)
 			}
 			return helpers.NewToolResultJSON(tag)
 		},
-	}
+	)
+}
+
+type tagListInput struct {
+	paginationInput
+
+	Name     string `json:"name,omitempty" jsonschema:"The name of the tag to filter by."`
+	Color    string `json:"color,omitempty" jsonschema:"The color of the tag to filter by."`
+	InboxIDs []int  `json:"inboxIDs,omitempty" jsonschema:"The IDs of the inboxes to filter by."`
 }
 
 // TagList returns a list of tags that apply to the filters in Teamwork Desk
 func TagList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "string",
-			Description: "The name of the tag to filter by.",
-		},
-		"color": {
-			Type:        "string",
-			Description: "The color of the tag to filter by.",
-		},
-		"inboxIDs": {
-			Type:        "array",
-			Description: "The IDs of the inboxes to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTagList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tags",
@@ -117,37 +98,24 @@ func TagList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all tags in Teamwork Desk, with optional filters for name, color, and inbox association. " +
 				"Enables users to audit, analyze, or synchronize tag configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: tagListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the tag list
-			name := arguments.GetString("name", "")
-			color := arguments.GetString("color", "")
-			inboxIDs := arguments.GetIntSlice("inboxIDs", []int{})
-
 			filter := deskclient.NewFilter()
-			if name != "" {
-				filter = filter.Eq("name", name)
+			if input.Name != "" {
+				filter = filter.Eq("name", input.Name)
 			}
-			if color != "" {
-				filter = filter.Eq("color", color)
+			if input.Color != "" {
+				filter = filter.Eq("color", input.Color)
 			}
-			if len(inboxIDs) > 0 {
-				filter = filter.In("inboxes.id", helpers.SliceToAny(inboxIDs))
+			if len(input.InboxIDs) > 0 {
+				filter = filter.In("inboxes.id", helpers.SliceToAny(input.InboxIDs))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			tags, err := client.Tags.List(ctx, params)
 			if err != nil {
@@ -155,44 +123,30 @@ func TagList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(tags)
 		},
-	}
+	)
+}
+
+type tagCreateInput struct {
+	Name  string `json:"name" jsonschema:"The name of the tag."`
+	Color string `json:"color,omitempty" jsonschema:"The color of the tag."`
 }
 
 // TagCreate creates a tag in Teamwork Desk
 func TagCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTagCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Tag",
 			},
 			Description: "Create a new tag in Teamwork Desk by specifying its name and color. Useful for customizing " +
 				"ticket workflows, introducing new categories, or adapting Desk to evolving support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the tag.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the tag.",
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagCreateInput) (*mcp.CallToolResult, error) {
 			tag, err := client.Tags.Create(ctx, &deskmodels.TagResponse{
 				Tag: deskmodels.Tag{
-					Name:  arguments.GetString("name", ""),
-					Color: arguments.GetString("color", ""),
+					Name:  input.Name,
+					Color: input.Color,
 				},
 			})
 			if err != nil {
@@ -200,13 +154,19 @@ func TagCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Tag created successfully with ID %d", tag.Tag.ID), nil
 		},
-	}
+	)
+}
+
+type tagUpdateInput struct {
+	ID    int    `json:"id" jsonschema:"The ID of the tag to update."`
+	Name  string `json:"name,omitempty" jsonschema:"The new name of the tag."`
+	Color string `json:"color,omitempty" jsonschema:"The color of the tag."`
 }
 
 // TagUpdate updates a tag in Teamwork Desk
 func TagUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTagUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Tag",
@@ -214,35 +174,12 @@ func TagUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing tag in Teamwork Desk by ID, allowing changes to its name and color. " +
 				"Supports evolving support policies, rebranding, or correcting tag attributes for improved " +
 				"ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tag to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The new name of the tag.",
-					},
-					"color": {
-						Type:        "string",
-						Description: "The color of the tag.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			_, err = client.Tags.Update(ctx, arguments.GetInt("id", 0), &deskmodels.TagResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.Tags.Update(ctx, input.ID, &deskmodels.TagResponse{
 				Tag: deskmodels.Tag{
-					Name:  arguments.GetString("name", ""),
-					Color: arguments.GetString("color", ""),
+					Name:  input.Name,
+					Color: input.Color,
 				},
 			})
 			if err != nil {
@@ -251,5 +188,29 @@ func TagUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Tag updated successfully"), nil
 		},
-	}
+	)
+}
+
+type tagDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the tag to delete."`
+}
+
+// TagDelete deletes a tag in Teamwork Desk
+func TagDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodTagDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Tag",
+			},
+			Description: "Delete an existing tag in Teamwork Desk by ID. " +
+				"Useful for retiring tags that are no longer used to categorize tickets.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "tags", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete tag: %w", err)
+			}
+			return helpers.NewToolResultText("Tag deleted successfully"), nil
+		},
+	)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/teamwork/mcp/internal/testutil"
 	"github.com/teamwork/mcp/internal/twdesk"
 )
@@ -44,16 +45,42 @@ func TestTagList(t *testing.T) {
 	defer cleanup()
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTagList.String(), map[string]any{
-		"name":     []string{"urgent", "important"},
-		"color":    []string{"red", "orange"},
+		"name":     "urgent",
+		"color":    "red",
 		"page":     float64(1),
 		"pageSize": float64(10),
 	})
 }
 
+func TestTagListMultipleNames(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusOK, []byte(`{"tags":[]}`))
+	defer cleanup()
+
+	// the filters were silently ignored before the arguments were typed, they are
+	// now rejected so the LLM can fix the call
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTagList.String(), map[string]any{
+		"name":  []string{"urgent", "important"},
+		"color": []string{"red", "orange"},
+	}, testutil.ExecuteToolRequestWithCheckMessage(func(t *testing.T, result mcp.Result) {
+		toolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || !toolResult.IsError {
+			t.Errorf("expected a tool error, got %#v", result)
+		}
+	}))
+}
+
 func TestTagListMinimal(t *testing.T) {
 	mcpServer, cleanup := mcpServerMock(t, http.StatusOK, []byte(`{"tags":[]}`))
 	defer cleanup()
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTagList.String(), map[string]any{})
 }
+
+func TestTagDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTagDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
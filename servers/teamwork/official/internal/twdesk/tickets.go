@@ -10,6 +10,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -27,15 +28,19 @@ var (
 const (
 	MethodTicketCreate toolsets.Method = "twdesk-create_ticket"
 	MethodTicketUpdate toolsets.Method = "twdesk-update_ticket"
+	MethodTicketDelete toolsets.Method = "twdesk-delete_ticket"
 	MethodTicketGet    toolsets.Method = "twdesk-get_ticket"
 	MethodTicketList   toolsets.Method = "twdesk-list_tickets"
+	MethodTicketSearch toolsets.Method = "twdesk-search_tickets"
 )
 
 func init() {
 	toolsets.RegisterMethod(MethodTicketCreate)
 	toolsets.RegisterMethod(MethodTicketUpdate)
+	toolsets.RegisterMethod(MethodTicketDelete)
 	toolsets.RegisterMethod(MethodTicketGet)
 	toolsets.RegisterMethod(MethodTicketList)
+	toolsets.RegisterMethod(MethodTicketSearch)
 
 	var err error
 	ticketGetOutputSchema, err = jsonschema.For[deskmodels.TicketResponse](&jsonschema.ForOptions{})
@@ -54,10 +59,14 @@ func init() {
 	}
 }
 
+type ticketGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the ticket to retrieve."`
+}
+
 // TicketGet finds a ticket in Teamwork Desk.  This will find it by ID
 func TicketGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTicketGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Ticket",
@@ -66,25 +75,10 @@ func TicketGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific ticket in Teamwork Desk by its ID. " +
 				"Useful for auditing ticket records, troubleshooting support workflows, or " +
 				"integrating Desk ticket data into automation and reporting systems.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the ticket to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: ticketGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			ticket, err := client.Tickets.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketGetInput) (*mcp.CallToolResult, error) {
+			ticket, err := client.Tickets.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get ticket: %w", err)
 			}
@@ -105,129 +99,31 @@ func TicketGet(client *deskclient.Client) toolsets.ToolWrapper {
 				StructuredContent: ticket,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type ticketListInput struct {
+	paginationInput
+
+	InboxIDs    []int `json:"inboxIDs,omitempty" jsonschema:"The IDs of the inboxes to filter by. Inbox IDs can be found by using the 'twdesk-list_inboxes' tool."`
+	CustomerIDs []int `json:"customerIDs,omitempty" jsonschema:"The IDs of the customers to filter by. Customer IDs can be found by using the 'twdesk-list_customers' tool."`
+	CompanyIDs  []int `json:"companyIDs,omitempty" jsonschema:"The IDs of the companies to filter by. Company IDs can be found by using the 'twdesk-list_companies' tool."`
+	TagIDs      []int `json:"tagIDs,omitempty" jsonschema:"The IDs of the tags to filter by. Tag IDs can be found by using the 'twdesk-list_tags' tool."`
+	TaskIDs     []int `json:"taskIDs,omitempty" jsonschema:"The IDs of the tasks to filter by. Task IDs can be found by using the 'twprojects-list_tasks' tool."`
+	ProjectsIDs []int `json:"projectsIDs,omitempty" jsonschema:"The IDs of the projects to filter by. Project IDs can be found by using the 'twprojects-list_projects' tool."`
+	StatusIDs   []int `json:"statusIDs,omitempty" jsonschema:"The IDs of the statuses to filter by. Status IDs can be found by using the 'twdesk-list_statuses' tool."`
+	PriorityIDs []int `json:"priorityIDs,omitempty" jsonschema:"The IDs of the priorities to filter by. Priority IDs can be found by using the 'twdesk-list_priorities' tool."`
+	SLAIDs      []int `json:"slaIDs,omitempty" jsonschema:"The IDs of the SLAs to filter by. SLA IDs can be found by using the 'twdesk-list_slas' tool."`
+	UserIDs     []int `json:"userIDs,omitempty" jsonschema:"The IDs of the users to filter by. User IDs can be found by using the 'twdesk-list_users' tool."`
+	Shared      bool  `json:"shared,omitempty" jsonschema:"Find tickets shared with me outside of inboxes I have access to."`
+	SLABreached bool  `json:"slaBreached,omitempty" jsonschema:"Find tickets where the SLA has been breached."`
 }
 
 // TicketList returns a list of tickets that apply to the filters in Teamwork Desk
 func TicketList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"inboxIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the inboxes to filter by.
-				Inbox IDs can be found by using the 'twdesk-list_inboxes' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"customerIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the customers to filter by. 
-			Customer IDs can be found by using the 'twdesk-list_customers' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"companyIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the companies to filter by. 
-			Company IDs can be found by using the 'twdesk-list_companies' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"tagIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the tags to filter by. 
-			Tag IDs can be found by using the 'twdesk-list_tags' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"taskIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the tasks to filter by.
-				Task IDs can be found by using the 'twprojects-list_tasks' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"projectsIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the projects to filter by.
-				Project IDs can be found by using the 'twprojects-list_projects' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"statusIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the statuses to filter by.
-				Status IDs can be found by using the 'twdesk-list_statuses' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"priorityIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the priorities to filter by.
-				Priority IDs can be found by using the 'twdesk-list_priorities' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"slaIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the SLAs to filter by.
-				SLA IDs can be found by using the 'twdesk-list_slas' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"userIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the users to filter by.
-				User IDs can be found by using the 'twdesk-list_users' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"shared": {
-			Type: "boolean",
-			Description: `
-			Find tickets shared with me outside of inboxes I have access to
-		`,
-		},
-		"slaBreached": {
-			Type: "boolean",
-			Description: `
-			Find tickets where the SLA has been breached
-		`,
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTicketList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tickets",
@@ -236,85 +132,63 @@ func TicketList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all tickets in Teamwork Desk, with extensive filters for inbox, customer, company, " +
 				"tag, status, priority, SLA, user, and more. Enables users to audit, analyze, or synchronize ticket data " +
 				"for support management, reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: ticketListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the ticket list
-			inboxIDs := arguments.GetIntSlice("inboxIDs", []int{})
-			customerIDs := arguments.GetIntSlice("customerIDs", []int{})
-			companyIDs := arguments.GetIntSlice("companyIDs", []int{})
-			tagIDs := arguments.GetIntSlice("tagIDs", []int{})
-			taskIDs := arguments.GetIntSlice("taskIDs", []int{})
-			projectsIDs := arguments.GetIntSlice("projectsIDs", []int{})
-			statusIDs := arguments.GetIntSlice("statusIDs", []int{})
-			priorityIDs := arguments.GetIntSlice("priorityIDs", []int{})
-			slaIDs := arguments.GetIntSlice("slaIDs", []int{})
-			userIDs := arguments.GetIntSlice("userIDs", []int{})
-			shared := arguments.GetBool("shared", false)
-			slaBreached := arguments.GetBool("slaBreached", false)
-
 			filter := deskclient.NewFilter()
 
-			if len(inboxIDs) > 0 {
-				filter = filter.In("inboxes.id", helpers.SliceToAny(inboxIDs))
+			if len(input.InboxIDs) > 0 {
+				filter = filter.In("inboxes.id", helpers.SliceToAny(input.InboxIDs))
 			}
 
-			if len(customerIDs) > 0 {
-				filter = filter.In("customers.id", helpers.SliceToAny(customerIDs))
+			if len(input.CustomerIDs) > 0 {
+				filter = filter.In("customers.id", helpers.SliceToAny(input.CustomerIDs))
 			}
 
-			if len(companyIDs) > 0 {
-				filter = filter.In("companies.id", helpers.SliceToAny(companyIDs))
+			if len(input.CompanyIDs) > 0 {
+				filter = filter.In("companies.id", helpers.SliceToAny(input.CompanyIDs))
 			}
 
-			if len(tagIDs) > 0 {
-				filter = filter.In("tags.id", helpers.SliceToAny(tagIDs))
+			if len(input.TagIDs) > 0 {
+				filter = filter.In("tags.id", helpers.SliceToAny(input.TagIDs))
 			}
 
-			if len(taskIDs) > 0 {
-				filter = filter.In("tasks.id", helpers.SliceToAny(taskIDs))
+			if len(input.TaskIDs) > 0 {
+				filter = filter.In("tasks.id", helpers.SliceToAny(input.TaskIDs))
 			}
 
-			if len(projectsIDs) > 0 {
-				filter = filter.In("projects.id", helpers.SliceToAny(projectsIDs))
+			if len(input.ProjectsIDs) > 0 {
+				filter = filter.In("projects.id", helpers.SliceToAny(input.ProjectsIDs))
 			}
 
-			if len(statusIDs) > 0 {
-				filter = filter.In("statuses.id", helpers.SliceToAny(statusIDs))
+			if len(input.StatusIDs) > 0 {
+				filter = filter.In("statuses.id", helpers.SliceToAny(input.StatusIDs))
 			}
 
-			if len(priorityIDs) > 0 {
-				filter = filter.In("priorities.id", helpers.SliceToAny(priorityIDs))
+			if len(input.PriorityIDs) > 0 {
+				filter = filter.In("priorities.id", helpers.SliceToAny(input.PriorityIDs))
 			}
 
-			if len(slaIDs) > 0 {
-				filter = filter.In("slas.id", helpers.SliceToAny(slaIDs))
+			if len(input.SLAIDs) > 0 {
+				filter = filter.In("slas.id", helpers.SliceToAny(input.SLAIDs))
 			}
 
-			if len(userIDs) > 0 {
-				filter = filter.In("users.id", helpers.SliceToAny(userIDs))
+			if len(input.UserIDs) > 0 {
+				filter = filter.In("users.id", helpers.SliceToAny(input.UserIDs))
 			}
 
-			if shared {
+			if input.Shared {
 				filter = filter.Eq("shared", true)
 			}
 
-			if slaBreached {
+			if input.SLABreached {
 				filter = filter.Eq("sla_breached", true)
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			tickets, err := client.Tickets.List(ctx, params)
 			if err != nil {
@@ -322,303 +196,109 @@ func TicketList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(tickets)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type ticketSearchInput struct {
+	Search      string  `json:"search" jsonschema:"The search term to use for finding tickets. This can be part of the subject, body, or other ticket fields."`
+	InboxIDs    []int64 `json:"inboxIDs,omitempty" jsonschema:"The IDs of the inboxes to filter by. Inbox IDs can be found by using the 'twdesk-list_inboxes' tool."`
+	CustomerIDs []int64 `json:"customerIDs,omitempty" jsonschema:"The IDs of the customers to filter by. Customer IDs can be found by using the 'twdesk-list_customers' tool."`
+	CompanyIDs  []int64 `json:"companyIDs,omitempty" jsonschema:"The IDs of the companies to filter by. Company IDs can be found by using the 'twdesk-list_companies' tool."`
+	TagIDs      []int64 `json:"tagIDs,omitempty" jsonschema:"The IDs of the tags to filter by. Tag IDs can be found by using the 'twdesk-list_tags' tool."`
+	StatusIDs   []int64 `json:"statusIDs,omitempty" jsonschema:"The IDs of the statuses to filter by. Status IDs can be found by using the 'twdesk-list_statuses' tool."`
+	PriorityIDs []int64 `json:"priorityIDs,omitempty" jsonschema:"The IDs of the priorities to filter by. Priority IDs can be found by using the 'twdesk-list_priorities' tool."`
+	UserIDs     []int64 `json:"userIDs,omitempty" jsonschema:"The IDs of the users to filter by. User IDs can be found by using the 'twdesk-list_users' tool."`
 }
 
 // TicketSearch uses the search API to find tickets in Teamwork Desk
 func TicketSearch(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"search": {
-			Type: "string",
-			Description: `
-				The search term to use for finding tickets.
-				This can be part of the subject, body, or other ticket fields.
-			`,
-		},
-		"inboxIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the inboxes to filter by.
-				Inbox IDs can be found by using the 'twdesk-list_inboxes' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"customerIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the customers to filter by. 
-			Customer IDs can be found by using the 'twdesk-list_customers' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"companyIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the companies to filter by. 
-			Company IDs can be found by using the 'twdesk-list_companies' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"tagIDs": {
-			Type: "array",
-			Description: `
-			The IDs of the tags to filter by. 
-			Tag IDs can be found by using the 'twdesk-list_tags' tool.
-		`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"statusIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the statuses to filter by.
-				Status IDs can be found by using the 'twdesk-list_statuses' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"priorityIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the priorities to filter by.
-				Priority IDs can be found by using the 'twdesk-list_priorities' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"userIDs": {
-			Type: "array",
-			Description: `
-				The IDs of the users to filter by.
-				User IDs can be found by using the 'twdesk-list_users' tool.
-			`,
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"shared": {
-			Type: "boolean",
-			Description: `
-			Find tickets shared with me outside of inboxes I have access to
-		`,
-		},
-		"slaBreached": {
-			Type: "boolean",
-			Description: `
-			Find tickets where the SLA has been breached
-		`,
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
-			Name: string(MethodTicketList),
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodTicketSearch),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Search Tickets",
 				ReadOnlyHint: true,
 			},
 			Description: "Search tickets in Teamwork Desk using various filters including inbox, customer, company, " +
-				"tag, status, priority, SLA, user, and more. This tool enables users to perform targeted searches " +
+				"tag, status, priority, user, and more. This tool enables users to perform targeted searches " +
 				"for tickets, facilitating efficient support management, reporting, and integration with other systems.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-				Required:   []string{"search"},
-			},
 			OutputSchema: ticketSearchOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			params := &deskmodels.SearchTicketsFilter{}
-
-			params.Search = arguments.GetString("search", "")
-
-			if arguments.GetIntSlice("customerIDs", nil) != nil {
-				params.Customers = helpers.IntSliceToInt64(arguments.GetIntSlice("customerIDs", nil))
-			}
-			if arguments.GetIntSlice("companyIDs", nil) != nil {
-				params.Companies = helpers.IntSliceToInt64(arguments.GetIntSlice("companyIDs", nil))
-			}
-			if arguments.GetIntSlice("tagIDs", nil) != nil {
-				params.Tags = helpers.IntSliceToInt64(arguments.GetIntSlice("tagIDs", nil))
-			}
-			if arguments.GetIntSlice("statusIDs", nil) != nil {
-				params.Statuses = helpers.IntSliceToInt64(arguments.GetIntSlice("statusIDs", nil))
-			}
-			if arguments.GetIntSlice("priorityIDs", nil) != nil {
-				params.Priorities = helpers.IntSliceToInt64(arguments.GetIntSlice("priorityIDs", nil))
-			}
-			if arguments.GetIntSlice("userIDs", nil) != nil {
-				params.Agents = helpers.IntSliceToInt64(arguments.GetIntSlice("userIDs", nil))
-			}
-
-			tickets, err := client.Tickets.Search(ctx, params)
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketSearchInput) (*mcp.CallToolResult, error) {
+			tickets, err := client.Tickets.Search(ctx, &deskmodels.SearchTicketsFilter{
+				Search:     input.Search,
+				Inboxes:    input.InboxIDs,
+				Customers:  input.CustomerIDs,
+				Companies:  input.CompanyIDs,
+				Tags:       input.TagIDs,
+				Statuses:   input.StatusIDs,
+				Priorities: input.PriorityIDs,
+				Agents:     input.UserIDs,
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list tickets: %w", err)
 			}
 			return helpers.NewToolResultJSON(tickets)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type ticketCreateInput struct {
+	Subject        string   `json:"subject" jsonschema:"The subject of the ticket."`
+	Body           string   `json:"body" jsonschema:"The body of the ticket."`
+	NotifyCustomer bool     `json:"notifyCustomer,omitempty" jsonschema:"Set to true if the the customer should be sent a copy of the ticket."`
+	BCC            []string `json:"bcc,omitempty" jsonschema:"An array of email addresses to BCC on ticket creation."`
+	CC             []string `json:"cc,omitempty" jsonschema:"An array of email addresses to CC on ticket creation."`
+	Files          []int    `json:"files,omitempty" jsonschema:"An array of file IDs to attach to the ticket. Use the 'twdesk-create_file' tool to upload files."`
+	Tags           []int    `json:"tags,omitempty" jsonschema:"An array of tag IDs to associate with the ticket. Tag IDs can be found by using the 'twdesk-list_tags' tool."`
+	PriorityID     int      `json:"priorityId,omitempty" jsonschema:"The priority of the ticket. Use the 'twdesk-list_priorities' tool to find valid IDs."`
+	StatusID       int      `json:"statusId,omitempty" jsonschema:"The status of the ticket. Use the 'twdesk-list_statuses' tool to find valid IDs."`
+	InboxID        int      `json:"inboxId" jsonschema:"The inbox ID of the ticket. Use the 'twdesk-list_inboxes' tool to find valid IDs."`
+	CustomerID     int      `json:"customerId,omitempty" jsonschema:"The customer ID of the ticket. Use the 'twdesk-list_customers' tool to find valid IDs."`
+	CustomerEmail  string   `json:"customerEmail,omitempty" jsonschema:"The email address of the customer. This is used to identify the customer in the system. Either the customerId or customerEmail is required to create a ticket. If email is provided we will either find or create the customer."`
+	TypeID         int      `json:"typeId,omitempty" jsonschema:"The type ID of the ticket. Use the 'twdesk-list_types' tool to find valid IDs."`
+	AgentID        int      `json:"agentId,omitempty" jsonschema:"The agent ID that the ticket should be assigned to. Use the 'twdesk-list_agents' tool to find valid IDs."`
 }
 
 // TicketCreate creates a ticket in Teamwork Desk
 func TicketCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTicketCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Ticket",
 			},
-			Description: `
-				Create a new ticket in Teamwork Desk by specifying subject, description, priority, and status.
-				"Useful for automating ticket creation, integrating external systems, or customizing support workflows.
-			`,
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"subject": {
-						Type:        "string",
-						Description: "The subject of the ticket.",
-					},
-					"body": {
-						Type:        "string",
-						Description: "The body of the ticket.",
-					},
-					"notifyCustomer": {
-						Type:        "boolean",
-						Description: "Set to true if the the customer should be sent a copy of the ticket.",
-					},
-					"bcc": {
-						Type:        "array",
-						Description: "An array of email addresses to BCC on ticket creation.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-						},
-					},
-					"cc": {
-						Type:        "array",
-						Description: "An array of email addresses to CC on ticket creation.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-						},
-					},
-					"files": {
-						Type: "array",
-						Description: `
-					An array of file IDs to attach to the ticket.  
-					Use the 'twdesk-create_file' tool to upload files.
-				`,
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"tags": {
-						Type: "array",
-						Description: `
-					An array of tag IDs to associate with the ticket. 
-					Tag IDs can be found by using the 'twdesk-list_tags' tool.
-				`,
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"priorityId": {
-						Type: "integer",
-						Description: `
-					The priority of the ticket. 
-					Use the 'twdesk-list_priorities' tool to find valid IDs.
-				`,
-					},
-					"statusId": {
-						Type: "integer",
-						Description: `
-					The status of the ticket. 
-					Use the 'twdesk-list_statuses' tool to find valid IDs.
-				`,
-					},
-					"inboxId": {
-						Type: "integer",
-						Description: `
-					The inbox ID of the ticket. 
-					Use the 'twdesk-list_inboxes' tool to find valid IDs.
-				`,
-					},
-					"customerId": {
-						Type: "integer",
-						Description: `
-					The customer ID of the ticket. 
-					Use the 'twdesk-list_customers' tool to find valid IDs.
-				`,
-					},
-					"customerEmail": {
-						Type: "string",
-						Description: `
-				The email address of the customer. 
-				This is used to identify the customer in the system.
-				Either the customerId or customerEmail is required to create a ticket.  
-				If email is provided we will either find or create the customer.
-			`,
-					},
-					"typeId": {
-						Type: "integer",
-						Description: `
-					The type ID of the ticket. 
-					Use the 'twdesk-list_types' tool to find valid IDs.
-				`,
-					},
-					"agentId": {
-						Type: "integer",
-						Description: `
-					The agent ID that the ticket should be assigned to. 
-					Use the 'twdesk-list_agents' tool to find valid IDs.
-				`,
-					},
-				},
-				Required: []string{"subject", "body", "inboxId"},
-			},
+			Description: "Create a new ticket in Teamwork Desk by specifying subject, description, priority, and status. " +
+				"Useful for automating ticket creation, integrating external systems, or customizing support workflows.",
 			OutputSchema: ticketGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketCreateInput) (*mcp.CallToolResult, error) {
 			data := deskmodels.Ticket{
-				Subject: arguments.GetString("subject", ""),
-				Body:    arguments.GetString("body", ""),
+				Subject: input.Subject,
+				Body:    input.Body,
 				Inbox: deskmodels.EntityRef{
-					ID: arguments.GetInt("inboxId", 0),
+					ID: input.InboxID,
 				},
+				NotifyCustomer: input.NotifyCustomer,
+				BCC:            input.BCC,
+				CC:             input.CC,
 			}
 
-			if arguments.GetInt("customerId", 0) != 0 {
+			if input.CustomerID != 0 {
 				data.Customer = deskmodels.EntityRef{
-					ID: arguments.GetInt("customerId", 0),
+					ID: input.CustomerID,
 				}
 			}
 
-			if email := arguments.GetString("customerEmail", ""); email != "" {
+			if input.CustomerEmail != "" {
 				filter := deskclient.NewFilter()
-				filter = filter.Eq("contacts.value", email)
+				filter = filter.Eq("contacts.value", input.CustomerEmail)
 
 				params := url.Values{}
 				params.Set("filter", filter.Build())
-				setPagination(&params, arguments)
+				setPagination(&params, paginationInput{})
 
 				customers, err := client.Customers.List(ctx, params)
 				if err != nil {
@@ -633,7 +313,7 @@ func TicketCreate(client *deskclient.Client) toolsets.ToolWrapper {
 					// Create the customer
 					customer, err := client.Customers.Create(ctx, &deskmodels.CustomerResponse{
 						Customer: deskmodels.Customer{
-							Email: email,
+							Email: input.CustomerEmail,
 						},
 					})
 					if err != nil {
@@ -645,54 +325,28 @@ func TicketCreate(client *deskclient.Client) toolsets.ToolWrapper {
 				}
 			}
 
-			if arguments.GetInt("priorityId", 0) != 0 {
-				data.Priority = &deskmodels.EntityRef{
-					ID: arguments.GetInt("priorityId", 0),
-				}
+			if input.PriorityID != 0 {
+				data.Priority = &deskmodels.EntityRef{ID: input.PriorityID}
 			}
 
-			if arguments.GetInt("statusId", 0) != 0 {
-				data.Status = &deskmodels.EntityRef{
-					ID: arguments.GetInt("statusId", 0),
-				}
+			if input.StatusID != 0 {
+				data.Status = &deskmodels.EntityRef{ID: input.StatusID}
 			}
 
-			if arguments.GetInt("typeId", 0) != 0 {
-				data.Type = &deskmodels.EntityRef{
-					ID: arguments.GetInt("typeId", 0),
-				}
+			if input.TypeID != 0 {
+				data.Type = &deskmodels.EntityRef{ID: input.TypeID}
 			}
 
-			if arguments.GetInt("agentId", 0) != 0 {
-				data.Agent = &deskmodels.EntityRef{
-					ID: arguments.GetInt("agentId", 0),
-				}
+			if input.AgentID != 0 {
+				data.Agent = &deskmodels.EntityRef{ID: input.AgentID}
 			}
 
-			if arguments.GetBool("notifyCustomer", false) {
-				data.NotifyCustomer = true
+			for _, fileID := range input.Files {
+				data.Files = append(data.Files, deskmodels.EntityRef{ID: fileID})
 			}
 
-			if len(arguments.GetIntSlice("files", []int{})) > 0 {
-				data.Files = []deskmodels.EntityRef{}
-				for _, fileID := range arguments.GetIntSlice("files", []int{}) {
-					data.Files = append(data.Files, deskmodels.EntityRef{ID: fileID})
-				}
-			}
-
-			if len(arguments.GetIntSlice("tags", []int{})) > 0 {
-				data.Tags = []deskmodels.EntityRef{}
-				for _, tagID := range arguments.GetIntSlice("tags", []int{}) {
-					data.Tags = append(data.Tags, deskmodels.EntityRef{ID: tagID})
-				}
-			}
-
-			if len(arguments.GetStringSlice("bcc", []string{})) > 0 {
-				data.BCC = arguments.GetStringSlice("bcc", []string{})
-			}
-
-			if len(arguments.GetStringSlice("cc", []string{})) > 0 {
-				data.CC = arguments.GetStringSlice("cc", []string{})
+			for _, tagID := range input.Tags {
+				data.Tags = append(data.Tags, deskmodels.EntityRef{ID: tagID})
 			}
 
 			ticket, err := client.Tickets.Create(ctx, &deskmodels.TicketResponse{
@@ -703,13 +357,24 @@ func TicketCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(ticket)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type ticketUpdateInput struct {
+	ID         int    `json:"id" jsonschema:"The ID of the ticket to update."`
+	Subject    string `json:"subject,omitempty" jsonschema:"The subject of the ticket."`
+	Body       string `json:"body,omitempty" jsonschema:"The body of the ticket."`
+	PriorityID int    `json:"priorityId,omitempty" jsonschema:"The priority of the ticket. Use the 'twdesk-list_priorities' tool to find valid IDs."`
+	StatusID   int    `json:"statusId,omitempty" jsonschema:"The status of the ticket. Use the 'twdesk-list_statuses' tool to find valid IDs."`
+	TypeID     int    `json:"typeId,omitempty" jsonschema:"The type ID of the ticket. Use the 'twdesk-list_types' tool to find valid IDs."`
+	AgentID    int    `json:"agentId,omitempty" jsonschema:"The agent ID that the ticket should be assigned to. Use the 'twdesk-list_agents' tool to find valid IDs."`
 }
 
 // TicketUpdate updates a ticket in Teamwork Desk
 func TicketUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTicketUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Ticket",
@@ -717,83 +382,31 @@ func TicketUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing ticket in Teamwork Desk by ID, allowing changes to its attributes. " +
 				"Supports evolving support processes, correcting ticket records, or integrating with automation " +
 				"systems for improved ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the ticket to update.",
-					},
-					"subject": {
-						Type:        "string",
-						Description: "The subject of the ticket.",
-					},
-					"body": {
-						Type:        "string",
-						Description: "The body of the ticket.",
-					},
-					"priorityId": {
-						Type: "integer",
-						Description: `
-					The priority of the ticket. 
-					Use the 'twdesk-list_priorities' tool to find valid IDs.
-				`,
-					},
-					"statusId": {
-						Type: "integer",
-						Description: `
-					The status of the ticket. 
-					Use the 'twdesk-list_statuses' tool to find valid IDs.
-				`,
-					},
-					"typeId": {
-						Type: "integer",
-						Description: `
-					The type ID of the ticket. 
-					Use the 'twdesk-list_types' tool to find valid IDs.
-				`,
-					},
-					"agentId": {
-						Type: "integer",
-						Description: `
-					The agent ID that the ticket should be assigned to. 
-					Use the 'twdesk-list_agents' tool to find valid IDs.
-				`,
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: ticketGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			data := deskmodels.Ticket{}
-
-			if subject := arguments.GetString("subject", ""); subject != "" {
-				data.Subject = subject
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketUpdateInput) (*mcp.CallToolResult, error) {
+			data := deskmodels.Ticket{
+				Subject: input.Subject,
+				Body:    input.Body,
 			}
 
-			if body := arguments.GetString("body", ""); body != "" {
-				data.Body = body
+			if input.PriorityID > 0 {
+				data.Priority = &deskmodels.EntityRef{ID: input.PriorityID}
 			}
 
-			if statusId := arguments.GetInt("statusId", 0); statusId > 0 {
-				data.Status = &deskmodels.EntityRef{ID: statusId}
+			if input.StatusID > 0 {
+				data.Status = &deskmodels.EntityRef{ID: input.StatusID}
 			}
 
-			if typeId := arguments.GetInt("typeId", 0); typeId > 0 {
-				data.Type = &deskmodels.EntityRef{ID: typeId}
+			if input.TypeID > 0 {
+				data.Type = &deskmodels.EntityRef{ID: input.TypeID}
 			}
 
-			if agentId := arguments.GetInt("agentId", 0); agentId > 0 {
-				data.Agent = &deskmodels.EntityRef{ID: agentId}
+			if input.AgentID > 0 {
+				data.Agent = &deskmodels.EntityRef{ID: input.AgentID}
 			}
 
-			ticket, err := client.Tickets.Update(ctx, arguments.GetInt("id", 0), &deskmodels.TicketResponse{
+			ticket, err := client.Tickets.Update(ctx, input.ID, &deskmodels.TicketResponse{
 				Ticket: data,
 			})
 			if err != nil {
@@ -801,5 +414,30 @@ func TicketUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(ticket)
 		},
-	}
+	)
+}
+
+type ticketDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the ticket to delete."`
+}
+
+// TicketDelete deletes a ticket in Teamwork Desk
+func TicketDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodTicketDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Ticket",
+			},
+			Description: "Delete an existing ticket in Teamwork Desk by ID. " +
+				"Removes the ticket along with its conversation history, so it should only be used for spam, " +
+				"duplicates or tickets created by mistake.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input ticketDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "tickets", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete ticket: %w", err)
+			}
+			return helpers.NewToolResultText("Ticket deleted successfully"), nil
+		},
+	)
 }
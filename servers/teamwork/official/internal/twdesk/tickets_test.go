@@ -70,11 +70,18 @@ func TestTicketSearch(t *testing.T) {
 	mcpServer, cleanup := mcpServerMock(t, http.StatusOK, []byte(`{"tickets":[{"id":123,"subject":"Ticket 1"},{"id":124,"subject":"Ticket 2"}]}`))
 	defer cleanup()
 
-	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTicketList.String(), map[string]any{
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTicketSearch.String(), map[string]any{
 		"search":      "Testing 123",
 		"statusIDs":   []float64{1, 2},
 		"priorityIDs": []float64{1, 2, 3},
-		"page":        float64(1),
-		"pageSize":    float64(10),
+	})
+}
+
+func TestTicketDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTicketDelete.String(), map[string]any{
+		"id": float64(123),
 	})
 }
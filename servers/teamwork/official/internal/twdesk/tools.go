@@ -2,11 +2,25 @@ package twdesk
 
 import (
 	deskclient "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
 
-// DefaultToolsetGroup creates a default ToolsetGroup for Teamwork Projects.
-func DefaultToolsetGroup(client *deskclient.Client) *toolsets.ToolsetGroup {
+// ToolsetDesk is the toolset containing all Teamwork Desk tools.
+const ToolsetDesk toolsets.Method = "desk"
+
+func init() {
+	// register the toolset methods
+	toolsets.RegisterMethod(ToolsetDesk)
+}
+
+// DefaultToolsetGroup creates a default ToolsetGroup for Teamwork Desk. The API
+// client sends the requests not supported by the Desk SDK, such as deletes.
+func DefaultToolsetGroup(
+	readOnly, allowDelete bool,
+	client *deskclient.Client,
+	api *deskapi.Client,
+) *toolsets.ToolsetGroup {
 	readTools := []toolsets.ToolWrapper{
 		CompanyGet(client),
 		CompanyList(client),
@@ -48,8 +62,22 @@ func DefaultToolsetGroup(client *deskclient.Client) *toolsets.ToolsetGroup {
 		TypeUpdate(client),
 	}
 
-	group := toolsets.NewToolsetGroup(false)
-	group.AddToolset(toolsets.NewToolset("desk", projectDescription).
+	// the delete tools are only available when deletion is explicitly allowed
+	if allowDelete {
+		deleteTools := []toolsets.ToolWrapper{
+			CompanyDelete(api),
+			CustomerDelete(api),
+			PriorityDelete(api),
+			StatusDelete(api),
+			TagDelete(api),
+			TicketDelete(api),
+			TypeDelete(api),
+		}
+		writeTools = append(writeTools, deleteTools...)
+	}
+
+	group := toolsets.NewToolsetGroup(readOnly)
+	group.AddToolset(toolsets.NewToolset(ToolsetDesk, projectDescription).
 		AddWriteTools(writeTools...).
 		AddReadTools(readTools...))
 	return group
@@ -0,0 +1,75 @@
+package twdesk_test
+
+import (
+	"testing"
+
+	deskclient "github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/mcp/internal/deskapi"
+	"github.com/teamwork/mcp/internal/twdesk"
+)
+
+func TestDefaultToolsetGroup(t *testing.T) {
+	client := &deskclient.Client{}
+	api := deskapi.NewClient("https://example.teamwork.com/desk/api/v2", nil)
+
+	deleteMethods := []string{
+		twdesk.MethodCompanyDelete.String(),
+		twdesk.MethodCustomerDelete.String(),
+		twdesk.MethodPriorityDelete.String(),
+		twdesk.MethodStatusDelete.String(),
+		twdesk.MethodTagDelete.String(),
+		twdesk.MethodTicketDelete.String(),
+		twdesk.MethodTypeDelete.String(),
+	}
+
+	tests := []struct {
+		name        string
+		readOnly    bool
+		allowDelete bool
+		wantDelete  bool
+	}{{
+		name: "default",
+	}, {
+		name:     "read-only",
+		readOnly: true,
+	}, {
+		name:        "allow delete",
+		allowDelete: true,
+		wantDelete:  true,
+	}, {
+		name:        "read-only with allow delete",
+		readOnly:    true,
+		allowDelete: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := twdesk.DefaultToolsetGroup(tt.readOnly, tt.allowDelete, client, api)
+
+			toolset, err := group.GetToolset(twdesk.ToolsetDesk)
+			if err != nil {
+				t.Fatalf("failed to get toolset: %v", err)
+			}
+
+			var writeTools int
+			available := make(map[string]bool)
+			for _, tool := range toolset.GetAvailableTools() {
+				available[tool.Tool.Name] = true
+				if !tool.Tool.Annotations.ReadOnlyHint {
+					writeTools++
+				}
+			}
+			if tt.readOnly && writeTools > 0 {
+				t.Errorf("expected no write tools in read-only mode, got %d", writeTools)
+			}
+			if !tt.readOnly && writeTools == 0 {
+				t.Error("expected write tools")
+			}
+			for _, method := range deleteMethods {
+				if available[method] != tt.wantDelete {
+					t.Errorf("unexpected availability of %q: %t, want %t", method, available[method], tt.wantDelete)
+				}
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	deskclient "github.com/teamwork/desksdkgo/client"
 	deskmodels "github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/mcp/internal/deskapi"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 )
@@ -20,6 +21,7 @@ import (
 const (
 	MethodTypeCreate toolsets.Method = "twdesk-create_type"
 	MethodTypeUpdate toolsets.Method = "twdesk-update_type"
+	MethodTypeDelete toolsets.Method = "twdesk-delete_type"
 	MethodTypeGet    toolsets.Method = "twdesk-get_type"
 	MethodTypeList   toolsets.Method = "twdesk-list_types"
 )
@@ -32,6 +34,7 @@ var (
 func init() {
 	toolsets.RegisterMethod(MethodTypeCreate)
 	toolsets.RegisterMethod(MethodTypeUpdate)
+	toolsets.RegisterMethod(MethodTypeDelete)
 	toolsets.RegisterMethod(MethodTypeGet)
 	toolsets.RegisterMethod(MethodTypeList)
 
@@ -47,10 +50,14 @@ func init() {
 	}
 }
 
+type typeGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the type to retrieve."`
+}
+
 // TypeGet finds a type in Teamwork Desk.  This will find it by ID
 func TypeGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTypeGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Type",
@@ -59,55 +66,29 @@ func TypeGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific ticket type in Teamwork Desk by its ID. " +
 				"Useful for auditing type usage, troubleshooting ticket categorization, or " +
 				"integrating Desk type data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the type to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: typeGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			t, err := client.TicketTypes.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input typeGetInput) (*mcp.CallToolResult, error) {
+			t, err := client.TicketTypes.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get type: %w", err)
 			}
 			return helpers.NewToolResultJSON(t)
 		},
-	}
+	)
+}
+
+type typeListInput struct {
+	paginationInput
+
+	Name     []string `json:"name,omitempty" jsonschema:"The name of the type to filter by."`
+	InboxIDs []int    `json:"inboxIDs,omitempty" jsonschema:"The inbox IDs of the type to filter by."`
 }
 
 // TypeList returns a list of types that apply to the filters in Teamwork Desk
 func TypeList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"name": {
-			Type:        "array",
-			Description: "The name of the type to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"inboxIDs": {
-			Type:        "array",
-			Description: "The inbox IDs of the type to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTypeList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Types",
@@ -116,33 +97,21 @@ func TypeList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all ticket types in Teamwork Desk, with optional filters for name and inbox association. " +
 				"Enables users to audit, analyze, or synchronize type configurations for ticket management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: typeListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input typeListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the type list
-			name := arguments.GetStringSlice("name", []string{})
-			inboxIDs := arguments.GetStringSlice("inboxIDs", []string{})
-
 			filter := deskclient.NewFilter()
-			if len(name) > 0 {
-				filter = filter.In("name", helpers.SliceToAny(name))
+			if len(input.Name) > 0 {
+				filter = filter.In("name", helpers.SliceToAny(input.Name))
 			}
-			if len(inboxIDs) > 0 {
-				filter = filter.In("inboxes.id", helpers.SliceToAny(inboxIDs))
+			if len(input.InboxIDs) > 0 {
+				filter = filter.In("inboxes.id", helpers.SliceToAny(input.InboxIDs))
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			types, err := client.TicketTypes.List(ctx, params)
 			if err != nil {
@@ -150,13 +119,19 @@ func TypeList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(types)
 		},
-	}
+	)
+}
+
+type typeCreateInput struct {
+	Name                    string `json:"name" jsonschema:"The name of the type."`
+	DisplayOrder            int    `json:"displayOrder,omitempty" jsonschema:"The display order of the type."`
+	EnabledForFutureInboxes bool   `json:"enabledForFutureInboxes,omitempty" jsonschema:"Whether the type is enabled for future inboxes."`
 }
 
 // TypeCreate creates a type in Teamwork Desk
 func TypeCreate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTypeCreate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Type",
@@ -164,36 +139,13 @@ func TypeCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Create a new ticket type in Teamwork Desk by specifying its name, display order, and future " +
 				"inbox settings. Useful for customizing ticket workflows, introducing new categories, or " +
 				"adapting Desk to evolving support processes.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the type.",
-					},
-					"displayOrder": {
-						Type:        "integer",
-						Description: "The display order of the type.",
-					},
-					"enabledForFutureInboxes": {
-						Type:        "boolean",
-						Description: "Whether the type is enabled for future inboxes.",
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input typeCreateInput) (*mcp.CallToolResult, error) {
 			t, err := client.TicketTypes.Create(ctx, &deskmodels.TicketTypeResponse{
 				TicketType: deskmodels.TicketType{
-					Name:                    arguments.GetString("name", ""),
-					DisplayOrder:            arguments.GetInt("displayOrder", 0),
-					EnabledForFutureInboxes: arguments.GetBool("enabledForFutureInboxes", false),
+					Name:                    input.Name,
+					DisplayOrder:            input.DisplayOrder,
+					EnabledForFutureInboxes: input.EnabledForFutureInboxes,
 				},
 			})
 			if err != nil {
@@ -201,13 +153,20 @@ func TypeCreate(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Type created successfully with ID %d", t.TicketType.ID), nil
 		},
-	}
+	)
+}
+
+type typeUpdateInput struct {
+	ID                      int    `json:"id" jsonschema:"The ID of the type to update."`
+	Name                    string `json:"name,omitempty" jsonschema:"The new name of the type."`
+	DisplayOrder            int    `json:"displayOrder,omitempty" jsonschema:"The display order of the type."`
+	EnabledForFutureInboxes bool   `json:"enabledForFutureInboxes,omitempty" jsonschema:"Whether the type is enabled for future inboxes."`
 }
 
 // TypeUpdate updates a type in Teamwork Desk
 func TypeUpdate(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodTypeUpdate),
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Type",
@@ -215,40 +174,13 @@ func TypeUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Update an existing ticket type in Teamwork Desk by ID, allowing changes to its name, " +
 				"display order, and future inbox settings. Supports evolving support policies, rebranding, or correcting " +
 				"type attributes for improved ticket handling.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the type to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The new name of the type.",
-					},
-					"displayOrder": {
-						Type:        "integer",
-						Description: "The display order of the type.",
-					},
-					"enabledForFutureInboxes": {
-						Type:        "boolean",
-						Description: "Whether the type is enabled for future inboxes.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			_, err = client.TicketTypes.Update(ctx, arguments.GetInt("id", 0), &deskmodels.TicketTypeResponse{
+		func(ctx context.Context, _ *mcp.CallToolRequest, input typeUpdateInput) (*mcp.CallToolResult, error) {
+			_, err := client.TicketTypes.Update(ctx, input.ID, &deskmodels.TicketTypeResponse{
 				TicketType: deskmodels.TicketType{
-					Name:                    arguments.GetString("name", ""),
-					DisplayOrder:            arguments.GetInt("displayOrder", 0),
-					EnabledForFutureInboxes: arguments.GetBool("enabledForFutureInboxes", false),
+					Name:                    input.Name,
+					DisplayOrder:            input.DisplayOrder,
+					EnabledForFutureInboxes: input.EnabledForFutureInboxes,
 				},
 			})
 			if err != nil {
@@ -257,5 +189,29 @@ func TypeUpdate(client *deskclient.Client) toolsets.ToolWrapper {
 
 			return helpers.NewToolResultText("Type updated successfully"), nil
 		},
-	}
+	)
+}
+
+type typeDeleteInput struct {
+	ID int `json:"id" jsonschema:"The ID of the type to delete."`
+}
+
+// TypeDelete deletes a type in Teamwork Desk
+func TypeDelete(api *deskapi.Client) toolsets.ToolWrapper {
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
+			Name: string(MethodTypeDelete),
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Delete Type",
+			},
+			Description: "Delete an existing type in Teamwork Desk by ID. " +
+				"Useful for retiring ticket types that no longer match the support processes.",
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, input typeDeleteInput) (*mcp.CallToolResult, error) {
+			if err := api.Delete(ctx, "tickettypes", input.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete type: %w", err)
+			}
+			return helpers.NewToolResultText("Type deleted successfully"), nil
+		},
+	)
 }
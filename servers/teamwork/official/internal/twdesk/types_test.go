@@ -57,3 +57,12 @@ func TestTypeListMinimal(t *testing.T) {
 
 	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTypeList.String(), map[string]any{})
 }
+
+func TestTypeDelete(t *testing.T) {
+	mcpServer, cleanup := mcpServerMock(t, http.StatusNoContent, nil)
+	defer cleanup()
+
+	testutil.ExecuteToolRequest(t, mcpServer, twdesk.MethodTypeDelete.String(), map[string]any{
+		"id": float64(123),
+	})
+}
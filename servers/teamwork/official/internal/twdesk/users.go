@@ -43,10 +43,14 @@ func init() {
 	}
 }
 
+type userGetInput struct {
+	ID int `json:"id" jsonschema:"The ID of the user to retrieve."`
+}
+
 // UserGet finds a user in Teamwork Desk.  This will find it by ID
 func UserGet(client *deskclient.Client) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodUserGet),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get User",
@@ -55,73 +59,32 @@ func UserGet(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "Retrieve detailed information about a specific user in Teamwork Desk by their ID. " +
 				"Useful for auditing user records, troubleshooting ticket assignments, or " +
 				"integrating Desk user data into automation workflows.",
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the user to retrieve.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: userGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
-			user, err := client.Users.Get(ctx, arguments.GetInt("id", 0))
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userGetInput) (*mcp.CallToolResult, error) {
+			user, err := client.Users.Get(ctx, input.ID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get user: %w", err)
 			}
 			return helpers.NewToolResultJSON(user)
 		},
-	}
+	)
+}
+
+type userListInput struct {
+	paginationInput
+
+	FirstName  []string `json:"firstName,omitempty" jsonschema:"The first names of the users to filter by."`
+	LastName   []string `json:"lastName,omitempty" jsonschema:"The last names of the users to filter by."`
+	Email      []string `json:"email,omitempty" jsonschema:"The email addresses of the users to filter by."`
+	InboxIDs   []int    `json:"inboxIDs,omitempty" jsonschema:"The IDs of the inboxes to filter by."`
+	IsPartTime bool     `json:"isPartTime,omitempty" jsonschema:"Whether to include part-time users in the results."`
 }
 
 // UserList returns a list of users that apply to the filters in Teamwork Desk
 func UserList(client *deskclient.Client) toolsets.ToolWrapper {
-	properties := map[string]*jsonschema.Schema{
-		"firstName": {
-			Type:        "array",
-			Description: "The first names of the users to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"lastName": {
-			Type:        "array",
-			Description: "The last names of the users to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"email": {
-			Type:        "array",
-			Description: "The email addresses of the users to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "string",
-			},
-		},
-		"inboxIDs": {
-			Type:        "array",
-			Description: "The IDs of the inboxes to filter by.",
-			Items: &jsonschema.Schema{
-				Type: "integer",
-			},
-		},
-		"isPartTime": {
-			Type:        "boolean",
-			Description: "Whether to include part-time users in the results.",
-		},
-	}
-	properties = paginationOptions(properties)
-
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name: string(MethodUserList),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Users",
@@ -130,46 +93,31 @@ func UserList(client *deskclient.Client) toolsets.ToolWrapper {
 			Description: "List all users in Teamwork Desk, with optional filters for name, email, inbox, and part-time status. " +
 				"Enables users to audit, analyze, or synchronize user configurations for support management, " +
 				"reporting, or integration scenarios.",
-			InputSchema: &jsonschema.Schema{
-				Type:       "object",
-				Properties: properties,
-			},
 			OutputSchema: userListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			arguments, err := helpers.NewToolArguments(request)
-			if err != nil {
-				return helpers.NewToolResultTextError(err.Error()), nil
-			}
-
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userListInput) (*mcp.CallToolResult, error) {
 			// Apply filters to the user list
-			firstNames := arguments.GetStringSlice("firstName", []string{})
-			lastNames := arguments.GetStringSlice("lastName", []string{})
-			emails := arguments.GetStringSlice("email", []string{})
-			inboxIDs := arguments.GetIntSlice("inboxIDs", []int{})
-
 			filter := deskclient.NewFilter()
-			if len(firstNames) > 0 {
-				filter = filter.In("firstName", helpers.SliceToAny(firstNames))
+			if len(input.FirstName) > 0 {
+				filter = filter.In("firstName", helpers.SliceToAny(input.FirstName))
 			}
-			if len(lastNames) > 0 {
-				filter = filter.In("lastName", helpers.SliceToAny(lastNames))
+			if len(input.LastName) > 0 {
+				filter = filter.In("lastName", helpers.SliceToAny(input.LastName))
 			}
-			if len(emails) > 0 {
-				filter = filter.In("email", helpers.SliceToAny(emails))
+			if len(input.Email) > 0 {
+				filter = filter.In("email", helpers.SliceToAny(input.Email))
 			}
-			if len(inboxIDs) > 0 {
-				filter = filter.In("inboxes.id", helpers.SliceToAny(inboxIDs))
+			if len(input.InboxIDs) > 0 {
+				filter = filter.In("inboxes.id", helpers.SliceToAny(input.InboxIDs))
 			}
 
-			isPartTime := arguments.GetBool("isPartTime", false)
-			if isPartTime {
+			if input.IsPartTime {
 				filter = filter.Eq("isPartTime", true)
 			}
 
 			params := url.Values{}
 			params.Set("filter", filter.Build())
-			setPagination(&params, arguments)
+			setPagination(&params, input.paginationInput)
 
 			users, err := client.Users.List(ctx, params)
 			if err != nil {
@@ -177,5 +125,5 @@ func UserList(client *deskclient.Client) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(users)
 		},
-	}
+	)
 }
@@ -2,8 +2,8 @@ package twprojects
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -47,89 +47,34 @@ func init() {
 	}
 }
 
+//nolint:lll
+type activityListInput struct {
+	paginationInput
+
+	StartDate    time.Time              `json:"start_date,omitempty" jsonschema:"Start date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	EndDate      time.Time              `json:"end_date,omitempty" jsonschema:"End date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	LogItemTypes []projects.LogItemType `json:"log_item_types,omitempty" jsonschema:"Filter activities by item types."`
+}
+
 // ActivityList lists activities in Teamwork.com.
 func ActivityList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodActivityList),
 			Description: "List activities in Teamwork.com. " + activityDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Activities",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"start_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "Start date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "End date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"log_item_types": {
-						Type:        "array",
-						Description: "Filter activities by item types.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-							Enum: []any{
-								"message",
-								"comment",
-								"task",
-								"tasklist",
-								"taskgroup",
-								"milestone",
-								"file",
-								"form",
-								"notebook",
-								"timelog",
-								"task_comment",
-								"notebook_comment",
-								"file_comment",
-								"link_comment",
-								"milestone_comment",
-								"project",
-								"link",
-								"billingInvoice",
-								"risk",
-								"projectUpdate",
-								"reacted",
-								"budget",
-							},
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: activityListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input activityListInput) (*mcp.CallToolResult, error) {
 			var activityListRequest projects.ActivityListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalTimeParam(&activityListRequest.Filters.StartDate, "start_date"),
-				helpers.OptionalTimeParam(&activityListRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalListParam(&activityListRequest.Filters.LogItemTypes, "log_item_types"),
-				helpers.OptionalNumericParam(&activityListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&activityListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			activityListRequest.Filters.StartDate = input.StartDate
+			activityListRequest.Filters.EndDate = input.EndDate
+			activityListRequest.Filters.LogItemTypes = input.LogItemTypes
+			activityListRequest.Filters.Page = input.Page
+			activityListRequest.Filters.PageSize = input.PageSize
 
 			activityList, err := projects.ActivityList(ctx, engine, activityListRequest)
 			if err != nil {
@@ -137,97 +82,63 @@ func ActivityList(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(activityList)
 		},
-	}
+		toolsets.WithInputSchemaEnum("log_item_types",
+			"message",
+			"comment",
+			"task",
+			"tasklist",
+			"taskgroup",
+			"milestone",
+			"file",
+			"form",
+			"notebook",
+			"timelog",
+			"task_comment",
+			"notebook_comment",
+			"file_comment",
+			"link_comment",
+			"milestone_comment",
+			"project",
+			"link",
+			"billingInvoice",
+			"risk",
+			"projectUpdate",
+			"reacted",
+			"budget",
+		),
+	)
+}
+
+//nolint:lll
+type activityListByProjectInput struct {
+	paginationInput
+
+	ProjectID    int64                  `json:"project_id,omitempty" jsonschema:"The ID of the project to retrieve activities from."`
+	StartDate    time.Time              `json:"start_date,omitempty" jsonschema:"Start date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	EndDate      time.Time              `json:"end_date,omitempty" jsonschema:"End date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	LogItemTypes []projects.LogItemType `json:"log_item_types,omitempty" jsonschema:"Filter activities by item types."`
 }
 
 // ActivityListByProject lists activities by project in Teamwork.com.
 func ActivityListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodActivityListByProject),
 			Description: "List activities in Teamwork.com by project. " + activityDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Activities by Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to retrieve activities from.",
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "Start date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "End date to filter activities. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"log_item_types": {
-						Type:        "array",
-						Description: "Filter activities by item types.",
-						Items: &jsonschema.Schema{
-							Type: "string",
-							Enum: []any{
-								"message",
-								"comment",
-								"task",
-								"tasklist",
-								"taskgroup",
-								"milestone",
-								"file",
-								"form",
-								"notebook",
-								"timelog",
-								"task_comment",
-								"notebook_comment",
-								"file_comment",
-								"link_comment",
-								"milestone_comment",
-								"project",
-								"link",
-								"billingInvoice",
-								"risk",
-								"projectUpdate",
-								"reacted",
-								"budget",
-							},
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: activityListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input activityListByProjectInput) (*mcp.CallToolResult, error) {
 			var activityListRequest projects.ActivityListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&activityListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalTimeParam(&activityListRequest.Filters.StartDate, "start_date"),
-				helpers.OptionalTimeParam(&activityListRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalListParam(&activityListRequest.Filters.LogItemTypes, "log_item_types"),
-				helpers.OptionalNumericParam(&activityListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&activityListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			activityListRequest.Path.ProjectID = input.ProjectID
+			activityListRequest.Filters.StartDate = input.StartDate
+			activityListRequest.Filters.EndDate = input.EndDate
+			activityListRequest.Filters.LogItemTypes = input.LogItemTypes
+			activityListRequest.Filters.Page = input.Page
+			activityListRequest.Filters.PageSize = input.PageSize
 
 			activityList, err := projects.ActivityList(ctx, engine, activityListRequest)
 			if err != nil {
@@ -235,5 +146,29 @@ func ActivityListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(activityList)
 		},
-	}
+		toolsets.WithInputSchemaEnum("log_item_types",
+			"message",
+			"comment",
+			"task",
+			"tasklist",
+			"taskgroup",
+			"milestone",
+			"file",
+			"form",
+			"notebook",
+			"timelog",
+			"task_comment",
+			"notebook_comment",
+			"file_comment",
+			"link_comment",
+			"milestone_comment",
+			"project",
+			"link",
+			"billingInvoice",
+			"risk",
+			"projectUpdate",
+			"reacted",
+			"budget",
+		),
+	)
 }
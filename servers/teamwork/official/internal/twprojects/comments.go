@@ -69,101 +69,44 @@ func init() {
 	}
 }
 
+type commentCreateObjectInput struct {
+	Type string `json:"type" jsonschema:"The type of object to create the comment for."`
+	ID   int64  `json:"id" jsonschema:"The ID of the object to create the comment for."`
+}
+
+//nolint:lll
+type commentCreateInput struct {
+	Object      commentCreateObjectInput `json:"object" jsonschema:"The object to create the comment for. It can be a tasks, milestones, files or notebooks."`
+	Body        string                   `json:"body" jsonschema:"The content of the comment. The content can be added as text or HTML."`
+	ContentType *string                  `json:"content_type,omitempty" jsonschema:"The content type of the comment. It can be either 'TEXT' or 'HTML'."`
+}
+
 // CommentCreate creates a comment in Teamwork.com.
 func CommentCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentCreate),
 			Description: "Create a new comment in Teamwork.com. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Comment",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"object": {
-						Type: "object",
-						Properties: map[string]*jsonschema.Schema{
-							"type": {
-								Type:        "string",
-								Description: "The type of object to create the comment for.",
-								Enum: []any{
-									"tasks",
-									"milestones",
-									"files",
-									"notebooks",
-								},
-							},
-							"id": {
-								Type:        "integer",
-								Description: "The ID of the object to create the comment for.",
-							},
-						},
-						Required:    []string{"type", "id"},
-						Description: "The object to create the comment for. It can be a tasks, milestones, files or notebooks.",
-					},
-					"body": {
-						Type:        "string",
-						Description: "The content of the comment. The content can be added as text or HTML.",
-					},
-					"content_type": {
-						Type:        "string",
-						Description: "The content type of the comment. It can be either 'TEXT' or 'HTML'.",
-						Enum: []any{
-							"TEXT",
-							"HTML",
-						},
-					},
-				},
-				Required: []string{"object", "body"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentCreateInput) (*mcp.CallToolResult, error) {
 			var commentCreateRequest projects.CommentCreateRequest
+			commentCreateRequest.Body = input.Body
+			commentCreateRequest.ContentType = input.ContentType
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&commentCreateRequest.Body, "body"),
-				helpers.OptionalPointerParam(&commentCreateRequest.ContentType, "content_type"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			var objectType string
-			var objectID int64
-			object, ok := arguments["object"]
-			if !ok {
-				return helpers.NewToolResultTextError("missing required parameter: object"), nil
-			}
-			objectMap, ok := object.(map[string]any)
-			if !ok {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid object: expected an object, got %T", object)), nil
-			} else if objectMap == nil {
-				return helpers.NewToolResultTextError("object cannot be nil"), nil
-			}
-			err = helpers.ParamGroup(objectMap,
-				helpers.RequiredParam(&objectType, "type"),
-				helpers.RequiredNumericParam(&objectID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid object: %s", err.Error())), nil
-			}
-
-			switch strings.ToLower(objectType) {
+			switch strings.ToLower(input.Object.Type) {
 			case "tasks":
-				commentCreateRequest.Path.TaskID = objectID
+				commentCreateRequest.Path.TaskID = input.Object.ID
 			case "milestones":
-				commentCreateRequest.Path.MilestoneID = objectID
+				commentCreateRequest.Path.MilestoneID = input.Object.ID
 			case "files":
-				commentCreateRequest.Path.FileVersionID = objectID
+				commentCreateRequest.Path.FileVersionID = input.Object.ID
 			case "notebooks":
-				commentCreateRequest.Path.NotebookID = objectID
+				commentCreateRequest.Path.NotebookID = input.Object.ID
 			default:
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid object type: %s", objectType)), nil
+				return helpers.NewToolResultTextError(fmt.Sprintf("invalid object type: %s", input.Object.Type)), nil
 			}
 
 			comment, err := projects.CommentCreate(ctx, engine, commentCreateRequest)
@@ -172,144 +115,90 @@ func CommentCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Comment created successfully with ID %d", comment.ID), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("object.type", "tasks", "milestones", "files", "notebooks"),
+		toolsets.WithInputSchemaEnum("content_type", "TEXT", "HTML"),
+	)
+}
+
+//nolint:lll
+type commentUpdateInput struct {
+	ID          int64   `json:"id" jsonschema:"The ID of the comment to update."`
+	Body        string  `json:"body" jsonschema:"The content of the comment. The content can be added as text or HTML."`
+	ContentType *string `json:"content_type,omitempty" jsonschema:"The content type of the comment. It can be either 'TEXT' or 'HTML'."`
 }
 
 // CommentUpdate updates a comment in Teamwork.com.
 func CommentUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentUpdate),
 			Description: "Update an existing comment in Teamwork.com. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Comment",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the comment to update.",
-					},
-					"body": {
-						Type:        "string",
-						Description: "The content of the comment. The content can be added as text or HTML.",
-					},
-					"content_type": {
-						Type:        "string",
-						Description: "The content type of the comment. It can be either 'TEXT' or 'HTML'.",
-						Enum: []any{
-							"TEXT",
-							"HTML",
-						},
-					},
-				},
-				Required: []string{"id", "body"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentUpdateInput) (*mcp.CallToolResult, error) {
 			var commentUpdateRequest projects.CommentUpdateRequest
+			commentUpdateRequest.Path.ID = input.ID
+			commentUpdateRequest.Body = input.Body
+			commentUpdateRequest.ContentType = input.ContentType
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentUpdateRequest.Path.ID, "id"),
-				helpers.RequiredParam(&commentUpdateRequest.Body, "body"),
-				helpers.OptionalPointerParam(&commentUpdateRequest.ContentType, "content_type"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.CommentUpdate(ctx, engine, commentUpdateRequest)
+			_, err := projects.CommentUpdate(ctx, engine, commentUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update comment")
 			}
 			return helpers.NewToolResultText("Comment updated successfully"), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("content_type", "TEXT", "HTML"),
+	)
+}
+
+type commentDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the comment to delete."`
 }
 
 // CommentDelete deletes a comment in Teamwork.com.
 func CommentDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentDelete),
 			Description: "Delete an existing comment in Teamwork.com. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Comment",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the comment to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentDeleteInput) (*mcp.CallToolResult, error) {
 			var commentDeleteRequest projects.CommentDeleteRequest
+			commentDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.CommentDelete(ctx, engine, commentDeleteRequest)
+			_, err := projects.CommentDelete(ctx, engine, commentDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete comment")
 			}
 			return helpers.NewToolResultText("Comment deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type commentGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the comment to get."`
 }
 
 // CommentGet retrieves a comment in Teamwork.com.
 func CommentGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentGet),
 			Description: "Get an existing comment in Teamwork.com. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Comment",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the comment to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: commentGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentGetInput) (*mcp.CallToolResult, error) {
 			var commentGetRequest projects.CommentGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentGetRequest.Path.ID = input.ID
 
 			comment, err := projects.CommentGet(ctx, engine, commentGetRequest)
 			if err != nil {
@@ -329,53 +218,32 @@ func CommentGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: comment,
 			}, nil
 		},
-	}
+	)
+}
+
+type commentListInput struct {
+	paginationInput
+
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter comments by name."`
 }
 
 // CommentList lists comments in Teamwork.com.
 func CommentList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentList),
 			Description: "List comments in Teamwork.com. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Comments",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter comments by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: commentListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentListInput) (*mcp.CallToolResult, error) {
 			var commentListRequest projects.CommentListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&commentListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentListRequest.Filters.SearchTerm = input.SearchTerm
+			commentListRequest.Filters.Page = input.Page
+			commentListRequest.Filters.PageSize = input.PageSize
 
 			commentList, err := projects.CommentList(ctx, engine, commentListRequest)
 			if err != nil {
@@ -395,60 +263,35 @@ func CommentList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: commentList,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type commentListByFileVersionInput struct {
+	paginationInput
+
+	FileVersionID int64  `json:"file_version_id" jsonschema:"The ID of the file version to retrieve comments for. Each file can have multiple versions, and comments can be associated with specific versions."`
+	SearchTerm    string `json:"search_term,omitempty" jsonschema:"A search term to filter comments by name."`
 }
 
 // CommentListByFileVersion lists comments by file version in Teamwork.com.
 func CommentListByFileVersion(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentListByFileVersion),
 			Description: "List comments in Teamwork.com by file version. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Comments by File Version",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"file_version_id": {
-						Type: "integer",
-						Description: "The ID of the file version to retrieve comments for. Each file can have multiple versions, " +
-							"and comments can be associated with specific versions.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter comments by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"file_version_id"},
-			},
 			OutputSchema: commentListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentListByFileVersionInput) (*mcp.CallToolResult, error) {
 			var commentListRequest projects.CommentListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentListRequest.Path.FileVersionID, "file_version_id"),
-				helpers.OptionalParam(&commentListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentListRequest.Path.FileVersionID = input.FileVersionID
+			commentListRequest.Filters.SearchTerm = input.SearchTerm
+			commentListRequest.Filters.Page = input.Page
+			commentListRequest.Filters.PageSize = input.PageSize
 
 			commentList, err := projects.CommentList(ctx, engine, commentListRequest)
 			if err != nil {
@@ -468,59 +311,34 @@ func CommentListByFileVersion(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: commentList,
 			}, nil
 		},
-	}
+	)
+}
+
+type commentListByMilestoneInput struct {
+	paginationInput
+
+	MilestoneID int64  `json:"milestone_id" jsonschema:"The ID of the milestone to retrieve comments for."`
+	SearchTerm  string `json:"search_term,omitempty" jsonschema:"A search term to filter comments by name."`
 }
 
 // CommentListByMilestone lists comments by milestone in Teamwork.com.
 func CommentListByMilestone(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentListByMilestone),
 			Description: "List comments in Teamwork.com by milestone. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Comments by Milestone",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"milestone_id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to retrieve comments for.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter comments by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"milestone_id"},
-			},
 			OutputSchema: commentListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentListByMilestoneInput) (*mcp.CallToolResult, error) {
 			var commentListRequest projects.CommentListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentListRequest.Path.MilestoneID, "milestone_id"),
-				helpers.OptionalParam(&commentListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentListRequest.Path.MilestoneID = input.MilestoneID
+			commentListRequest.Filters.SearchTerm = input.SearchTerm
+			commentListRequest.Filters.Page = input.Page
+			commentListRequest.Filters.PageSize = input.PageSize
 
 			commentList, err := projects.CommentList(ctx, engine, commentListRequest)
 			if err != nil {
@@ -540,59 +358,34 @@ func CommentListByMilestone(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: commentList,
 			}, nil
 		},
-	}
+	)
+}
+
+type commentListByNotebookInput struct {
+	paginationInput
+
+	NotebookID int64  `json:"notebook_id" jsonschema:"The ID of the notebook to retrieve comments for."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter comments by name."`
 }
 
 // CommentListByNotebook lists comments by notebook in Teamwork.com.
 func CommentListByNotebook(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentListByNotebook),
 			Description: "List comments in Teamwork.com by notebook. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Comments by Notebook",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"notebook_id": {
-						Type:        "integer",
-						Description: "The ID of the notebook to retrieve comments for.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter comments by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"notebook_id"},
-			},
 			OutputSchema: commentListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentListByNotebookInput) (*mcp.CallToolResult, error) {
 			var commentListRequest projects.CommentListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentListRequest.Path.NotebookID, "notebook_id"),
-				helpers.OptionalParam(&commentListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentListRequest.Path.NotebookID = input.NotebookID
+			commentListRequest.Filters.SearchTerm = input.SearchTerm
+			commentListRequest.Filters.Page = input.Page
+			commentListRequest.Filters.PageSize = input.PageSize
 
 			commentList, err := projects.CommentList(ctx, engine, commentListRequest)
 			if err != nil {
@@ -612,59 +405,34 @@ func CommentListByNotebook(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: commentList,
 			}, nil
 		},
-	}
+	)
+}
+
+type commentListByTaskInput struct {
+	paginationInput
+
+	TaskID     int64  `json:"task_id" jsonschema:"The ID of the task to retrieve comments for."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter comments by name."`
 }
 
 // CommentListByTask lists comments by task in Teamwork.com.
 func CommentListByTask(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCommentListByTask),
 			Description: "List comments in Teamwork.com by task. " + commentDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Comments by Task",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"task_id": {
-						Type:        "integer",
-						Description: "The ID of the task to retrieve comments for.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter comments by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"task_id"},
-			},
 			OutputSchema: commentListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input commentListByTaskInput) (*mcp.CallToolResult, error) {
 			var commentListRequest projects.CommentListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&commentListRequest.Path.TaskID, "task_id"),
-				helpers.OptionalParam(&commentListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&commentListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			commentListRequest.Path.TaskID = input.TaskID
+			commentListRequest.Filters.SearchTerm = input.SearchTerm
+			commentListRequest.Filters.Page = input.Page
+			commentListRequest.Filters.PageSize = input.PageSize
 
 			commentList, err := projects.CommentList(ctx, engine, commentListRequest)
 			if err != nil {
@@ -684,7 +452,7 @@ func CommentListByTask(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: commentList,
 			}, nil
 		},
-	}
+	)
 }
 
 func commentPathBuilder(object map[string]any) string {
@@ -59,122 +59,56 @@ func init() {
 	}
 }
 
+//nolint:lll
+type companyCreateInput struct {
+	Name        string  `json:"name" jsonschema:"The name of the company."`
+	AddressOne  *string `json:"address_one,omitempty" jsonschema:"The first line of the address of the company."`
+	AddressTwo  *string `json:"address_two,omitempty" jsonschema:"The second line of the address of the company."`
+	City        *string `json:"city,omitempty" jsonschema:"The city of the company."`
+	State       *string `json:"state,omitempty" jsonschema:"The state of the company."`
+	Zip         *string `json:"zip,omitempty" jsonschema:"The ZIP or postal code of the company."`
+	CountryCode *string `json:"country_code,omitempty" jsonschema:"The country code of the company, e.g., 'US' for the United States."`
+	Phone       *string `json:"phone,omitempty" jsonschema:"The phone number of the company."`
+	Fax         *string `json:"fax,omitempty" jsonschema:"The fax number of the company."`
+	EmailOne    *string `json:"email_one,omitempty" jsonschema:"The primary email address of the company."`
+	EmailTwo    *string `json:"email_two,omitempty" jsonschema:"The secondary email address of the company."`
+	EmailThree  *string `json:"email_three,omitempty" jsonschema:"The tertiary email address of the company."`
+	Website     *string `json:"website,omitempty" jsonschema:"The website of the company."`
+	Profile     *string `json:"profile,omitempty" jsonschema:"A profile description for the company."`
+	ManagerID   *int64  `json:"manager_id,omitempty" jsonschema:"The ID of the user who manages the company."`
+	IndustryID  *int64  `json:"industry_id,omitempty" jsonschema:"The ID of the industry the company belongs to."`
+	TagIDs      []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the company."`
+}
+
 // CompanyCreate creates a company in Teamwork.com.
 func CompanyCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCompanyCreate),
 			Description: "Create a new company in Teamwork.com. " + companyDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Company",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the company.",
-					},
-					"address_one": {
-						Type:        "string",
-						Description: "The first line of the address of the company.",
-					},
-					"address_two": {
-						Type:        "string",
-						Description: "The second line of the address of the company.",
-					},
-					"city": {
-						Type:        "string",
-						Description: "The city of the company.",
-					},
-					"state": {
-						Type:        "string",
-						Description: "The state of the company.",
-					},
-					"zip": {
-						Type:        "string",
-						Description: "The ZIP or postal code of the company.",
-					},
-					"country_code": {
-						Type:        "string",
-						Description: "The country code of the company, e.g., 'US' for the United States.",
-					},
-					"phone": {
-						Type:        "string",
-						Description: "The phone number of the company.",
-					},
-					"fax": {
-						Type:        "string",
-						Description: "The fax number of the company.",
-					},
-					"email_one": {
-						Type:        "string",
-						Description: "The primary email address of the company.",
-					},
-					"email_two": {
-						Type:        "string",
-						Description: "The secondary email address of the company.",
-					},
-					"email_three": {
-						Type:        "string",
-						Description: "The tertiary email address of the company.",
-					},
-					"website": {
-						Type:        "string",
-						Description: "The website of the company.",
-					},
-					"profile": {
-						Type:        "string",
-						Description: "A profile description for the company.",
-					},
-					"manager_id": {
-						Type:        "integer",
-						Description: "The ID of the user who manages the company.",
-					},
-					"industry_id": {
-						Type:        "integer",
-						Description: "The ID of the industry the company belongs to.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the company.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyCreateInput) (*mcp.CallToolResult, error) {
 			var companyCreateRequest projects.CompanyCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&companyCreateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&companyCreateRequest.AddressOne, "address_one"),
-				helpers.OptionalPointerParam(&companyCreateRequest.AddressTwo, "address_two"),
-				helpers.OptionalPointerParam(&companyCreateRequest.City, "city"),
-				helpers.OptionalPointerParam(&companyCreateRequest.State, "state"),
-				helpers.OptionalPointerParam(&companyCreateRequest.Zip, "zip"),
-				helpers.OptionalPointerParam(&companyCreateRequest.CountryCode, "country_code"),
-				helpers.OptionalPointerParam(&companyCreateRequest.Phone, "phone"),
-				helpers.OptionalPointerParam(&companyCreateRequest.Fax, "fax"),
-				helpers.OptionalPointerParam(&companyCreateRequest.EmailOne, "email_one"),
-				helpers.OptionalPointerParam(&companyCreateRequest.EmailTwo, "email_two"),
-				helpers.OptionalPointerParam(&companyCreateRequest.EmailThree, "email_three"),
-				helpers.OptionalPointerParam(&companyCreateRequest.Website, "website"),
-				helpers.OptionalPointerParam(&companyCreateRequest.Profile, "profile"),
-				helpers.OptionalNumericPointerParam(&companyCreateRequest.ManagerID, "manager_id"),
-				helpers.OptionalNumericPointerParam(&companyCreateRequest.IndustryID, "industry_id"),
-				helpers.OptionalNumericListParam(&companyCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			companyCreateRequest.Name = input.Name
+			companyCreateRequest.AddressOne = input.AddressOne
+			companyCreateRequest.AddressTwo = input.AddressTwo
+			companyCreateRequest.City = input.City
+			companyCreateRequest.State = input.State
+			companyCreateRequest.Zip = input.Zip
+			companyCreateRequest.CountryCode = input.CountryCode
+			companyCreateRequest.Phone = input.Phone
+			companyCreateRequest.Fax = input.Fax
+			companyCreateRequest.EmailOne = input.EmailOne
+			companyCreateRequest.EmailTwo = input.EmailTwo
+			companyCreateRequest.EmailThree = input.EmailThree
+			companyCreateRequest.Website = input.Website
+			companyCreateRequest.Profile = input.Profile
+			companyCreateRequest.ManagerID = input.ManagerID
+			companyCreateRequest.IndustryID = input.IndustryID
+			companyCreateRequest.TagIDs = input.TagIDs
 
 			companyResponse, err := projects.CompanyCreate(ctx, engine, companyCreateRequest)
 			if err != nil {
@@ -182,218 +116,117 @@ func CompanyCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Company created successfully with ID %d", companyResponse.Company.ID), nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type companyUpdateInput struct {
+	ID          int64   `json:"id" jsonschema:"The ID of the company to update."`
+	Name        *string `json:"name,omitempty" jsonschema:"The name of the company."`
+	AddressOne  *string `json:"address_one,omitempty" jsonschema:"The first line of the address of the company."`
+	AddressTwo  *string `json:"address_two,omitempty" jsonschema:"The second line of the address of the company."`
+	City        *string `json:"city,omitempty" jsonschema:"The city of the company."`
+	State       *string `json:"state,omitempty" jsonschema:"The state of the company."`
+	Zip         *string `json:"zip,omitempty" jsonschema:"The ZIP or postal code of the company."`
+	CountryCode *string `json:"country_code,omitempty" jsonschema:"The country code of the company, e.g., 'US' for the United States."`
+	Phone       *string `json:"phone,omitempty" jsonschema:"The phone number of the company."`
+	Fax         *string `json:"fax,omitempty" jsonschema:"The fax number of the company."`
+	EmailOne    *string `json:"email_one,omitempty" jsonschema:"The primary email address of the company."`
+	EmailTwo    *string `json:"email_two,omitempty" jsonschema:"The secondary email address of the company."`
+	EmailThree  *string `json:"email_three,omitempty" jsonschema:"The tertiary email address of the company."`
+	Website     *string `json:"website,omitempty" jsonschema:"The website of the company."`
+	Profile     *string `json:"profile,omitempty" jsonschema:"A profile description for the company."`
+	ManagerID   *int64  `json:"manager_id,omitempty" jsonschema:"The ID of the user who manages the company."`
+	IndustryID  *int64  `json:"industry_id,omitempty" jsonschema:"The ID of the industry the company belongs to."`
+	TagIDs      []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the company."`
 }
 
 // CompanyUpdate updates a company in Teamwork.com.
 func CompanyUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCompanyUpdate),
 			Description: "Update an existing company in Teamwork.com. " + companyDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Company",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the company to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the company.",
-					},
-					"address_one": {
-						Type:        "string",
-						Description: "The first line of the address of the company.",
-					},
-					"address_two": {
-						Type:        "string",
-						Description: "The second line of the address of the company.",
-					},
-					"city": {
-						Type:        "string",
-						Description: "The city of the company.",
-					},
-					"state": {
-						Type:        "string",
-						Description: "The state of the company.",
-					},
-					"zip": {
-						Type:        "string",
-						Description: "The ZIP or postal code of the company.",
-					},
-					"country_code": {
-						Type:        "string",
-						Description: "The country code of the company, e.g., 'US' for the United States.",
-					},
-					"phone": {
-						Type:        "string",
-						Description: "The phone number of the company.",
-					},
-					"fax": {
-						Type:        "string",
-						Description: "The fax number of the company.",
-					},
-					"email_one": {
-						Type:        "string",
-						Description: "The primary email address of the company.",
-					},
-					"email_two": {
-						Type:        "string",
-						Description: "The secondary email address of the company.",
-					},
-					"email_three": {
-						Type:        "string",
-						Description: "The tertiary email address of the company.",
-					},
-					"website": {
-						Type:        "string",
-						Description: "The website of the company.",
-					},
-					"profile": {
-						Type:        "string",
-						Description: "A profile description for the company.",
-					},
-					"manager_id": {
-						Type:        "integer",
-						Description: "The ID of the user who manages the company.",
-					},
-					"industry_id": {
-						Type:        "integer",
-						Description: "The ID of the industry the company belongs to.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the company.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyUpdateInput) (*mcp.CallToolResult, error) {
 			var companyUpdateRequest projects.CompanyUpdateRequest
+			companyUpdateRequest.Path.ID = input.ID
+			companyUpdateRequest.Name = input.Name
+			companyUpdateRequest.AddressOne = input.AddressOne
+			companyUpdateRequest.AddressTwo = input.AddressTwo
+			companyUpdateRequest.City = input.City
+			companyUpdateRequest.State = input.State
+			companyUpdateRequest.Zip = input.Zip
+			companyUpdateRequest.CountryCode = input.CountryCode
+			companyUpdateRequest.Phone = input.Phone
+			companyUpdateRequest.Fax = input.Fax
+			companyUpdateRequest.EmailOne = input.EmailOne
+			companyUpdateRequest.EmailTwo = input.EmailTwo
+			companyUpdateRequest.EmailThree = input.EmailThree
+			companyUpdateRequest.Website = input.Website
+			companyUpdateRequest.Profile = input.Profile
+			companyUpdateRequest.ManagerID = input.ManagerID
+			companyUpdateRequest.IndustryID = input.IndustryID
+			companyUpdateRequest.TagIDs = input.TagIDs
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&companyUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.AddressOne, "address_one"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.AddressTwo, "address_two"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.City, "city"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.State, "state"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Zip, "zip"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.CountryCode, "country_code"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Phone, "phone"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Fax, "fax"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.EmailOne, "email_one"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.EmailTwo, "email_two"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.EmailThree, "email_three"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Website, "website"),
-				helpers.OptionalPointerParam(&companyUpdateRequest.Profile, "profile"),
-				helpers.OptionalNumericPointerParam(&companyUpdateRequest.ManagerID, "manager_id"),
-				helpers.OptionalNumericPointerParam(&companyUpdateRequest.IndustryID, "industry_id"),
-				helpers.OptionalNumericListParam(&companyUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.CompanyUpdate(ctx, engine, companyUpdateRequest)
+			_, err := projects.CompanyUpdate(ctx, engine, companyUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update company")
 			}
 			return helpers.NewToolResultText("Company updated successfully"), nil
 		},
-	}
+	)
+}
+
+type companyDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the company to delete."`
 }
 
 // CompanyDelete deletes a company in Teamwork.com.
 func CompanyDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCompanyDelete),
 			Description: "Delete an existing company in Teamwork.com. " + companyDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Company",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the company to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyDeleteInput) (*mcp.CallToolResult, error) {
 			var companyDeleteRequest projects.CompanyDeleteRequest
+			companyDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&companyDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.CompanyDelete(ctx, engine, companyDeleteRequest)
+			_, err := projects.CompanyDelete(ctx, engine, companyDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete company")
 			}
 			return helpers.NewToolResultText("Company deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type companyGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the company to get."`
 }
 
 // CompanyGet retrieves a company in Teamwork.com.
 func CompanyGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCompanyGet),
 			Description: "Get an existing company in Teamwork.com. " + companyDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Company",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the company to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: companyGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyGetInput) (*mcp.CallToolResult, error) {
 			var companyGetRequest projects.CompanyGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&companyGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			companyGetRequest.Path.ID = input.ID
 
 			company, err := projects.CompanyGet(ctx, engine, companyGetRequest)
 			if err != nil {
@@ -415,69 +248,37 @@ func CompanyGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: company,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type companyListInput struct {
+	paginationInput
+
+	SearchTerm   string  `json:"search_term,omitempty" jsonschema:"A search term to filter companies by name. Each word from the search term is used to match against the company name."`
+	TagIDs       []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter companies by tags"`
+	MatchAllTags *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match companies that have all the specified tags. If false, the search will match companies that have any of the specified tags. Defaults to false."`
 }
 
 // CompanyList lists companies in Teamwork.com.
 func CompanyList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodCompanyList),
 			Description: "List companies in Teamwork.com. " + companyDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Companies",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter companies by name. " +
-							"Each word from the search term is used to match against the company name.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter companies by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match companies that have all the specified tags. " +
-							"If false, the search will match companies that have any of the specified tags. " +
-							"Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: companyListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input companyListInput) (*mcp.CallToolResult, error) {
 			var companyListRequest projects.CompanyListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&companyListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&companyListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&companyListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&companyListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&companyListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			companyListRequest.Filters.SearchTerm = input.SearchTerm
+			companyListRequest.Filters.TagIDs = input.TagIDs
+			companyListRequest.Filters.MatchAllTags = input.MatchAllTags
+			companyListRequest.Filters.Page = input.Page
+			companyListRequest.Filters.PageSize = input.PageSize
 
 			companyList, err := projects.CompanyList(ctx, engine, companyListRequest)
 			if err != nil {
@@ -499,5 +300,5 @@ func CompanyList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: companyList,
 			}, nil
 		},
-	}
+	)
 }
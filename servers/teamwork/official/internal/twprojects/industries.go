@@ -46,18 +46,17 @@ func init() {
 
 // IndustryList lists projects in Teamwork.com.
 func IndustryList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodIndustryList),
 			Description: "List industries in Teamwork.com. " + industryDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Industries",
 				ReadOnlyHint: true,
 			},
-			InputSchema:  &jsonschema.Schema{Type: "object"},
 			OutputSchema: industryListOutputSchema,
 		},
-		Handler: func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, error) {
 			var industryListRequest projects.IndustryListRequest
 
 			industryList, err := projects.IndustryList(ctx, engine, industryListRequest)
@@ -66,5 +65,5 @@ func IndustryList(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(industryList)
 		},
-	}
+	)
 }
@@ -60,128 +60,36 @@ func init() {
 	}
 }
 
+//nolint:lll
+type milestoneCreateInput struct {
+	Name        string              `json:"name" jsonschema:"The name of the milestone."`
+	ProjectID   int64               `json:"project_id" jsonschema:"The ID of the project to create the milestone in."`
+	Description *string             `json:"description,omitempty" jsonschema:"A description of the milestone."`
+	DueDate     projects.LegacyDate `json:"due_date" jsonschema:"The due date of the milestone in the format YYYYMMDD. This date will be used in all tasks without a due date related to this milestone."`
+	Assignees   userGroupsInput     `json:"assignees" jsonschema:"An object containing assignees for the milestone. MUST contain at least one of: user_ids, company_ids or team_ids with non-empty arrays."`
+	TasklistIDs []int64             `json:"tasklist_ids,omitempty" jsonschema:"A list of tasklist IDs to associate with the milestone."`
+	TagIDs      []int64             `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the milestone."`
+}
+
 // MilestoneCreate creates a milestone in Teamwork.com.
 func MilestoneCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneCreate),
 			Description: "Create a new milestone in Teamwork.com. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Milestone",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the milestone.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to create the milestone in.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the milestone.",
-					},
-					"due_date": {
-						Type: "string",
-						Description: "The due date of the milestone in the format YYYYMMDD. This date will be used in all tasks " +
-							"without a due date related to this milestone.",
-					},
-					"assignees": {
-						Type: "object",
-						Description: "An object containing assignees for the milestone. " +
-							"MUST contain at least one of: user_ids, company_ids or team_ids with non-empty arrays.",
-						Properties: map[string]*jsonschema.Schema{
-							"user_ids": {
-								Type:        "array",
-								Description: "List of user IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-							"company_ids": {
-								Type:        "array",
-								Description: "List of company IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-							"team_ids": {
-								Type:        "array",
-								Description: "List of team IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-						},
-						MinProperties: twapi.Ptr(1),
-						MaxProperties: twapi.Ptr(3),
-						AnyOf: []*jsonschema.Schema{
-							{Required: []string{"user_ids"}},
-							{Required: []string{"company_ids"}},
-							{Required: []string{"team_ids"}},
-						},
-					},
-					"tasklist_ids": {
-						Type:        "array",
-						Description: "A list of tasklist IDs to associate with the milestone.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the milestone.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"name", "project_id", "due_date", "assignees"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneCreateInput) (*mcp.CallToolResult, error) {
 			var milestoneCreateRequest projects.MilestoneCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&milestoneCreateRequest.Path.ProjectID, "project_id"),
-				helpers.RequiredParam(&milestoneCreateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&milestoneCreateRequest.Description, "description"),
-				helpers.RequiredLegacyDateParam(&milestoneCreateRequest.DueAt, "due_date"),
-				helpers.OptionalNumericListParam(&milestoneCreateRequest.TasklistIDs, "tasklist_ids"),
-				helpers.OptionalNumericListParam(&milestoneCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			assignees, ok := arguments["assignees"]
-			if !ok {
-				return helpers.NewToolResultTextError("missing required parameter: assignees"), nil
-			}
-			assigneesMap, ok := assignees.(map[string]any)
-			if !ok {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid assignees: expected an object, got %T", assignees)), nil
-			} else if assigneesMap == nil {
-				return helpers.NewToolResultTextError("assignees cannot be null"), nil
-			}
-			err = helpers.ParamGroup(assigneesMap,
-				helpers.OptionalNumericListParam(&milestoneCreateRequest.Assignees.UserIDs, "user_ids"),
-				helpers.OptionalNumericListParam(&milestoneCreateRequest.Assignees.CompanyIDs, "company_ids"),
-				helpers.OptionalNumericListParam(&milestoneCreateRequest.Assignees.TeamIDs, "team_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid assignees: %s", err.Error())), nil
-			}
+			milestoneCreateRequest.Path.ProjectID = input.ProjectID
+			milestoneCreateRequest.Name = input.Name
+			milestoneCreateRequest.Description = input.Description
+			milestoneCreateRequest.DueAt = input.DueDate
+			milestoneCreateRequest.TasklistIDs = input.TasklistIDs
+			milestoneCreateRequest.TagIDs = input.TagIDs
+			milestoneCreateRequest.Assignees = input.Assignees.legacyUserGroups()
 			if milestoneCreateRequest.Assignees.IsEmpty() {
 				return helpers.NewToolResultTextError("at least one assignee must be provided"), nil
 			}
@@ -192,216 +100,100 @@ func MilestoneCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Milestone created successfully with ID %d", milestone.ID), nil
 		},
-	}
+		withUserGroupsSchema("assignees", "milestone"),
+	)
+}
+
+//nolint:lll
+type milestoneUpdateInput struct {
+	ID          int64                `json:"id" jsonschema:"The ID of the milestone to update."`
+	Name        *string              `json:"name,omitempty" jsonschema:"The name of the milestone."`
+	Description *string              `json:"description,omitempty" jsonschema:"A description of the milestone."`
+	DueDate     *projects.LegacyDate `json:"due_date,omitempty" jsonschema:"The due date of the milestone in the format YYYYMMDD. This date will be used in all tasks without a due date related to this milestone."`
+	Assignees   *userGroupsInput     `json:"assignees,omitempty" jsonschema:"An object containing assignees for the milestone."`
+	TasklistIDs []int64              `json:"tasklist_ids,omitempty" jsonschema:"A list of tasklist IDs to associate with the milestone."`
+	TagIDs      []int64              `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the milestone."`
 }
 
 // MilestoneUpdate updates a milestone in Teamwork.com.
 func MilestoneUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneUpdate),
 			Description: "Update an existing milestone in Teamwork.com. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Milestone",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the milestone.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the milestone.",
-					},
-					"due_date": {
-						Type: "string",
-						Description: "The due date of the milestone in the format YYYYMMDD. This date will be used in all tasks " +
-							"without a due date related to this milestone.",
-					},
-					"assignees": {
-						Type:        "object",
-						Description: "An object containing assignees for the milestone.",
-						Properties: map[string]*jsonschema.Schema{
-							"user_ids": {
-								Type:        "array",
-								Description: "List of user IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-							"company_ids": {
-								Type:        "array",
-								Description: "List of company IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-							"team_ids": {
-								Type:        "array",
-								Description: "List of team IDs assigned to the milestone.",
-								Items: &jsonschema.Schema{
-									Type: "integer",
-								},
-								MinItems: twapi.Ptr(1),
-							},
-						},
-						MinProperties: twapi.Ptr(1),
-						MaxProperties: twapi.Ptr(3),
-						AnyOf: []*jsonschema.Schema{
-							{Required: []string{"user_ids"}},
-							{Required: []string{"company_ids"}},
-							{Required: []string{"team_ids"}},
-						},
-					},
-					"tasklist_ids": {
-						Type:        "array",
-						Description: "A list of tasklist IDs to associate with the milestone.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the milestone.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneUpdateInput) (*mcp.CallToolResult, error) {
 			var milestoneUpdateRequest projects.MilestoneUpdateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&milestoneUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&milestoneUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&milestoneUpdateRequest.Description, "description"),
-				helpers.OptionalLegacyDatePointerParam(&milestoneUpdateRequest.DueAt, "due_date"),
-				helpers.OptionalNumericListParam(&milestoneUpdateRequest.TasklistIDs, "tasklist_ids"),
-				helpers.OptionalNumericListParam(&milestoneUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			if assignees, ok := arguments["assignees"]; ok {
-				assigneesMap, ok := assignees.(map[string]any)
-				if !ok {
-					return helpers.NewToolResultTextError("invalid assignees"), nil
-				} else if assigneesMap != nil {
-					milestoneUpdateRequest.Assignees = new(projects.LegacyUserGroups)
-					err = helpers.ParamGroup(assigneesMap,
-						helpers.OptionalNumericListParam(&milestoneUpdateRequest.Assignees.UserIDs, "user_ids"),
-						helpers.OptionalNumericListParam(&milestoneUpdateRequest.Assignees.CompanyIDs, "company_ids"),
-						helpers.OptionalNumericListParam(&milestoneUpdateRequest.Assignees.TeamIDs, "team_ids"),
-					)
-					if err != nil {
-						return helpers.NewToolResultTextError(fmt.Sprintf("invalid assignees: %s", err.Error())), nil
-					}
-				}
+			milestoneUpdateRequest.Path.ID = input.ID
+			milestoneUpdateRequest.Name = input.Name
+			milestoneUpdateRequest.Description = input.Description
+			milestoneUpdateRequest.DueAt = input.DueDate
+			milestoneUpdateRequest.TasklistIDs = input.TasklistIDs
+			milestoneUpdateRequest.TagIDs = input.TagIDs
+			if input.Assignees != nil {
+				assignees := input.Assignees.legacyUserGroups()
+				milestoneUpdateRequest.Assignees = &assignees
 			}
 
-			_, err = projects.MilestoneUpdate(ctx, engine, milestoneUpdateRequest)
+			_, err := projects.MilestoneUpdate(ctx, engine, milestoneUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update milestone")
 			}
 			return helpers.NewToolResultText("Milestone updated successfully"), nil
 		},
-	}
+		withUserGroupsSchema("assignees", "milestone"),
+	)
+}
+
+type milestoneDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the milestone to delete."`
 }
 
 // MilestoneDelete deletes a milestone in Teamwork.com.
 func MilestoneDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneDelete),
 			Description: "Delete an existing milestone in Teamwork.com. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Milestone",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneDeleteInput) (*mcp.CallToolResult, error) {
 			var milestoneDeleteRequest projects.MilestoneDeleteRequest
+			milestoneDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&milestoneDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.MilestoneDelete(ctx, engine, milestoneDeleteRequest)
+			_, err := projects.MilestoneDelete(ctx, engine, milestoneDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete milestone")
 			}
 			return helpers.NewToolResultText("Milestone deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type milestoneGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the milestone to get."`
 }
 
 // MilestoneGet retrieves a milestone in Teamwork.com.
 func MilestoneGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneGet),
 			Description: "Get an existing milestone in Teamwork.com. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Milestone",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: milestoneGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneGetInput) (*mcp.CallToolResult, error) {
 			var milestoneGetRequest projects.MilestoneGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&milestoneGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			milestoneGetRequest.Path.ID = input.ID
 
 			milestone, err := projects.MilestoneGet(ctx, engine, milestoneGetRequest)
 			if err != nil {
@@ -423,71 +215,37 @@ func MilestoneGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: milestone,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type milestoneListInput struct {
+	paginationInput
+
+	SearchTerm   string  `json:"search_term,omitempty" jsonschema:"A search term to filter milestones by name. Each word from the search term is used to match against the milestone name and description. The milestone will be selected if each word of the term matches the milestone name or description, not requiring that the word matches are in the same field."`
+	TagIDs       []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter milestones by tags"`
+	MatchAllTags *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match milestones that have all the specified tags. If false, the search will match milestones that have any of the specified tags. Defaults to false."`
 }
 
 // MilestoneList lists milestones in Teamwork.com.
 func MilestoneList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneList),
 			Description: "List milestones in Teamwork.com. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Milestones",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter milestones by name. " +
-							"Each word from the search term is used to match against the milestone name and description. " +
-							"The milestone will be selected if each word of the term matches the milestone name or description, not " +
-							"requiring that the word matches are in the same field.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter milestones by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match milestones that have all the specified tags. " +
-							"If false, the search will match milestones that have any of the specified tags. " +
-							"Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: milestoneListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneListInput) (*mcp.CallToolResult, error) {
 			var milestoneListRequest projects.MilestoneListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&milestoneListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&milestoneListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&milestoneListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&milestoneListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&milestoneListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			milestoneListRequest.Filters.SearchTerm = input.SearchTerm
+			milestoneListRequest.Filters.TagIDs = input.TagIDs
+			milestoneListRequest.Filters.MatchAllTags = input.MatchAllTags
+			milestoneListRequest.Filters.Page = input.Page
+			milestoneListRequest.Filters.PageSize = input.PageSize
 
 			milestoneList, err := projects.MilestoneList(ctx, engine, milestoneListRequest)
 			if err != nil {
@@ -509,77 +267,39 @@ func MilestoneList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: milestoneList,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type milestoneListByProjectInput struct {
+	paginationInput
+
+	ProjectID    int64   `json:"project_id" jsonschema:"The ID of the project from which to retrieve milestones."`
+	SearchTerm   string  `json:"search_term,omitempty" jsonschema:"A search term to filter milestones by name. Each word from the search term is used to match against the milestone name and description. The milestone will be selected if each word of the term matches the milestone name or description, not requiring that the word matches are in the same field."`
+	TagIDs       []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter milestones by tags"`
+	MatchAllTags *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match milestones that have all the specified tags. If false, the search will match milestones that have any of the specified tags. Defaults to false."`
 }
 
 // MilestoneListByProject lists milestones in Teamwork.com by project.
 func MilestoneListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodMilestoneListByProject),
 			Description: "List milestones in Teamwork.com by project. " + milestoneDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Milestones by Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve milestones.",
-					},
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter milestones by name. " +
-							"Each word from the search term is used to match against the milestone name and description. " +
-							"The milestone will be selected if each word of the term matches the milestone name or description, not " +
-							"requiring that the word matches are in the same field.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter milestones by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match milestones that have all the specified tags. " +
-							"If false, the search will match milestones that have any of the specified tags. " +
-							"Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: milestoneListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input milestoneListByProjectInput) (*mcp.CallToolResult, error) {
 			var milestoneListRequest projects.MilestoneListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&milestoneListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalParam(&milestoneListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&milestoneListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&milestoneListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&milestoneListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&milestoneListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			milestoneListRequest.Path.ProjectID = input.ProjectID
+			milestoneListRequest.Filters.SearchTerm = input.SearchTerm
+			milestoneListRequest.Filters.TagIDs = input.TagIDs
+			milestoneListRequest.Filters.MatchAllTags = input.MatchAllTags
+			milestoneListRequest.Filters.Page = input.Page
+			milestoneListRequest.Filters.PageSize = input.PageSize
 
 			milestoneList, err := projects.MilestoneList(ctx, engine, milestoneListRequest)
 			if err != nil {
@@ -601,5 +321,5 @@ func MilestoneListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: milestoneList,
 			}, nil
 		},
-	}
+	)
 }
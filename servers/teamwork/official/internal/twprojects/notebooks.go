@@ -58,73 +58,33 @@ func init() {
 	}
 }
 
+type notebookCreateInput struct {
+	ProjectID   int64                 `json:"project_id" jsonschema:"The ID of the project to create the notebook in."`
+	Name        string                `json:"name" jsonschema:"The name of the notebook."`
+	Description *string               `json:"description,omitempty" jsonschema:"A description of the notebook."`
+	Contents    string                `json:"contents" jsonschema:"The contents of the notebook."`
+	Type        projects.NotebookType `json:"type" jsonschema:"The type of the notebook. Valid values are 'MARKDOWN' and 'HTML'."`
+	TagIDs      []int64               `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the notebook."`
+}
+
 // NotebookCreate creates a notebook in Teamwork.com.
 func NotebookCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodNotebookCreate),
 			Description: "Create a new notebook in Teamwork.com. " + notebookDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Notebook",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the notebook.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to create the notebook in.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the notebook.",
-					},
-					"contents": {
-						Type:        "string",
-						Description: "The contents of the notebook.",
-					},
-					"type": {
-						Type:        "string",
-						Description: "The type of the notebook. Valid values are 'MARKDOWN' and 'HTML'.",
-						Enum:        []any{"MARKDOWN", "HTML"},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the notebook.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"name", "project_id", "contents", "type"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input notebookCreateInput) (*mcp.CallToolResult, error) {
 			var notebookCreateRequest projects.NotebookCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&notebookCreateRequest.Path.ProjectID, "project_id"),
-				helpers.RequiredParam(&notebookCreateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&notebookCreateRequest.Description, "description"),
-				helpers.RequiredParam(&notebookCreateRequest.Contents, "contents"),
-				helpers.RequiredParam(&notebookCreateRequest.Type, "type",
-					helpers.RestrictValues(
-						projects.NotebookTypeMarkdown,
-						projects.NotebookTypeHTML,
-					),
-				),
-				helpers.OptionalNumericListParam(&notebookCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			notebookCreateRequest.Path.ProjectID = input.ProjectID
+			notebookCreateRequest.Name = input.Name
+			notebookCreateRequest.Description = input.Description
+			notebookCreateRequest.Contents = input.Contents
+			notebookCreateRequest.Type = input.Type
+			notebookCreateRequest.TagIDs = input.TagIDs
 
 			notebookResponse, err := projects.NotebookCreate(ctx, engine, notebookCreateRequest)
 			if err != nil {
@@ -132,164 +92,94 @@ func NotebookCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Notebook created successfully with ID %d", notebookResponse.Notebook.ID), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", projects.NotebookTypeMarkdown, projects.NotebookTypeHTML),
+	)
+}
+
+type notebookUpdateInput struct {
+	ID          int64                  `json:"id" jsonschema:"The ID of the notebook to update."`
+	Name        *string                `json:"name,omitempty" jsonschema:"The name of the notebook."`
+	Description *string                `json:"description,omitempty" jsonschema:"A description of the notebook."`
+	Contents    *string                `json:"contents,omitempty" jsonschema:"The contents of the notebook."`
+	Type        *projects.NotebookType `json:"type,omitempty" jsonschema:"The type of the notebook. Valid values are 'MARKDOWN' and 'HTML'."`
+	TagIDs      []int64                `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the notebook."`
 }
 
 // NotebookUpdate updates a notebook in Teamwork.com.
 func NotebookUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodNotebookUpdate),
 			Description: "Update an existing notebook in Teamwork.com. " + notebookDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Notebook",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the notebook to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the notebook.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the notebook.",
-					},
-					"contents": {
-						Type:        "string",
-						Description: "The contents of the notebook.",
-					},
-					"type": {
-						Type:        "string",
-						Description: "The type of the notebook. Valid values are 'MARKDOWN' and 'HTML'.",
-						Enum:        []any{"MARKDOWN", "HTML"},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the notebook.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input notebookUpdateInput) (*mcp.CallToolResult, error) {
 			var notebookUpdateRequest projects.NotebookUpdateRequest
+			notebookUpdateRequest.Path.ID = input.ID
+			notebookUpdateRequest.Name = input.Name
+			notebookUpdateRequest.Description = input.Description
+			notebookUpdateRequest.Contents = input.Contents
+			notebookUpdateRequest.Type = input.Type
+			notebookUpdateRequest.TagIDs = input.TagIDs
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&notebookUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&notebookUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&notebookUpdateRequest.Description, "description"),
-				helpers.OptionalPointerParam(&notebookUpdateRequest.Contents, "contents"),
-				helpers.OptionalPointerParam(&notebookUpdateRequest.Type, "type",
-					helpers.RestrictValues(
-						projects.NotebookTypeMarkdown,
-						projects.NotebookTypeHTML,
-					),
-				),
-				helpers.OptionalNumericListParam(&notebookUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.NotebookUpdate(ctx, engine, notebookUpdateRequest)
+			_, err := projects.NotebookUpdate(ctx, engine, notebookUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update notebook")
 			}
 			return helpers.NewToolResultText("Notebook updated successfully"), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", projects.NotebookTypeMarkdown, projects.NotebookTypeHTML),
+	)
+}
+
+type notebookDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the notebook to delete."`
 }
 
 // NotebookDelete deletes a notebook in Teamwork.com.
 func NotebookDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodNotebookDelete),
 			Description: "Delete an existing notebook in Teamwork.com. " + notebookDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Notebook",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the notebook to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input notebookDeleteInput) (*mcp.CallToolResult, error) {
 			var notebookDeleteRequest projects.NotebookDeleteRequest
+			notebookDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&notebookDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.NotebookDelete(ctx, engine, notebookDeleteRequest)
+			_, err := projects.NotebookDelete(ctx, engine, notebookDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete notebook")
 			}
 			return helpers.NewToolResultText("Notebook deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type notebookGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the notebook to get."`
 }
 
 // NotebookGet retrieves a notebook in Teamwork.com.
 func NotebookGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodNotebookGet),
 			Description: "Get an existing notebook in Teamwork.com. " + notebookDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Notebook",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the notebook to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: notebookGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input notebookGetInput) (*mcp.CallToolResult, error) {
 			var notebookGetRequest projects.NotebookGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&notebookGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			notebookGetRequest.Path.ID = input.ID
 
 			notebook, err := projects.NotebookGet(ctx, engine, notebookGetRequest)
 			if err != nil {
@@ -311,85 +201,41 @@ func NotebookGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: notebook,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type notebookListInput struct {
+	paginationInput
+
+	ProjectIDs      []int64 `json:"project_ids,omitempty" jsonschema:"A list of project IDs to filter notebooks by projects"`
+	SearchTerm      string  `json:"search_term,omitempty" jsonschema:"A search term to filter notebooks by name or description. The notebook will be selected if each word of the term matches the notebook name or description, not requiring that the word matches are in the same field."`
+	TagIDs          []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter notebooks by tags"`
+	MatchAllTags    *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match notebooks that have all the specified tags. If false, the search will match notebooks that have any of the specified tags. Defaults to false."`
+	IncludeContents *bool   `json:"include_contents,omitempty" jsonschema:"If true, the contents of the notebook will be included in the response. Defaults to true."`
 }
 
 // NotebookList lists notebooks in Teamwork.com.
 func NotebookList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodNotebookList),
 			Description: "List notebooks in Teamwork.com. " + notebookDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Notebooks",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_ids": {
-						Type:        "array",
-						Description: "A list of project IDs to filter notebooks by projects",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter notebooks by name or description. " +
-							"The notebook will be selected if each word of the term matches the notebook name or description, not " +
-							"requiring that the word matches are in the same field.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter notebooks by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match notebooks that have all the specified tags. " +
-							"If false, the search will match notebooks that have any of the specified tags. " +
-							"Defaults to false.",
-					},
-					"include_contents": {
-						Type: "boolean",
-						Description: "If true, the contents of the notebook will be included in the response. " +
-							"Defaults to true.",
-						Default: json.RawMessage(`true`),
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: notebookListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input notebookListInput) (*mcp.CallToolResult, error) {
 			var notebookListRequest projects.NotebookListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalNumericListParam(&notebookListRequest.Filters.ProjectIDs, "project_ids"),
-				helpers.OptionalParam(&notebookListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&notebookListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&notebookListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalPointerParam(&notebookListRequest.Filters.IncludeContents, "include_contents"),
-				helpers.OptionalNumericParam(&notebookListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&notebookListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			notebookListRequest.Filters.ProjectIDs = input.ProjectIDs
+			notebookListRequest.Filters.SearchTerm = input.SearchTerm
+			notebookListRequest.Filters.TagIDs = input.TagIDs
+			notebookListRequest.Filters.MatchAllTags = input.MatchAllTags
+			notebookListRequest.Filters.IncludeContents = input.IncludeContents
+			notebookListRequest.Filters.Page = input.Page
+			notebookListRequest.Filters.PageSize = input.PageSize
 
 			notebookList, err := projects.NotebookList(ctx, engine, notebookListRequest)
 			if err != nil {
@@ -411,5 +257,8 @@ func NotebookList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: notebookList,
 			}, nil
 		},
-	}
+		toolsets.WithInputSchemaProperty("include_contents", func(schema *jsonschema.Schema) {
+			schema.Default = json.RawMessage(`true`)
+		}),
+	)
 }
@@ -2,10 +2,7 @@ package twprojects
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
@@ -32,53 +29,31 @@ func init() {
 	toolsets.RegisterMethod(MethodProjectMemberAdd)
 }
 
+type projectMemberAddInput struct {
+	ProjectID int64   `json:"project_id" jsonschema:"The ID of the project to add the member to."`
+	UserIDs   []int64 `json:"user_ids,omitempty" jsonschema:"A list of user IDs to add to the project."`
+}
+
 // ProjectMemberAdd adds a user to a project in Teamwork.com.
 func ProjectMemberAdd(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectMemberAdd),
 			Description: "Add a user to a project in Teamwork.com. " + projectMemberDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Add Project Member",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to add the member to.",
-					},
-					"user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to add to the project.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"project_id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectMemberAddInput) (*mcp.CallToolResult, error) {
 			var projectMemberAddRequest projects.ProjectMemberAddRequest
+			projectMemberAddRequest.Path.ProjectID = input.ProjectID
+			projectMemberAddRequest.UserIDs = input.UserIDs
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&projectMemberAddRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalNumericListParam(&projectMemberAddRequest.UserIDs, "user_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.ProjectMemberAdd(ctx, engine, projectMemberAddRequest)
+			_, err := projects.ProjectMemberAdd(ctx, engine, projectMemberAddRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to add project member")
 			}
 			return helpers.NewToolResultText("Project member added successfully"), nil
 		},
-	}
+	)
 }
@@ -59,243 +59,126 @@ func init() {
 	}
 }
 
+type projectCreateInput struct {
+	Name        string               `json:"name" jsonschema:"The name of the project."`
+	Description *string              `json:"description,omitempty" jsonschema:"The description of the project."`
+	StartAt     *projects.LegacyDate `json:"start_at,omitempty" jsonschema:"The start date of the project in the format YYYYMMDD."`
+	EndAt       *projects.LegacyDate `json:"end_at,omitempty" jsonschema:"The end date of the project in the format YYYYMMDD."`
+	CompanyID   int64                `json:"company_id,omitempty" jsonschema:"The ID of the company associated with the project."`
+	OwnerID     *int64               `json:"owned_id,omitempty" jsonschema:"The ID of the user who owns the project."`
+	TagIDs      []int64              `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the project."`
+}
+
 // ProjectCreate creates a project in Teamwork.com.
 func ProjectCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectCreate),
 			Description: "Create a new project in Teamwork.com. " + projectDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Project",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the project.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the project.",
-					},
-					"start_at": {
-						Type:        "string",
-						Description: "The start date of the project in the format YYYYMMDD.",
-					},
-					"end_at": {
-						Type:        "string",
-						Description: "The end date of the project in the format YYYYMMDD.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the company associated with the project.",
-					},
-					"owned_id": {
-						Type:        "integer",
-						Description: "The ID of the user who owns the project.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the project.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var projectCreateRequest projects.ProjectCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&projectCreateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&projectCreateRequest.Description, "description"),
-				helpers.OptionalLegacyDatePointerParam(&projectCreateRequest.StartAt, "start_at"),
-				helpers.OptionalLegacyDatePointerParam(&projectCreateRequest.EndAt, "end_at"),
-				helpers.OptionalNumericParam(&projectCreateRequest.CompanyID, "company_id"),
-				helpers.OptionalNumericPointerParam(&projectCreateRequest.OwnerID, "owned_id"),
-				helpers.OptionalNumericListParam(&projectCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			project, err := projects.ProjectCreate(ctx, engine, projectCreateRequest)
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectCreateInput) (*mcp.CallToolResult, error) {
+			project, err := projects.ProjectCreate(ctx, engine, projects.ProjectCreateRequest{
+				Name:        input.Name,
+				Description: input.Description,
+				StartAt:     input.StartAt,
+				EndAt:       input.EndAt,
+				CompanyID:   input.CompanyID,
+				OwnerID:     input.OwnerID,
+				TagIDs:      input.TagIDs,
+			})
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to create project")
 			}
 			return helpers.NewToolResultText("Project created successfully with ID %d", project.ID), nil
 		},
-	}
+	)
+}
+
+type projectUpdateInput struct {
+	ID          int64                `json:"id" jsonschema:"The ID of the project to update."`
+	Name        *string              `json:"name,omitempty" jsonschema:"The name of the project."`
+	Description *string              `json:"description,omitempty" jsonschema:"The description of the project."`
+	StartAt     *projects.LegacyDate `json:"start_at,omitempty" jsonschema:"The start date of the project in the format YYYYMMDD."`
+	EndAt       *projects.LegacyDate `json:"end_at,omitempty" jsonschema:"The end date of the project in the format YYYYMMDD."`
+	CompanyID   *int64               `json:"company_id,omitempty" jsonschema:"The ID of the company associated with the project."`
+	OwnerID     *int64               `json:"owned_id,omitempty" jsonschema:"The ID of the user who owns the project."`
+	TagIDs      []int64              `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the project."`
 }
 
 // ProjectUpdate updates a project in Teamwork.com.
 func ProjectUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectUpdate),
 			Description: "Update an existing project in Teamwork.com. " + projectDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Project",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the project to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the project.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the project.",
-					},
-					"start_at": {
-						Type:        "string",
-						Description: "The start date of the project in the format YYYYMMDD.",
-					},
-					"end_at": {
-						Type:        "string",
-						Description: "The end date of the project in the format YYYYMMDD.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the company associated with the project.",
-					},
-					"owned_id": {
-						Type:        "integer",
-						Description: "The ID of the user who owns the project.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the project.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var projectUpdateRequest projects.ProjectUpdateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&projectUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&projectUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&projectUpdateRequest.Description, "description"),
-				helpers.OptionalLegacyDatePointerParam(&projectUpdateRequest.StartAt, "start_at"),
-				helpers.OptionalLegacyDatePointerParam(&projectUpdateRequest.EndAt, "end_at"),
-				helpers.OptionalNumericPointerParam(&projectUpdateRequest.CompanyID, "company_id"),
-				helpers.OptionalNumericPointerParam(&projectUpdateRequest.OwnerID, "owned_id"),
-				helpers.OptionalNumericListParam(&projectUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.ProjectUpdate(ctx, engine, projectUpdateRequest)
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectUpdateInput) (*mcp.CallToolResult, error) {
+			projectUpdateRequest := projects.NewProjectUpdateRequest(input.ID)
+			projectUpdateRequest.Name = input.Name
+			projectUpdateRequest.Description = input.Description
+			projectUpdateRequest.StartAt = input.StartAt
+			projectUpdateRequest.EndAt = input.EndAt
+			projectUpdateRequest.CompanyID = input.CompanyID
+			projectUpdateRequest.OwnerID = input.OwnerID
+			projectUpdateRequest.TagIDs = input.TagIDs
+
+			_, err := projects.ProjectUpdate(ctx, engine, projectUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update project")
 			}
 			return helpers.NewToolResultText("Project updated successfully"), nil
 		},
-	}
+	)
+}
+
+type projectDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the project to delete."`
 }
 
 // ProjectDelete deletes a project in Teamwork.com.
 func ProjectDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectDelete),
 			Description: "Delete an existing project in Teamwork.com. " + projectDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Project",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the project to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var projectDeleteRequest projects.ProjectDeleteRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&projectDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.ProjectDelete(ctx, engine, projectDeleteRequest)
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectDeleteInput) (*mcp.CallToolResult, error) {
+			_, err := projects.ProjectDelete(ctx, engine, projects.NewProjectDeleteRequest(input.ID))
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete project")
 			}
 			return helpers.NewToolResultText("Project deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type projectGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the project to get."`
 }
 
 // ProjectGet retrieves a project in Teamwork.com.
 func ProjectGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectGet),
 			Description: "Get an existing project in Teamwork.com. " + projectDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the project to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: projectGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var projectGetRequest projects.ProjectGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&projectGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			project, err := projects.ProjectGet(ctx, engine, projectGetRequest)
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectGetInput) (*mcp.CallToolResult, error) {
+			project, err := projects.ProjectGet(ctx, engine, projects.NewProjectGetRequest(input.ID))
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to get project")
 			}
@@ -315,67 +198,37 @@ func ProjectGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: project,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type projectListInput struct {
+	paginationInput
+
+	SearchTerm   string  `json:"search_term,omitempty" jsonschema:"A search term to filter projects by name or description."`
+	TagIDs       []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter projects by tags."`
+	MatchAllTags *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match projects that have all the specified tags. If false, the search will match projects that have any of the specified tags. Defaults to false."`
 }
 
 // ProjectList lists projects in Teamwork.com.
 func ProjectList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodProjectList),
 			Description: "List projects in Teamwork.com. " + projectDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Projects",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter projects by name or description.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter projects by tags.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match projects that have all the specified tags. If false, the " +
-							"search will match projects that have any of the specified tags. Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: projectListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input projectListInput) (*mcp.CallToolResult, error) {
 			var projectListRequest projects.ProjectListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&projectListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&projectListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&projectListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&projectListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&projectListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			projectListRequest.Filters.SearchTerm = input.SearchTerm
+			projectListRequest.Filters.TagIDs = input.TagIDs
+			projectListRequest.Filters.MatchAllTags = input.MatchAllTags
+			projectListRequest.Filters.Page = input.Page
+			projectListRequest.Filters.PageSize = input.PageSize
 
 			projectList, err := projects.ProjectList(ctx, engine, projectListRequest)
 			if err != nil {
@@ -397,5 +250,5 @@ func ProjectList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: projectList,
 			}, nil
 		},
-	}
+	)
 }
@@ -2,7 +2,6 @@ package twprojects
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/google/jsonschema-go/jsonschema"
@@ -57,44 +56,26 @@ func init() {
 	}
 }
 
+//nolint:lll
+type tagCreateInput struct {
+	Name      string `json:"name" jsonschema:"The name of the tag. It must have less than 50 characters."`
+	ProjectID *int64 `json:"project_id,omitempty" jsonschema:"The ID of the project to associate the tag with. This is for project-scoped tags."`
+}
+
 // TagCreate creates a tag in Teamwork.com.
 func TagCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTagCreate),
 			Description: "Create a new tag in Teamwork.com. " + tagDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Tag",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the tag. It must have less than 50 characters.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to associate the tag with. This is for project-scoped tags.",
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagCreateInput) (*mcp.CallToolResult, error) {
 			var tagCreateRequest projects.TagCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&tagCreateRequest.Name, "name"),
-				helpers.OptionalNumericPointerParam(&tagCreateRequest.ProjectID, "project_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tagCreateRequest.Name = input.Name
+			tagCreateRequest.ProjectID = input.ProjectID
 
 			tagResponse, err := projects.TagCreate(ctx, engine, tagCreateRequest)
 			if err != nil {
@@ -102,140 +83,87 @@ func TagCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Tag created successfully with ID %d", tagResponse.Tag.ID), nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type tagUpdateInput struct {
+	ID        int64   `json:"id" jsonschema:"The ID of the tag to update."`
+	Name      *string `json:"name,omitempty" jsonschema:"The name of the tag. It must have less than 50 characters."`
+	ProjectID *int64  `json:"project_id,omitempty" jsonschema:"The ID of the project to associate the tag with. This is for project-scoped tags."`
 }
 
 // TagUpdate updates a tag in Teamwork.com.
 func TagUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTagUpdate),
 			Description: "Update an existing tag in Teamwork.com. " + tagDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Tag",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tag to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the tag. It must have less than 50 characters.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to associate the tag with. This is for project-scoped tags.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagUpdateInput) (*mcp.CallToolResult, error) {
 			var tagUpdateRequest projects.TagUpdateRequest
+			tagUpdateRequest.Path.ID = input.ID
+			tagUpdateRequest.Name = input.Name
+			tagUpdateRequest.ProjectID = input.ProjectID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tagUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&tagUpdateRequest.Name, "name"),
-				helpers.OptionalNumericPointerParam(&tagUpdateRequest.ProjectID, "project_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TagUpdate(ctx, engine, tagUpdateRequest)
+			_, err := projects.TagUpdate(ctx, engine, tagUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update tag")
 			}
 			return helpers.NewToolResultText("Tag updated successfully"), nil
 		},
-	}
+	)
+}
+
+type tagDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the tag to delete."`
 }
 
 // TagDelete deletes a tag in Teamwork.com.
 func TagDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTagDelete),
 			Description: "Delete an existing tag in Teamwork.com. " + tagDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Tag",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tag to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagDeleteInput) (*mcp.CallToolResult, error) {
 			var tagDeleteRequest projects.TagDeleteRequest
+			tagDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tagDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TagDelete(ctx, engine, tagDeleteRequest)
+			_, err := projects.TagDelete(ctx, engine, tagDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete tag")
 			}
 			return helpers.NewToolResultText("Tag deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type tagGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the tag to get."`
 }
 
 // TagGet retrieves a tag in Teamwork.com.
 func TagGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTagGet),
 			Description: "Get an existing tag in Teamwork.com. " + tagDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Tag",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tag to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: tagGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagGetInput) (*mcp.CallToolResult, error) {
 			var tagGetRequest projects.TagGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tagGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tagGetRequest.Path.ID = input.ID
 
 			tag, err := projects.TagGet(ctx, engine, tagGetRequest)
 			if err != nil {
@@ -243,83 +171,37 @@ func TagGet(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(tag)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type tagListInput struct {
+	paginationInput
+
+	SearchTerm string  `json:"search_term,omitempty" jsonschema:"A search term to filter tags by name. Each word from the search term is used to match against the tag name."`
+	ItemType   string  `json:"item_type,omitempty" jsonschema:"The type of item to filter tags by. Valid values are 'project', 'task', 'tasklist', 'milestone', 'message', 'timelog', 'notebook', 'file', 'company' and 'link'."`
+	ProjectIDs []int64 `json:"project_ids,omitempty" jsonschema:"A list of project IDs to filter tags by projects"`
 }
 
 // TagList lists tags in Teamwork.com.
 func TagList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTagList),
 			Description: "List tags in Teamwork.com. " + tagDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tags",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter tags by name. Each word from the search term is used to match " +
-							"against the tag name.",
-					},
-					"item_type": {
-						Type: "string",
-						Description: "The type of item to filter tags by. Valid values are 'project', 'task', 'tasklist', " +
-							"'milestone', 'message', 'timelog', 'notebook', 'file', 'company' and 'link'.",
-						Enum: []any{
-							"project",
-							"task",
-							"tasklist",
-							"milestone",
-							"message",
-							"timelog",
-							"notebook",
-							"file",
-							"company",
-							"link",
-						},
-					},
-					"project_ids": {
-						Type:        "array",
-						Description: "A list of project IDs to filter tags by projects",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: tagListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tagListInput) (*mcp.CallToolResult, error) {
 			var tagListRequest projects.TagListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&tagListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalParam(&tagListRequest.Filters.ItemType, "item_type",
-					helpers.RestrictValues("project", "task", "tasklist", "milestone", "message", "timelog", "notebook",
-						"file", "company", "link"),
-				),
-				helpers.OptionalNumericListParam(&tagListRequest.Filters.ProjectIDs, "project_ids"),
-				helpers.OptionalNumericParam(&tagListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&tagListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tagListRequest.Filters.SearchTerm = input.SearchTerm
+			tagListRequest.Filters.ItemType = input.ItemType
+			tagListRequest.Filters.ProjectIDs = input.ProjectIDs
+			tagListRequest.Filters.Page = input.Page
+			tagListRequest.Filters.PageSize = input.PageSize
 
 			tagList, err := projects.TagList(ctx, engine, tagListRequest)
 			if err != nil {
@@ -327,5 +209,17 @@ func TagList(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(tagList)
 		},
-	}
+		toolsets.WithInputSchemaEnum("item_type",
+			"project",
+			"task",
+			"tasklist",
+			"milestone",
+			"message",
+			"timelog",
+			"notebook",
+			"file",
+			"company",
+			"link",
+		),
+	)
 }
@@ -60,54 +60,29 @@ func init() {
 	}
 }
 
+type tasklistCreateInput struct {
+	Name        string  `json:"name" jsonschema:"The name of the tasklist."`
+	ProjectID   int64   `json:"project_id" jsonschema:"The ID of the project to create the tasklist in."`
+	Description *string `json:"description,omitempty" jsonschema:"The description of the tasklist."`
+	MilestoneID *int64  `json:"milestone_id,omitempty" jsonschema:"The ID of the milestone to associate with the tasklist."`
+}
+
 // TasklistCreate creates a tasklist in Teamwork.com.
 func TasklistCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistCreate),
 			Description: "Create a new tasklist in Teamwork.com. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Tasklist",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the tasklist.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to create the tasklist in.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the tasklist.",
-					},
-					"milestone_id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to associate with the tasklist.",
-					},
-				},
-				Required: []string{"name", "project_id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistCreateInput) (*mcp.CallToolResult, error) {
 			var tasklistCreateRequest projects.TasklistCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&tasklistCreateRequest.Name, "name"),
-				helpers.RequiredNumericParam(&tasklistCreateRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalPointerParam(&tasklistCreateRequest.Description, "description"),
-				helpers.OptionalNumericPointerParam(&tasklistCreateRequest.MilestoneID, "milestone_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tasklistCreateRequest.Name = input.Name
+			tasklistCreateRequest.Path.ProjectID = input.ProjectID
+			tasklistCreateRequest.Description = input.Description
+			tasklistCreateRequest.MilestoneID = input.MilestoneID
 
 			tasklist, err := projects.TasklistCreate(ctx, engine, tasklistCreateRequest)
 			if err != nil {
@@ -115,145 +90,88 @@ func TasklistCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Tasklist created successfully with ID %d", tasklist.ID), nil
 		},
-	}
+	)
+}
+
+type tasklistUpdateInput struct {
+	ID          int64   `json:"id" jsonschema:"The ID of the tasklist to update."`
+	Name        *string `json:"name,omitempty" jsonschema:"The name of the tasklist."`
+	Description *string `json:"description,omitempty" jsonschema:"The description of the tasklist."`
+	MilestoneID *int64  `json:"milestone_id,omitempty" jsonschema:"The ID of the milestone to associate with the tasklist."`
 }
 
 // TasklistUpdate updates a tasklist in Teamwork.com.
 func TasklistUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistUpdate),
 			Description: "Update an existing tasklist in Teamwork.com. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Tasklist",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tasklist to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the tasklist.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the tasklist.",
-					},
-					"milestone_id": {
-						Type:        "integer",
-						Description: "The ID of the milestone to associate with the tasklist.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistUpdateInput) (*mcp.CallToolResult, error) {
 			var tasklistUpdateRequest projects.TasklistUpdateRequest
+			tasklistUpdateRequest.Path.ID = input.ID
+			tasklistUpdateRequest.Name = input.Name
+			tasklistUpdateRequest.Description = input.Description
+			tasklistUpdateRequest.MilestoneID = input.MilestoneID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tasklistUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&tasklistUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&tasklistUpdateRequest.Description, "description"),
-				helpers.OptionalNumericPointerParam(&tasklistUpdateRequest.MilestoneID, "milestone_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TasklistUpdate(ctx, engine, tasklistUpdateRequest)
+			_, err := projects.TasklistUpdate(ctx, engine, tasklistUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update tasklist")
 			}
 			return helpers.NewToolResultText("Tasklist updated successfully"), nil
 		},
-	}
+	)
+}
+
+type tasklistDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the tasklist to delete."`
 }
 
 // TasklistDelete deletes a tasklist in Teamwork.com.
 func TasklistDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistDelete),
 			Description: "Delete an existing tasklist in Teamwork.com. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Tasklist",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tasklist to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistDeleteInput) (*mcp.CallToolResult, error) {
 			var tasklistDeleteRequest projects.TasklistDeleteRequest
+			tasklistDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tasklistDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TasklistDelete(ctx, engine, tasklistDeleteRequest)
+			_, err := projects.TasklistDelete(ctx, engine, tasklistDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete tasklist")
 			}
 			return helpers.NewToolResultText("Tasklist deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type tasklistGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the tasklist to get."`
 }
 
 // TasklistGet retrieves a tasklist in Teamwork.com.
 func TasklistGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistGet),
 			Description: "Get an existing tasklist in Teamwork.com. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Tasklist",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the tasklist to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: tasklistGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistGetInput) (*mcp.CallToolResult, error) {
 			var tasklistGetRequest projects.TasklistGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tasklistGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tasklistGetRequest.Path.ID = input.ID
 
 			tasklist, err := projects.TasklistGet(ctx, engine, tasklistGetRequest)
 			if err != nil {
@@ -275,53 +193,32 @@ func TasklistGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: tasklist,
 			}, nil
 		},
-	}
+	)
+}
+
+type tasklistListInput struct {
+	paginationInput
+
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter tasklists by name."`
 }
 
 // TasklistList lists tasklists in Teamwork.com.
 func TasklistList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistList),
 			Description: "List tasklists in Teamwork.com. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tasklists",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter tasklists by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: tasklistListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistListInput) (*mcp.CallToolResult, error) {
 			var tasklistListRequest projects.TasklistListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&tasklistListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&tasklistListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&tasklistListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tasklistListRequest.Filters.SearchTerm = input.SearchTerm
+			tasklistListRequest.Filters.Page = input.Page
+			tasklistListRequest.Filters.PageSize = input.PageSize
 
 			tasklistList, err := projects.TasklistList(ctx, engine, tasklistListRequest)
 			if err != nil {
@@ -343,59 +240,34 @@ func TasklistList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: tasklistList,
 			}, nil
 		},
-	}
+	)
+}
+
+type tasklistListByProjectInput struct {
+	paginationInput
+
+	ProjectID  int64  `json:"project_id" jsonschema:"The ID of the project from which to retrieve tasklists."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter tasklists by name."`
 }
 
 // TasklistListByProject lists tasklists in Teamwork.com by project.
 func TasklistListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTasklistListByProject),
 			Description: "List tasklists in Teamwork.com by project. " + tasklistDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tasklists By Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve tasklists.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter tasklists by name.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: tasklistListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input tasklistListByProjectInput) (*mcp.CallToolResult, error) {
 			var tasklistListRequest projects.TasklistListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&tasklistListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalParam(&tasklistListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&tasklistListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&tasklistListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			tasklistListRequest.Path.ProjectID = input.ProjectID
+			tasklistListRequest.Filters.SearchTerm = input.SearchTerm
+			tasklistListRequest.Filters.Page = input.Page
+			tasklistListRequest.Filters.PageSize = input.PageSize
 
 			tasklistList, err := projects.TasklistList(ctx, engine, tasklistListRequest)
 			if err != nil {
@@ -417,5 +289,5 @@ func TasklistListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: tasklistList,
 			}, nil
 		},
-	}
+	)
 }
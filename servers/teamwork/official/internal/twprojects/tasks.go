@@ -62,196 +62,82 @@ func init() {
 	}
 }
 
+//nolint:lll
+type taskPredecessorInput struct {
+	TaskID int64                        `json:"task_id" jsonschema:"The ID of the predecessor task."`
+	Type   projects.TaskPredecessorType `json:"type" jsonschema:"The type of dependency. Possible values are: start or complete. 'start' means this task can complete when the predecessor starts, 'complete' means this task can complete when the predecessor completes."`
+}
+
+func taskPredecessors(inputs []taskPredecessorInput) []projects.TaskPredecessor {
+	var predecessors []projects.TaskPredecessor
+	for _, input := range inputs {
+		predecessors = append(predecessors, projects.TaskPredecessor{
+			ID:   input.TaskID,
+			Type: input.Type,
+		})
+	}
+	return predecessors
+}
+
+// taskSchemaOptions contains the constraints shared by the tools that create
+// and update tasks.
+func taskSchemaOptions() []toolsets.InputSchemaOption {
+	return []toolsets.InputSchemaOption{
+		toolsets.WithInputSchemaEnum("priority", "low", "medium", "high"),
+		toolsets.WithInputSchemaProperty("progress", func(schema *jsonschema.Schema) {
+			schema.Minimum = twapi.Ptr(float64(0))
+			schema.Maximum = twapi.Ptr(float64(100))
+		}),
+		withUserGroupsSchema("assignees", "task"),
+		toolsets.WithInputSchemaEnum("predecessors.type",
+			projects.TaskPredecessorTypeStart,
+			projects.TaskPredecessorTypeFinish,
+		),
+	}
+}
+
+//nolint:lll
+type taskCreateInput struct {
+	Name             string                 `json:"name" jsonschema:"The name of the task."`
+	TasklistID       int64                  `json:"tasklist_id"`
+	Description      *string                `json:"description,omitempty" jsonschema:"The description of the task."`
+	Priority         *string                `json:"priority,omitempty" jsonschema:"The priority of the task. Possible values are: low, medium, high."`
+	Progress         *int64                 `json:"progress,omitempty" jsonschema:"The progress of the task, as a percentage (0-100). Only whole numbers are allowed."`
+	StartDate        *twapi.Date            `json:"start_date,omitempty" jsonschema:"The start date of the task in ISO 8601 format (YYYY-MM-DD)."`
+	DueDate          *twapi.Date            `json:"due_date,omitempty" jsonschema:"The due date of the task in ISO 8601 format (YYYY-MM-DD). When this is not provided, it will fallback to the milestone due date if a milestone is set."`
+	EstimatedMinutes *int64                 `json:"estimated_minutes,omitempty" jsonschema:"The estimated time to complete the task in minutes."`
+	ParentTaskID     *int64                 `json:"parent_task_id,omitempty" jsonschema:"The ID of the parent task if creating a subtask."`
+	Assignees        *userGroupsInput       `json:"assignees,omitempty" jsonschema:"An object containing assignees for the task."`
+	TagIDs           []int64                `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to assign to the task."`
+	Predecessors     []taskPredecessorInput `json:"predecessors,omitempty" jsonschema:"List of task dependencies that must be completed before this task can start, defining its position in the project workflow and ensuring proper sequencing of work."`
+}
+
 // TaskCreate creates a task in Teamwork.com.
 func TaskCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskCreate),
 			Description: "Create a new task in Teamwork.com. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Task",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the task.",
-					},
-					"tasklist_id": {
-						Type: "integer",
-						Description: "The ID of the tasklist. If you only have the project's name, use the " +
-							string(MethodProjectList) + " method with the search_term parameter to find the project ID, and " +
-							"then the " + string(MethodTasklistList) + " method with the project_id to choose the tasklist ID. If " +
-							"you know the tasklist's name, you may also use the search_term parameter with the " +
-							string(MethodTasklistList) + " method to find the tasklist ID.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the task.",
-					},
-					"priority": {
-						Type:        "string",
-						Description: "The priority of the task. Possible values are: low, medium, high.",
-						Enum:        []any{"low", "medium", "high"},
-					},
-					"progress": {
-						Type:        "integer",
-						Description: "The progress of the task, as a percentage (0-100). Only whole numbers are allowed.",
-						Minimum:     twapi.Ptr(float64(0)),
-						Maximum:     twapi.Ptr(float64(100)),
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The start date of the task in ISO 8601 format (YYYY-MM-DD).",
-					},
-					"due_date": {
-						Type:   "string",
-						Format: "date",
-						Description: "The due date of the task in ISO 8601 format (YYYY-MM-DD). When this is not provided, it " +
-							"will fallback to the milestone due date if a milestone is set.",
-					},
-					"estimated_minutes": {
-						Type:        "integer",
-						Description: "The estimated time to complete the task in minutes.",
-					},
-					"parent_task_id": {
-						Type:        "integer",
-						Description: "The ID of the parent task if creating a subtask.",
-					},
-					"assignees": {
-						Type:        "object",
-						Description: "An object containing assignees for the task.",
-						Properties: map[string]*jsonschema.Schema{
-							"user_ids": {
-								Type:        "array",
-								Description: "List of user IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-							"company_ids": {
-								Type:        "array",
-								Description: "List of company IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-							"team_ids": {
-								Type:        "array",
-								Description: "List of team IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-						},
-						MinProperties: twapi.Ptr(1),
-						MaxProperties: twapi.Ptr(3),
-						AnyOf: []*jsonschema.Schema{
-							{Required: []string{"user_ids"}},
-							{Required: []string{"company_ids"}},
-							{Required: []string{"team_ids"}},
-						},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to assign to the task.",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"predecessors": {
-						Type: "array",
-						Description: "List of task dependencies that must be completed before this task can start, defining its " +
-							"position in the project workflow and ensuring proper sequencing of work.",
-						Items: &jsonschema.Schema{
-							Type: "object",
-							Properties: map[string]*jsonschema.Schema{
-								"task_id": {
-									Type:        "integer",
-									Description: "The ID of the predecessor task.",
-								},
-								"type": {
-									Type: "string",
-									Description: "The type of dependency. Possible values are: start or complete. 'start' means this " +
-										"task can complete when the predecessor starts, 'complete' means this task can complete when " +
-										"the predecessor completes.",
-									Enum: []any{"start", "complete"},
-								},
-							},
-						},
-					},
-				},
-				Required: []string{"name", "tasklist_id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskCreateInput) (*mcp.CallToolResult, error) {
 			var taskCreateRequest projects.TaskCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&taskCreateRequest.Name, "name"),
-				helpers.RequiredNumericParam(&taskCreateRequest.Path.TasklistID, "tasklist_id"),
-				helpers.OptionalPointerParam(&taskCreateRequest.Description, "description"),
-				helpers.OptionalPointerParam(&taskCreateRequest.Priority, "priority",
-					helpers.RestrictValues("low", "medium", "high"),
-				),
-				helpers.OptionalNumericPointerParam(&taskCreateRequest.Progress, "progress"),
-				helpers.OptionalDatePointerParam(&taskCreateRequest.StartAt, "start_date"),
-				helpers.OptionalDatePointerParam(&taskCreateRequest.DueAt, "due_date"),
-				helpers.OptionalNumericPointerParam(&taskCreateRequest.EstimatedMinutes, "estimated_minutes"),
-				helpers.OptionalNumericPointerParam(&taskCreateRequest.ParentTaskID, "parent_task_id"),
-				helpers.OptionalNumericListParam(&taskCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			if assignees, ok := arguments["assignees"]; ok {
-				assigneesMap, ok := assignees.(map[string]any)
-				if !ok {
-					return helpers.NewToolResultTextError("invalid assignees"), nil
-				} else if assigneesMap != nil {
-					taskCreateRequest.Assignees = new(projects.UserGroups)
-
-					err = helpers.ParamGroup(assigneesMap,
-						helpers.OptionalNumericListParam(&taskCreateRequest.Assignees.UserIDs, "user_ids"),
-						helpers.OptionalNumericListParam(&taskCreateRequest.Assignees.CompanyIDs, "company_ids"),
-						helpers.OptionalNumericListParam(&taskCreateRequest.Assignees.TeamIDs, "team_ids"),
-					)
-					if err != nil {
-						return helpers.NewToolResultTextError(fmt.Sprintf("invalid assignees: %s", err)), nil
-					}
-				}
-			}
-
-			if predecessors, ok := arguments["predecessors"]; ok {
-				predecessorsSlice, ok := predecessors.([]any)
-				if !ok {
-					return helpers.NewToolResultTextError("invalid predecessors"), nil
-				}
-
-				for _, predecessor := range predecessorsSlice {
-					predecessorMap, ok := predecessor.(map[string]any)
-					if !ok {
-						return helpers.NewToolResultTextError("invalid predecessors"), nil
-					}
-
-					var p projects.TaskPredecessor
-					err = helpers.ParamGroup(predecessorMap,
-						helpers.RequiredNumericParam(&p.ID, "task_id"),
-						helpers.RequiredParam(&p.Type, "type",
-							helpers.RestrictValues(
-								projects.TaskPredecessorTypeStart,
-								projects.TaskPredecessorTypeFinish,
-							),
-						),
-					)
-					if err != nil {
-						return helpers.NewToolResultTextError(fmt.Sprintf("invalid predecessor: %s", err)), nil
-					}
-
-					taskCreateRequest.Predecessors = append(taskCreateRequest.Predecessors, p)
-				}
+			taskCreateRequest.Name = input.Name
+			taskCreateRequest.Path.TasklistID = input.TasklistID
+			taskCreateRequest.Description = input.Description
+			taskCreateRequest.Priority = input.Priority
+			taskCreateRequest.Progress = input.Progress
+			taskCreateRequest.StartAt = input.StartDate
+			taskCreateRequest.DueAt = input.DueDate
+			taskCreateRequest.EstimatedMinutes = input.EstimatedMinutes
+			taskCreateRequest.ParentTaskID = input.ParentTaskID
+			taskCreateRequest.TagIDs = input.TagIDs
+			taskCreateRequest.Predecessors = taskPredecessors(input.Predecessors)
+			if input.Assignees != nil {
+				assignees := input.Assignees.userGroups()
+				taskCreateRequest.Assignees = &assignees
 			}
 
 			taskResponse, err := projects.TaskCreate(ctx, engine, taskCreateRequest)
@@ -260,285 +146,120 @@ func TaskCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Task created successfully with ID %d", taskResponse.Task.ID), nil
 		},
-	}
+		append(taskSchemaOptions(),
+			toolsets.WithInputSchemaProperty("tasklist_id", func(schema *jsonschema.Schema) {
+				schema.Description = "The ID of the tasklist. If you only have the project's name, use the " +
+					string(MethodProjectList) + " method with the search_term parameter to find the project ID, and " +
+					"then the " + string(MethodTasklistList) + " method with the project_id to choose the tasklist ID. If " +
+					"you know the tasklist's name, you may also use the search_term parameter with the " +
+					string(MethodTasklistList) + " method to find the tasklist ID."
+			}),
+		)...,
+	)
+}
+
+//nolint:lll
+type taskUpdateInput struct {
+	ID               int64                  `json:"id" jsonschema:"The ID of the task to update."`
+	TasklistID       *int64                 `json:"tasklist_id,omitempty" jsonschema:"The ID of the tasklist."`
+	Name             *string                `json:"name,omitempty" jsonschema:"The name of the task."`
+	Description      *string                `json:"description,omitempty" jsonschema:"The description of the task."`
+	Priority         *string                `json:"priority,omitempty" jsonschema:"The priority of the task. Possible values are: low, medium, high."`
+	Progress         *int64                 `json:"progress,omitempty" jsonschema:"The progress of the task, as a percentage (0-100). Only whole numbers are allowed."`
+	StartDate        *twapi.Date            `json:"start_date,omitempty" jsonschema:"The start date of the task in ISO 8601 format (YYYY-MM-DD)."`
+	DueDate          *twapi.Date            `json:"due_date,omitempty" jsonschema:"The due date of the task in ISO 8601 format (YYYY-MM-DD). When this is not provided, it will fallback to the milestone due date if a milestone is set."`
+	EstimatedMinutes *int64                 `json:"estimated_minutes,omitempty" jsonschema:"The estimated time to complete the task in minutes."`
+	ParentTaskID     *int64                 `json:"parent_task_id,omitempty" jsonschema:"The ID of the parent task if creating a subtask."`
+	Assignees        *userGroupsInput       `json:"assignees,omitempty" jsonschema:"An object containing assignees for the task."`
+	TagIDs           []int64                `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to assign to the task."`
+	Predecessors     []taskPredecessorInput `json:"predecessors,omitempty" jsonschema:"List of task dependencies that must be completed before this task can start, defining its position in the project workflow and ensuring proper sequencing of work."`
 }
 
 // TaskUpdate updates a task in Teamwork.com.
 func TaskUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskUpdate),
 			Description: "Update an existing task in Teamwork.com. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Task",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the task to update.",
-					},
-					"tasklist_id": {
-						Type:        "integer",
-						Description: "The ID of the tasklist.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the task.",
-					},
-					"priority": {
-						Type:        "string",
-						Description: "The priority of the task. Possible values are: low, medium, high.",
-						Enum:        []any{"low", "medium", "high"},
-					},
-					"progress": {
-						Type:        "integer",
-						Description: "The progress of the task, as a percentage (0-100). Only whole numbers are allowed.",
-						Minimum:     twapi.Ptr(float64(0)),
-						Maximum:     twapi.Ptr(float64(100)),
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The start date of the task in ISO 8601 format (YYYY-MM-DD).",
-					},
-					"due_date": {
-						Type:   "string",
-						Format: "date",
-						Description: "The due date of the task in ISO 8601 format (YYYY-MM-DD). When this is not provided, it " +
-							"will fallback to the milestone due date if a milestone is set.",
-					},
-					"estimated_minutes": {
-						Type:        "integer",
-						Description: "The estimated time to complete the task in minutes.",
-					},
-					"parent_task_id": {
-						Type:        "integer",
-						Description: "The ID of the parent task if creating a subtask.",
-					},
-					"assignees": {
-						Type:        "object",
-						Description: "An object containing assignees for the task.",
-						Properties: map[string]*jsonschema.Schema{
-							"user_ids": {
-								Type:        "array",
-								Description: "List of user IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-							"company_ids": {
-								Type:        "array",
-								Description: "List of company IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-							"team_ids": {
-								Type:        "array",
-								Description: "List of team IDs assigned to the task.",
-								Items:       &jsonschema.Schema{Type: "integer"},
-								MinItems:    twapi.Ptr(1),
-							},
-						},
-						MinProperties: twapi.Ptr(1),
-						MaxProperties: twapi.Ptr(3),
-						AnyOf: []*jsonschema.Schema{
-							{Required: []string{"user_ids"}},
-							{Required: []string{"company_ids"}},
-							{Required: []string{"team_ids"}},
-						},
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to assign to the task.",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"predecessors": {
-						Type: "array",
-						Description: "List of task dependencies that must be completed before this task can start, defining its " +
-							"position in the project workflow and ensuring proper sequencing of work.",
-						Items: &jsonschema.Schema{
-							Type: "object",
-							Properties: map[string]*jsonschema.Schema{
-								"task_id": {
-									Type:        "integer",
-									Description: "The ID of the predecessor task.",
-								},
-								"type": {
-									Type: "string",
-									Description: "The type of dependency. Possible values are: start or complete. 'start' means this " +
-										"task can complete when the predecessor starts, 'complete' means this task can complete when the " +
-										"predecessor completes.",
-									Enum: []any{"start", "complete"},
-								},
-							},
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskUpdateInput) (*mcp.CallToolResult, error) {
 			var taskUpdateRequest projects.TaskUpdateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&taskUpdateRequest.Path.ID, "id"),
-				helpers.OptionalNumericPointerParam(&taskUpdateRequest.TasklistID, "tasklist_id"),
-				helpers.OptionalPointerParam(&taskUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&taskUpdateRequest.Description, "description"),
-				helpers.OptionalPointerParam(&taskUpdateRequest.Priority, "priority",
-					helpers.RestrictValues("low", "medium", "high"),
-				),
-				helpers.OptionalNumericPointerParam(&taskUpdateRequest.Progress, "progress"),
-				helpers.OptionalDatePointerParam(&taskUpdateRequest.StartAt, "start_date"),
-				helpers.OptionalDatePointerParam(&taskUpdateRequest.DueAt, "due_date"),
-				helpers.OptionalNumericPointerParam(&taskUpdateRequest.EstimatedMinutes, "estimated_minutes"),
-				helpers.OptionalNumericPointerParam(&taskUpdateRequest.ParentTaskID, "parent_task_id"),
-				helpers.OptionalNumericListParam(&taskUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			if assignees, ok := arguments["assignees"]; ok {
-				assigneesMap, ok := assignees.(map[string]any)
-				if !ok {
-					return helpers.NewToolResultTextError("invalid assignees"), nil
-				} else if assigneesMap != nil {
-					taskUpdateRequest.Assignees = new(projects.UserGroups)
-
-					err = helpers.ParamGroup(assigneesMap,
-						helpers.OptionalNumericListParam(&taskUpdateRequest.Assignees.UserIDs, "user_ids"),
-						helpers.OptionalNumericListParam(&taskUpdateRequest.Assignees.CompanyIDs, "company_ids"),
-						helpers.OptionalNumericListParam(&taskUpdateRequest.Assignees.TeamIDs, "team_ids"),
-					)
-					if err != nil {
-						return helpers.NewToolResultTextError(fmt.Sprintf("invalid assignees: %s", err)), nil
-					}
-				}
-			}
-
-			if predecessors, ok := arguments["predecessors"]; ok {
-				predecessorsSlice, ok := predecessors.([]any)
-				if !ok {
-					return helpers.NewToolResultTextError("invalid predecessors"), nil
-				}
-
-				for _, predecessor := range predecessorsSlice {
-					predecessorMap, ok := predecessor.(map[string]any)
-					if !ok {
-						return helpers.NewToolResultTextError("invalid predecessors"), nil
-					}
-
-					var p projects.TaskPredecessor
-					err = helpers.ParamGroup(predecessorMap,
-						helpers.RequiredNumericParam(&p.ID, "task_id"),
-						helpers.RequiredParam(&p.Type, "type",
-							helpers.RestrictValues(
-								projects.TaskPredecessorTypeStart,
-								projects.TaskPredecessorTypeFinish,
-							),
-						),
-					)
-					if err != nil {
-						return helpers.NewToolResultTextError(fmt.Sprintf("invalid predecessor: %s", err)), nil
-					}
-
-					taskUpdateRequest.Predecessors = append(taskUpdateRequest.Predecessors, p)
-				}
+			taskUpdateRequest.Path.ID = input.ID
+			taskUpdateRequest.TasklistID = input.TasklistID
+			taskUpdateRequest.Name = input.Name
+			taskUpdateRequest.Description = input.Description
+			taskUpdateRequest.Priority = input.Priority
+			taskUpdateRequest.Progress = input.Progress
+			taskUpdateRequest.StartAt = input.StartDate
+			taskUpdateRequest.DueAt = input.DueDate
+			taskUpdateRequest.EstimatedMinutes = input.EstimatedMinutes
+			taskUpdateRequest.ParentTaskID = input.ParentTaskID
+			taskUpdateRequest.TagIDs = input.TagIDs
+			taskUpdateRequest.Predecessors = taskPredecessors(input.Predecessors)
+			if input.Assignees != nil {
+				assignees := input.Assignees.userGroups()
+				taskUpdateRequest.Assignees = &assignees
 			}
 
-			_, err = projects.TaskUpdate(ctx, engine, taskUpdateRequest)
+			_, err := projects.TaskUpdate(ctx, engine, taskUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update task")
 			}
 			return helpers.NewToolResultText("Task updated successfully"), nil
 		},
-	}
+		taskSchemaOptions()...,
+	)
+}
+
+type taskDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the task to delete."`
 }
 
 // TaskDelete deletes a task in Teamwork.com.
 func TaskDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskDelete),
 			Description: "Delete an existing task in Teamwork.com. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Task",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the task to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskDeleteInput) (*mcp.CallToolResult, error) {
 			var taskDeleteRequest projects.TaskDeleteRequest
+			taskDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&taskDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TaskDelete(ctx, engine, taskDeleteRequest)
+			_, err := projects.TaskDelete(ctx, engine, taskDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete task")
 			}
 			return helpers.NewToolResultText("Task deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type taskGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the task to get."`
 }
 
 // TaskGet retrieves a task in Teamwork.com.
 func TaskGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskGet),
 			Description: "Get an existing task in Teamwork.com. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Task",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the task to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: taskGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskGetInput) (*mcp.CallToolResult, error) {
 			var taskGetRequest projects.TaskGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&taskGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			taskGetRequest.Path.ID = input.ID
 
 			task, err := projects.TaskGet(ctx, engine, taskGetRequest)
 			if err != nil {
@@ -560,71 +281,39 @@ func TaskGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: task,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type taskListInput struct {
+	paginationInput
+
+	SearchTerm      string  `json:"search_term,omitempty" jsonschema:"A search term to filter tasks by name."`
+	TagIDs          []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter tasks by tags"`
+	AssigneeUserIDs []int64 `json:"assignee_user_ids,omitempty" jsonschema:"A list of user IDs to filter tasks by assigned users"`
+	MatchAllTags    *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match tasks that have all the specified tags. If false, the search will match tasks that have any of the specified tags. Defaults to false."`
 }
 
 // TaskList lists tasks in Teamwork.com.
 func TaskList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskList),
 			Description: "List tasks in Teamwork.com. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tasks",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter tasks by name.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter tasks by tags",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"assignee_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter tasks by assigned users",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match tasks that have all the specified tags. If false, the " +
-							"search will match tasks that have any of the specified tags. Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: taskListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskListInput) (*mcp.CallToolResult, error) {
 			var taskListRequest projects.TaskListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&taskListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.AssigneeUserIDs, "assignee_user_ids"),
-				helpers.OptionalPointerParam(&taskListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			taskListRequest.Filters.SearchTerm = input.SearchTerm
+			taskListRequest.Filters.TagIDs = input.TagIDs
+			taskListRequest.Filters.AssigneeUserIDs = input.AssigneeUserIDs
+			taskListRequest.Filters.MatchAllTags = input.MatchAllTags
+			taskListRequest.Filters.Page = input.Page
+			taskListRequest.Filters.PageSize = input.PageSize
 
 			taskList, err := projects.TaskList(ctx, engine, taskListRequest)
 			if err != nil {
@@ -646,77 +335,41 @@ func TaskList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: taskList,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type taskListByTasklistInput struct {
+	paginationInput
+
+	TasklistID      int64   `json:"tasklist_id" jsonschema:"The ID of the tasklist from which to retrieve tasks."`
+	SearchTerm      string  `json:"search_term,omitempty" jsonschema:"A search term to filter tasks by name."`
+	TagIDs          []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter tasks by tags"`
+	AssigneeUserIDs []int64 `json:"assignee_user_ids,omitempty" jsonschema:"A list of user IDs to filter tasks by assigned users"`
+	MatchAllTags    *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match tasks that have all the specified tags. If false, the search will match tasks that have any of the specified tags. Defaults to false."`
 }
 
 // TaskListByTasklist lists tasks in Teamwork.com by tasklist.
 func TaskListByTasklist(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskListByTasklist),
 			Description: "List tasks in Teamwork.com by tasklist. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tasks By Tasklist",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"tasklist_id": {
-						Type:        "integer",
-						Description: "The ID of the tasklist from which to retrieve tasks.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter tasks by name.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter tasks by tags",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"assignee_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter tasks by assigned users",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match tasks that have all the specified tags. If false, the " +
-							"search will match tasks that have any of the specified tags. Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"tasklist_id"},
-			},
 			OutputSchema: taskListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskListByTasklistInput) (*mcp.CallToolResult, error) {
 			var taskListRequest projects.TaskListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&taskListRequest.Path.TasklistID, "tasklist_id"),
-				helpers.OptionalParam(&taskListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.AssigneeUserIDs, "assignee_user_ids"),
-				helpers.OptionalPointerParam(&taskListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			taskListRequest.Path.TasklistID = input.TasklistID
+			taskListRequest.Filters.SearchTerm = input.SearchTerm
+			taskListRequest.Filters.TagIDs = input.TagIDs
+			taskListRequest.Filters.AssigneeUserIDs = input.AssigneeUserIDs
+			taskListRequest.Filters.MatchAllTags = input.MatchAllTags
+			taskListRequest.Filters.Page = input.Page
+			taskListRequest.Filters.PageSize = input.PageSize
 
 			taskList, err := projects.TaskList(ctx, engine, taskListRequest)
 			if err != nil {
@@ -738,77 +391,41 @@ func TaskListByTasklist(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: taskList,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type taskListByProjectInput struct {
+	paginationInput
+
+	ProjectID       int64   `json:"project_id" jsonschema:"The ID of the project from which to retrieve tasks."`
+	SearchTerm      string  `json:"search_term,omitempty" jsonschema:"A search term to filter tasks by name."`
+	TagIDs          []int64 `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter tasks by tags"`
+	AssigneeUserIDs []int64 `json:"assignee_user_ids,omitempty" jsonschema:"A list of user IDs to filter tasks by assigned users"`
+	MatchAllTags    *bool   `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match tasks that have all the specified tags. If false, the search will match tasks that have any of the specified tags. Defaults to false."`
 }
 
 // TaskListByProject lists tasks in Teamwork.com by project.
 func TaskListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTaskListByProject),
 			Description: "List tasks in Teamwork.com by project. " + taskDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Tasks By Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve tasks.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter tasks by name.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter tasks by tags",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"assignee_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter tasks by assigned users",
-						Items:       &jsonschema.Schema{Type: "integer"},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match tasks that have all the specified tags. If false, the " +
-							"search will match tasks that have any of the specified tags. Defaults to false.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: taskListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input taskListByProjectInput) (*mcp.CallToolResult, error) {
 			var taskListRequest projects.TaskListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&taskListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalParam(&taskListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalNumericListParam(&taskListRequest.Filters.AssigneeUserIDs, "assignee_user_ids"),
-				helpers.OptionalPointerParam(&taskListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&taskListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			taskListRequest.Path.ProjectID = input.ProjectID
+			taskListRequest.Filters.SearchTerm = input.SearchTerm
+			taskListRequest.Filters.TagIDs = input.TagIDs
+			taskListRequest.Filters.AssigneeUserIDs = input.AssigneeUserIDs
+			taskListRequest.Filters.MatchAllTags = input.MatchAllTags
+			taskListRequest.Filters.Page = input.Page
+			taskListRequest.Filters.PageSize = input.PageSize
 
 			taskList, err := projects.TaskList(ctx, engine, taskListRequest)
 			if err != nil {
@@ -830,5 +447,5 @@ func TaskListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: taskList,
 			}, nil
 		},
-	}
+	)
 }
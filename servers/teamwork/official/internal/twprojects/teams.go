@@ -61,72 +61,37 @@ func init() {
 	}
 }
 
+//nolint:lll
+type teamCreateInput struct {
+	Name         string  `json:"name" jsonschema:"The name of the team."`
+	Handle       *string `json:"handle,omitempty" jsonschema:"The handle of the team. It is a unique identifier for the team. It must not have spaces or special characters."`
+	Description  *string `json:"description,omitempty" jsonschema:"The description of the team."`
+	ParentTeamID *int64  `json:"parent_team_id,omitempty" jsonschema:"The ID of the parent team. This is used to create a hierarchy of teams."`
+	CompanyID    *int64  `json:"company_id,omitempty" jsonschema:"The ID of the company. This is used to create a team scoped for a specific company."`
+	ProjectID    *int64  `json:"project_id,omitempty" jsonschema:"The ID of the project. This is used to create a team scoped for a specific project."`
+	UserIDs      []int64 `json:"user_ids,omitempty" jsonschema:"A list of user IDs to add to the team."`
+}
+
 // TeamCreate creates a team in Teamwork.com.
 func TeamCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamCreate),
 			Description: "Create a new team in Teamwork.com. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Team",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"name": {
-						Type:        "string",
-						Description: "The name of the team.",
-					},
-					"handle": {
-						Type: "string",
-						Description: "The handle of the team. It is a unique identifier for the team. It must not have spaces " +
-							"or special characters.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the team.",
-					},
-					"parent_team_id": {
-						Type:        "integer",
-						Description: "The ID of the parent team. This is used to create a hierarchy of teams.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the company. This is used to create a team scoped for a specific company.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project. This is used to create a team scoped for a specific project.",
-					},
-					"user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to add to the team.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"name"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamCreateInput) (*mcp.CallToolResult, error) {
 			var teamCreateRequest projects.TeamCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&teamCreateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&teamCreateRequest.Handle, "handle"),
-				helpers.OptionalPointerParam(&teamCreateRequest.Description, "description"),
-				helpers.OptionalNumericPointerParam(&teamCreateRequest.ParentTeamID, "parent_team_id"),
-				helpers.OptionalNumericPointerParam(&teamCreateRequest.CompanyID, "company_id"),
-				helpers.OptionalNumericPointerParam(&teamCreateRequest.ProjectID, "project_id"),
-				helpers.OptionalCustomNumericListParam(&teamCreateRequest.UserIDs, "user_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
+			teamCreateRequest.Name = input.Name
+			teamCreateRequest.Handle = input.Handle
+			teamCreateRequest.Description = input.Description
+			teamCreateRequest.ParentTeamID = input.ParentTeamID
+			teamCreateRequest.CompanyID = input.CompanyID
+			teamCreateRequest.ProjectID = input.ProjectID
+			for _, id := range input.UserIDs {
+				teamCreateRequest.UserIDs.Add(float64(id))
 			}
 
 			team, err := projects.TeamCreate(ctx, engine, teamCreateRequest)
@@ -135,168 +100,96 @@ func TeamCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Team created successfully with ID %d", team.ID), nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type teamUpdateInput struct {
+	ID          int64   `json:"id" jsonschema:"The ID of the team to update."`
+	Name        *string `json:"name,omitempty" jsonschema:"The name of the team."`
+	Handle      *string `json:"handle,omitempty" jsonschema:"The handle of the team. It is a unique identifier for the team. It must not have spaces or special characters."`
+	Description *string `json:"description,omitempty" jsonschema:"The description of the team."`
+	CompanyID   *int64  `json:"company_id,omitempty" jsonschema:"The ID of the company. This is used to create a team scoped for a specific company."`
+	ProjectID   *int64  `json:"project_id,omitempty" jsonschema:"The ID of the project. This is used to create a team scoped for a specific project."`
+	UserIDs     []int64 `json:"user_ids,omitempty" jsonschema:"A list of user IDs to add to the team."`
 }
 
 // TeamUpdate updates a team in Teamwork.com.
 func TeamUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamUpdate),
 			Description: "Update an existing team in Teamwork.com. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Team",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the team to update.",
-					},
-					"name": {
-						Type:        "string",
-						Description: "The name of the team.",
-					},
-					"handle": {
-						Type: "string",
-						Description: "The handle of the team. It is a unique identifier for the team. It must not have spaces " +
-							"or special characters.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "The description of the team.",
-					},
-					"parent_team_id": {
-						Type:        "integer",
-						Description: "The ID of the parent team. This is used to create a hierarchy of teams.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the company. This is used to create a team scoped for a specific company.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project. This is used to create a team scoped for a specific project.",
-					},
-					"user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to add to the team.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			var teamUpdateRequest projects.TeamUpdateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&teamUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&teamUpdateRequest.Name, "name"),
-				helpers.OptionalPointerParam(&teamUpdateRequest.Handle, "handle"),
-				helpers.OptionalPointerParam(&teamUpdateRequest.Description, "description"),
-				helpers.OptionalNumericPointerParam(&teamUpdateRequest.CompanyID, "company_id"),
-				helpers.OptionalNumericPointerParam(&teamUpdateRequest.ProjectID, "project_id"),
-				helpers.OptionalCustomNumericListParam(&teamUpdateRequest.UserIDs, "user_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamUpdateInput) (*mcp.CallToolResult, error) {
+			teamUpdateRequest := projects.NewTeamUpdateRequest(input.ID)
+			teamUpdateRequest.Name = input.Name
+			teamUpdateRequest.Handle = input.Handle
+			teamUpdateRequest.Description = input.Description
+			teamUpdateRequest.CompanyID = input.CompanyID
+			teamUpdateRequest.ProjectID = input.ProjectID
+			for _, id := range input.UserIDs {
+				teamUpdateRequest.UserIDs.Add(float64(id))
 			}
 
-			_, err = projects.TeamUpdate(ctx, engine, teamUpdateRequest)
+			_, err := projects.TeamUpdate(ctx, engine, teamUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update team")
 			}
 			return helpers.NewToolResultText("Team updated successfully"), nil
 		},
-	}
+	)
+}
+
+type teamDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the team to delete."`
 }
 
 // TeamDelete deletes a team in Teamwork.com.
 func TeamDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamDelete),
 			Description: "Delete an existing team in Teamwork.com. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Team",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the team to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamDeleteInput) (*mcp.CallToolResult, error) {
 			var teamDeleteRequest projects.TeamDeleteRequest
+			teamDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&teamDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TeamDelete(ctx, engine, teamDeleteRequest)
+			_, err := projects.TeamDelete(ctx, engine, teamDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete team")
 			}
 			return helpers.NewToolResultText("Team deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type teamGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the team to get."`
 }
 
 // TeamGet retrieves a team in Teamwork.com.
 func TeamGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamGet),
 			Description: "Get an existing team in Teamwork.com. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Team",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the team to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: teamGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamGetInput) (*mcp.CallToolResult, error) {
 			var teamGetRequest projects.TeamGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&teamGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			teamGetRequest.Path.ID = input.ID
 
 			team, err := projects.TeamGet(ctx, engine, teamGetRequest)
 			if err != nil {
@@ -318,57 +211,42 @@ func TeamGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: team,
 			}, nil
 		},
-	}
+	)
+}
+
+type teamListInput struct {
+	paginationInput
+
+	SearchTerm *string `json:"search_term,omitempty" jsonschema:"A search term to filter teams by name or handle."`
 }
 
 // TeamList lists teams in Teamwork.com.
 func TeamList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamList),
 			Description: "List teams in Teamwork.com. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Teams",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter teams by name or handle.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: teamListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamListInput) (*mcp.CallToolResult, error) {
 			var teamListRequest projects.TeamListRequest
 
 			// to simplify the teams logic for the LLM, always return all team types
 			teamListRequest.Filters.IncludeCompanyTeams = true
 			teamListRequest.Filters.IncludeProjectTeams = true
 			teamListRequest.Filters.IncludeSubteams = true
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
+			if input.SearchTerm != nil {
+				teamListRequest.Filters.SearchTerm = *input.SearchTerm
 			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&teamListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
+			if input.Page > 0 {
+				teamListRequest.Filters.Page = input.Page
+			}
+			if input.PageSize > 0 {
+				teamListRequest.Filters.PageSize = input.PageSize
 			}
 
 			teamList, err := projects.TeamList(ctx, engine, teamListRequest)
@@ -391,59 +269,34 @@ func TeamList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: teamList,
 			}, nil
 		},
-	}
+	)
+}
+
+type teamListByCompanyInput struct {
+	paginationInput
+
+	CompanyID  int64  `json:"company_id" jsonschema:"The ID of the company from which to retrieve teams."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter teams by name or handle."`
 }
 
 // TeamListByCompany lists teams in Teamwork.com by client/company.
 func TeamListByCompany(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamListByCompany),
 			Description: "List teams in Teamwork.com by client/company. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Teams By Company",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the company from which to retrieve teams.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter teams by name or handle.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"company_id"},
-			},
 			OutputSchema: teamListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamListByCompanyInput) (*mcp.CallToolResult, error) {
 			var teamListRequest projects.TeamListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&teamListRequest.Path.CompanyID, "company_id"),
-				helpers.OptionalParam(&teamListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			teamListRequest.Path.CompanyID = input.CompanyID
+			teamListRequest.Filters.SearchTerm = input.SearchTerm
+			teamListRequest.Filters.Page = input.Page
+			teamListRequest.Filters.PageSize = input.PageSize
 
 			teamList, err := projects.TeamList(ctx, engine, teamListRequest)
 			if err != nil {
@@ -465,59 +318,34 @@ func TeamListByCompany(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: teamList,
 			}, nil
 		},
-	}
+	)
+}
+
+type teamListByProjectInput struct {
+	paginationInput
+
+	ProjectID  int64  `json:"project_id" jsonschema:"The ID of the project from which to retrieve teams."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter teams by name or handle."`
 }
 
 // TeamListByProject lists teams in Teamwork.com by project.
 func TeamListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTeamListByProject),
 			Description: "List teams in Teamwork.com by project. " + teamDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Teams By Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve teams.",
-					},
-					"search_term": {
-						Type:        "string",
-						Description: "A search term to filter teams by name or handle.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: teamListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input teamListByProjectInput) (*mcp.CallToolResult, error) {
 			var teamListRequest projects.TeamListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&teamListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalParam(&teamListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&teamListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			teamListRequest.Path.ProjectID = input.ProjectID
+			teamListRequest.Filters.SearchTerm = input.SearchTerm
+			teamListRequest.Filters.Page = input.Page
+			teamListRequest.Filters.PageSize = input.PageSize
 
 			teamList, err := projects.TeamList(ctx, engine, teamListRequest)
 			if err != nil {
@@ -539,5 +367,5 @@ func TeamListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: teamList,
 			}, nil
 		},
-	}
+	)
 }
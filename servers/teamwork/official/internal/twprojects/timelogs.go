@@ -2,8 +2,8 @@ package twprojects
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -62,98 +62,44 @@ func init() {
 	}
 }
 
+//nolint:lll
+type timelogCreateInput struct {
+	Description *string    `json:"description,omitempty" jsonschema:"A description of the timelog."`
+	Date        twapi.Date `json:"date" jsonschema:"The date of the timelog in the format YYYY-MM-DD."`
+	Time        twapi.Time `json:"time" jsonschema:"The time of the timelog in the format HH:MM:SS."`
+	IsUTC       bool       `json:"is_utc,omitempty" jsonschema:"If true, the time is in UTC. Defaults to false."`
+	Hours       int64      `json:"hours" jsonschema:"The number of hours spent on the timelog. Must be a positive integer."`
+	Minutes     int64      `json:"minutes" jsonschema:"The number of minutes spent on the timelog. Must be a positive integer less than 60, otherwise the hours attribute should be incremented."`
+	Billable    bool       `json:"billable,omitempty" jsonschema:"If true, the timelog is billable. Defaults to false."`
+	ProjectID   int64      `json:"project_id,omitempty" jsonschema:"The ID of the project to associate the timelog with. Either project_id or task_id must be provided, but not both."`
+	TaskID      int64      `json:"task_id,omitempty" jsonschema:"The ID of the task to associate the timelog with. Either project_id or task_id must be provided, but not both."`
+	UserID      *int64     `json:"user_id,omitempty" jsonschema:"The ID of the user to associate the timelog with. Defaults to the authenticated user if not provided."`
+	TagIDs      []int64    `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the timelog."`
+}
+
 // TimelogCreate creates a timelog in Teamwork.com.
 func TimelogCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogCreate),
 			Description: "Create a new timelog in Teamwork.com. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Timelog",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"description": {
-						Type:        "string",
-						Description: "A description of the timelog.",
-					},
-					"date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The date of the timelog in the format YYYY-MM-DD.",
-					},
-					"time": {
-						Type:        "string",
-						Pattern:     `^(?:[01]\d|2[0-3]):[0-5]\d:[0-5]\d$`,
-						Description: "The time of the timelog in the format HH:MM:SS.",
-					},
-					"is_utc": {
-						Type:        "boolean",
-						Description: "If true, the time is in UTC. Defaults to false.",
-					},
-					"hours": {
-						Type:        "integer",
-						Description: "The number of hours spent on the timelog. Must be a positive integer.",
-					},
-					"minutes": {
-						Type: "integer",
-						Description: "The number of minutes spent on the timelog. Must be a positive integer less than 60, " +
-							"otherwise the hours attribute should be incremented.",
-					},
-					"billable": {
-						Type:        "boolean",
-						Description: "If true, the timelog is billable. Defaults to false.",
-					},
-					"project_id": {
-						Type: "integer",
-						Description: "The ID of the project to associate the timelog with. Either project_id or task_id must be " +
-							"provided, but not both.",
-					},
-					"task_id": {
-						Type: "integer",
-						Description: "The ID of the task to associate the timelog with. Either project_id or task_id must be " +
-							"provided, but not both.",
-					},
-					"user_id": {
-						Type: "integer",
-						Description: "The ID of the user to associate the timelog with. Defaults to the authenticated user if " +
-							"not provided.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the timelog.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"date", "time", "hours", "minutes"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogCreateInput) (*mcp.CallToolResult, error) {
 			var timelogCreateRequest projects.TimelogCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalNumericParam(&timelogCreateRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalNumericParam(&timelogCreateRequest.Path.TaskID, "task_id"),
-				helpers.OptionalPointerParam(&timelogCreateRequest.Description, "description"),
-				helpers.RequiredDateParam(&timelogCreateRequest.Date, "date"),
-				helpers.RequiredTimeOnlyParam(&timelogCreateRequest.Time, "time"),
-				helpers.OptionalParam(&timelogCreateRequest.IsUTC, "is_utc"),
-				helpers.RequiredNumericParam(&timelogCreateRequest.Hours, "hours"),
-				helpers.RequiredNumericParam(&timelogCreateRequest.Minutes, "minutes"),
-				helpers.OptionalParam(&timelogCreateRequest.Billable, "billable"),
-				helpers.OptionalNumericPointerParam(&timelogCreateRequest.UserID, "user_id"),
-				helpers.OptionalNumericListParam(&timelogCreateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timelogCreateRequest.Path.ProjectID = input.ProjectID
+			timelogCreateRequest.Path.TaskID = input.TaskID
+			timelogCreateRequest.Description = input.Description
+			timelogCreateRequest.Date = input.Date
+			timelogCreateRequest.Time = input.Time
+			timelogCreateRequest.IsUTC = input.IsUTC
+			timelogCreateRequest.Hours = input.Hours
+			timelogCreateRequest.Minutes = input.Minutes
+			timelogCreateRequest.Billable = input.Billable
+			timelogCreateRequest.UserID = input.UserID
+			timelogCreateRequest.TagIDs = input.TagIDs
 
 			timelogResponse, err := projects.TimelogCreate(ctx, engine, timelogCreateRequest)
 			if err != nil {
@@ -161,192 +107,101 @@ func TimelogCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Timelog created successfully with ID %d", timelogResponse.Timelog.ID), nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type timelogUpdateInput struct {
+	ID          int64       `json:"id" jsonschema:"The ID of the timelog to update."`
+	Description *string     `json:"description,omitempty" jsonschema:"A description of the timelog."`
+	Date        *twapi.Date `json:"date,omitempty" jsonschema:"The date of the timelog in the format YYYY-MM-DD."`
+	Time        *twapi.Time `json:"time,omitempty" jsonschema:"The time of the timelog in the format HH:MM:SS."`
+	IsUTC       *bool       `json:"is_utc,omitempty" jsonschema:"If true, the time is in UTC. Defaults to false."`
+	Hours       *int64      `json:"hours,omitempty" jsonschema:"The number of hours spent on the timelog. Must be a positive integer."`
+	Minutes     *int64      `json:"minutes,omitempty" jsonschema:"The number of minutes spent on the timelog. Must be a positive integer less than 60, otherwise the hours attribute should be incremented."`
+	Billable    *bool       `json:"billable,omitempty" jsonschema:"If true, the timelog is billable. Defaults to false."`
+	UserID      *int64      `json:"user_id,omitempty" jsonschema:"The ID of the user to associate the timelog with. Defaults to the authenticated user if not provided."`
+	TagIDs      []int64     `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to associate with the timelog."`
 }
 
 // TimelogUpdate updates a timelog in Teamwork.com.
 func TimelogUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogUpdate),
 			Description: "Update an existing timelog in Teamwork.com. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Timelog",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timelog to update.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the timelog.",
-					},
-					"date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The date of the timelog in the format YYYY-MM-DD.",
-					},
-					"time": {
-						Type:        "string",
-						Pattern:     `^(?:[01]\d|2[0-3]):[0-5]\d:[0-5]\d$`,
-						Description: "The time of the timelog in the format HH:MM:SS.",
-					},
-					"is_utc": {
-						Type:        "boolean",
-						Description: "If true, the time is in UTC. Defaults to false.",
-					},
-					"hours": {
-						Type:        "integer",
-						Description: "The number of hours spent on the timelog. Must be a positive integer.",
-					},
-					"minutes": {
-						Type: "integer",
-						Description: "The number of minutes spent on the timelog. Must be a positive integer less than 60, " +
-							"otherwise the hours attribute should be incremented.",
-					},
-					"billable": {
-						Type:        "boolean",
-						Description: "If true, the timelog is billable. Defaults to false.",
-					},
-					"project_id": {
-						Type: "integer",
-						Description: "The ID of the project to associate the timelog with. Either project_id or task_id must be " +
-							"provided, but not both.",
-					},
-					"task_id": {
-						Type: "integer",
-						Description: "The ID of the task to associate the timelog with. Either project_id or task_id must be " +
-							"provided, but not both.",
-					},
-					"user_id": {
-						Type: "integer",
-						Description: "The ID of the user to associate the timelog with. Defaults to the authenticated user if " +
-							"not provided.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to associate with the timelog.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogUpdateInput) (*mcp.CallToolResult, error) {
 			var timelogUpdateRequest projects.TimelogUpdateRequest
+			timelogUpdateRequest.Path.ID = input.ID
+			timelogUpdateRequest.Description = input.Description
+			timelogUpdateRequest.Date = input.Date
+			timelogUpdateRequest.Time = input.Time
+			timelogUpdateRequest.IsUTC = input.IsUTC
+			timelogUpdateRequest.Hours = input.Hours
+			timelogUpdateRequest.Minutes = input.Minutes
+			timelogUpdateRequest.Billable = input.Billable
+			timelogUpdateRequest.UserID = input.UserID
+			timelogUpdateRequest.TagIDs = input.TagIDs
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timelogUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&timelogUpdateRequest.Description, "description"),
-				helpers.OptionalDatePointerParam(&timelogUpdateRequest.Date, "date"),
-				helpers.OptionalTimeOnlyPointerParam(&timelogUpdateRequest.Time, "time"),
-				helpers.OptionalPointerParam(&timelogUpdateRequest.IsUTC, "is_utc"),
-				helpers.OptionalNumericPointerParam(&timelogUpdateRequest.Hours, "hours"),
-				helpers.OptionalNumericPointerParam(&timelogUpdateRequest.Minutes, "minutes"),
-				helpers.OptionalPointerParam(&timelogUpdateRequest.Billable, "billable"),
-				helpers.OptionalNumericPointerParam(&timelogUpdateRequest.UserID, "user_id"),
-				helpers.OptionalNumericListParam(&timelogUpdateRequest.TagIDs, "tag_ids"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimelogUpdate(ctx, engine, timelogUpdateRequest)
+			_, err := projects.TimelogUpdate(ctx, engine, timelogUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update timelog")
 			}
 			return helpers.NewToolResultText("Timelog updated successfully"), nil
 		},
-	}
+	)
+}
+
+type timelogDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timelog to delete."`
 }
 
 // TimelogDelete deletes a timelog in Teamwork.com.
 func TimelogDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogDelete),
 			Description: "Delete an existing timelog in Teamwork.com. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Timelog",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timelog to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogDeleteInput) (*mcp.CallToolResult, error) {
 			var timelogDeleteRequest projects.TimelogDeleteRequest
+			timelogDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timelogDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimelogDelete(ctx, engine, timelogDeleteRequest)
+			_, err := projects.TimelogDelete(ctx, engine, timelogDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete timelog")
 			}
 			return helpers.NewToolResultText("Timelog deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type timelogGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timelog to get."`
 }
 
 // TimelogGet retrieves a timelog in Teamwork.com.
 func TimelogGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogGet),
 			Description: "Get an existing timelog in Teamwork.com. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Timelog",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timelog to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: timelogGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogGetInput) (*mcp.CallToolResult, error) {
 			var timelogGetRequest projects.TimelogGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timelogGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timelogGetRequest.Path.ID = input.ID
 
 			timelog, err := projects.TimelogGet(ctx, engine, timelogGetRequest)
 			if err != nil {
@@ -354,98 +209,45 @@ func TimelogGet(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(timelog)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type timelogListInput struct {
+	paginationInput
+
+	TagIDs             []int64    `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter timelogs by tags"`
+	MatchAllTags       *bool      `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match timelogs that have all the specified tags. If false, the search will match timelogs that have any of the specified tags. Defaults to false."`
+	StartDate          *time.Time `json:"start_date,omitempty" jsonschema:"Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	EndDate            *time.Time `json:"end_date,omitempty" jsonschema:"End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	AssignedUserIDs    []int64    `json:"assigned_user_ids,omitempty" jsonschema:"A list of user IDs to filter timelogs by assigned users"`
+	AssignedCompanyIDs []int64    `json:"assigned_company_ids,omitempty" jsonschema:"A list of company IDs to filter timelogs by assigned companies"`
+	AssignedTeamIDs    []int64    `json:"assigned_team_ids,omitempty" jsonschema:"A list of team IDs to filter timelogs by assigned teams"`
 }
 
 // TimelogList lists timelogs in Teamwork.com.
 func TimelogList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogList),
 			Description: "List timelogs in Teamwork.com. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Timelogs",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter timelogs by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match timelogs that have all the specified tags. If false, the " +
-							"search will match timelogs that have any of the specified tags. Defaults to false.",
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"assigned_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter timelogs by assigned users",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_company_ids": {
-						Type:        "array",
-						Description: "A list of company IDs to filter timelogs by assigned companies",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_team_ids": {
-						Type:        "array",
-						Description: "A list of team IDs to filter timelogs by assigned teams",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: timelogListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogListInput) (*mcp.CallToolResult, error) {
 			var timelogListRequest projects.TimelogListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&timelogListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.StartDate, "start_date"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToUserIDs, "assigned_user_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToCompanyIDs, "assigned_company_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToTeamIDs, "assigned_team_ids"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timelogListRequest.Filters.TagIDs = input.TagIDs
+			timelogListRequest.Filters.MatchAllTags = input.MatchAllTags
+			timelogListRequest.Filters.StartDate = input.StartDate
+			timelogListRequest.Filters.EndDate = input.EndDate
+			timelogListRequest.Filters.AssignedToUserIDs = input.AssignedUserIDs
+			timelogListRequest.Filters.AssignedToCompanyIDs = input.AssignedCompanyIDs
+			timelogListRequest.Filters.AssignedToTeamIDs = input.AssignedTeamIDs
+			timelogListRequest.Filters.Page = input.Page
+			timelogListRequest.Filters.PageSize = input.PageSize
 
 			timelogList, err := projects.TimelogList(ctx, engine, timelogListRequest)
 			if err != nil {
@@ -453,104 +255,47 @@ func TimelogList(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(timelogList)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type timelogListByProjectInput struct {
+	paginationInput
+
+	ProjectID          int64      `json:"project_id" jsonschema:"The ID of the project from which to retrieve timelogs."`
+	TagIDs             []int64    `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter timelogs by tags"`
+	MatchAllTags       *bool      `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match timelogs that have all the specified tags. If false, the search will match timelogs that have any of the specified tags. Defaults to false."`
+	StartDate          *time.Time `json:"start_date,omitempty" jsonschema:"Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	EndDate            *time.Time `json:"end_date,omitempty" jsonschema:"End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	AssignedUserIDs    []int64    `json:"assigned_user_ids,omitempty" jsonschema:"A list of user IDs to filter timelogs by assigned users"`
+	AssignedCompanyIDs []int64    `json:"assigned_company_ids,omitempty" jsonschema:"A list of company IDs to filter timelogs by assigned companies"`
+	AssignedTeamIDs    []int64    `json:"assigned_team_ids,omitempty" jsonschema:"A list of team IDs to filter timelogs by assigned teams"`
 }
 
 // TimelogListByProject lists timelogs in Teamwork.com by project.
 func TimelogListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogListByProject),
 			Description: "List timelogs in Teamwork.com by project. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Timelogs By Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve timelogs.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter timelogs by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match timelogs that have all the specified tags. If false, the " +
-							"search will match timelogs that have any of the specified tags. Defaults to false.",
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"assigned_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter timelogs by assigned users",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_company_ids": {
-						Type:        "array",
-						Description: "A list of company IDs to filter timelogs by assigned companies",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_team_ids": {
-						Type:        "array",
-						Description: "A list of team IDs to filter timelogs by assigned teams",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: timelogListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogListByProjectInput) (*mcp.CallToolResult, error) {
 			var timelogListRequest projects.TimelogListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timelogListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&timelogListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.StartDate, "start_date"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToUserIDs, "assigned_user_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToCompanyIDs, "assigned_company_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToTeamIDs, "assigned_team_ids"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timelogListRequest.Path.ProjectID = input.ProjectID
+			timelogListRequest.Filters.TagIDs = input.TagIDs
+			timelogListRequest.Filters.MatchAllTags = input.MatchAllTags
+			timelogListRequest.Filters.StartDate = input.StartDate
+			timelogListRequest.Filters.EndDate = input.EndDate
+			timelogListRequest.Filters.AssignedToUserIDs = input.AssignedUserIDs
+			timelogListRequest.Filters.AssignedToCompanyIDs = input.AssignedCompanyIDs
+			timelogListRequest.Filters.AssignedToTeamIDs = input.AssignedTeamIDs
+			timelogListRequest.Filters.Page = input.Page
+			timelogListRequest.Filters.PageSize = input.PageSize
 
 			timelogList, err := projects.TimelogList(ctx, engine, timelogListRequest)
 			if err != nil {
@@ -558,104 +303,47 @@ func TimelogListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(timelogList)
 		},
-	}
+	)
+}
+
+//nolint:lll
+type timelogListByTaskInput struct {
+	paginationInput
+
+	TaskID             int64      `json:"task_id" jsonschema:"The ID of the task from which to retrieve timelogs."`
+	TagIDs             []int64    `json:"tag_ids,omitempty" jsonschema:"A list of tag IDs to filter timelogs by tags"`
+	MatchAllTags       *bool      `json:"match_all_tags,omitempty" jsonschema:"If true, the search will match timelogs that have all the specified tags. If false, the search will match timelogs that have any of the specified tags. Defaults to false."`
+	StartDate          *time.Time `json:"start_date,omitempty" jsonschema:"Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	EndDate            *time.Time `json:"end_date,omitempty" jsonschema:"End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ."`
+	AssignedUserIDs    []int64    `json:"assigned_user_ids,omitempty" jsonschema:"A list of user IDs to filter timelogs by assigned users"`
+	AssignedCompanyIDs []int64    `json:"assigned_company_ids,omitempty" jsonschema:"A list of company IDs to filter timelogs by assigned companies"`
+	AssignedTeamIDs    []int64    `json:"assigned_team_ids,omitempty" jsonschema:"A list of team IDs to filter timelogs by assigned teams"`
 }
 
 // TimelogListByTask lists timelogs in Teamwork.com by task.
 func TimelogListByTask(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimelogListByTask),
 			Description: "List timelogs in Teamwork.com by task. " + timelogDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Timelogs By Task",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"task_id": {
-						Type:        "integer",
-						Description: "The ID of the task from which to retrieve timelogs.",
-					},
-					"tag_ids": {
-						Type:        "array",
-						Description: "A list of tag IDs to filter timelogs by tags",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"match_all_tags": {
-						Type: "boolean",
-						Description: "If true, the search will match timelogs that have all the specified tags. If false, the " +
-							"search will match timelogs that have any of the specified tags. Defaults to false.",
-					},
-					"start_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "Start date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date-time",
-						Description: "End date to filter timelogs. The date format follows RFC3339 - YYYY-MM-DDTHH:MM:SSZ.",
-					},
-					"assigned_user_ids": {
-						Type:        "array",
-						Description: "A list of user IDs to filter timelogs by assigned users",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_company_ids": {
-						Type:        "array",
-						Description: "A list of company IDs to filter timelogs by assigned companies",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"assigned_team_ids": {
-						Type:        "array",
-						Description: "A list of team IDs to filter timelogs by assigned teams",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"task_id"},
-			},
 			OutputSchema: timelogListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timelogListByTaskInput) (*mcp.CallToolResult, error) {
 			var timelogListRequest projects.TimelogListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timelogListRequest.Path.TaskID, "task_id"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.TagIDs, "tag_ids"),
-				helpers.OptionalPointerParam(&timelogListRequest.Filters.MatchAllTags, "match_all_tags"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.StartDate, "start_date"),
-				helpers.OptionalTimePointerParam(&timelogListRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToUserIDs, "assigned_user_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToCompanyIDs, "assigned_company_ids"),
-				helpers.OptionalNumericListParam(&timelogListRequest.Filters.AssignedToTeamIDs, "assigned_team_ids"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&timelogListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timelogListRequest.Path.TaskID = input.TaskID
+			timelogListRequest.Filters.TagIDs = input.TagIDs
+			timelogListRequest.Filters.MatchAllTags = input.MatchAllTags
+			timelogListRequest.Filters.StartDate = input.StartDate
+			timelogListRequest.Filters.EndDate = input.EndDate
+			timelogListRequest.Filters.AssignedToUserIDs = input.AssignedUserIDs
+			timelogListRequest.Filters.AssignedToCompanyIDs = input.AssignedCompanyIDs
+			timelogListRequest.Filters.AssignedToTeamIDs = input.AssignedTeamIDs
+			timelogListRequest.Filters.Page = input.Page
+			timelogListRequest.Filters.PageSize = input.PageSize
 
 			timelogList, err := projects.TimelogList(ctx, engine, timelogListRequest)
 			if err != nil {
@@ -663,5 +351,5 @@ func TimelogListByTask(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(timelogList)
 		},
-	}
+	)
 }
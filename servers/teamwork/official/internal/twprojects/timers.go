@@ -64,69 +64,36 @@ func init() {
 	}
 }
 
+//nolint:lll
+type timerCreateInput struct {
+	Description       *string `json:"description,omitempty" jsonschema:"A description of the timer."`
+	Billable          *bool   `json:"billable,omitempty" jsonschema:"If true, the timer is billable. Defaults to false."`
+	Running           *bool   `json:"running,omitempty" jsonschema:"If true, the timer will start running immediately."`
+	Seconds           *int64  `json:"seconds,omitempty" jsonschema:"The number of seconds to set the timer for."`
+	StopRunningTimers *bool   `json:"stop_running_timers,omitempty" jsonschema:"If true, any other running timers will be stopped when this timer is created."`
+	ProjectID         int64   `json:"project_id" jsonschema:"The ID of the project to associate the timer with."`
+	TaskID            *int64  `json:"task_id,omitempty" jsonschema:"The ID of the task to associate the timer with."`
+}
+
 // TimerCreate creates a timer in Teamwork.com.
 func TimerCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerCreate),
 			Description: "Create a new timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"description": {
-						Type:        "string",
-						Description: "A description of the timer.",
-					},
-					"billable": {
-						Type:        "boolean",
-						Description: "If true, the timer is billable. Defaults to false.",
-					},
-					"running": {
-						Type:        "boolean",
-						Description: "If true, the timer will start running immediately.",
-					},
-					"seconds": {
-						Type:        "integer",
-						Description: "The number of seconds to set the timer for.",
-					},
-					"stop_running_timers": {
-						Type:        "boolean",
-						Description: "If true, any other running timers will be stopped when this timer is created.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to associate the timer with.",
-					},
-					"task_id": {
-						Type:        "integer",
-						Description: "The ID of the task to associate the timer with.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerCreateInput) (*mcp.CallToolResult, error) {
 			var timerCreateRequest projects.TimerCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalPointerParam(&timerCreateRequest.Description, "description"),
-				helpers.OptionalPointerParam(&timerCreateRequest.Billable, "billable"),
-				helpers.OptionalPointerParam(&timerCreateRequest.Running, "running"),
-				helpers.OptionalNumericPointerParam(&timerCreateRequest.Seconds, "seconds"),
-				helpers.OptionalPointerParam(&timerCreateRequest.StopRunningTimers, "stop_running_timers"),
-				helpers.RequiredNumericParam(&timerCreateRequest.ProjectID, "project_id"),
-				helpers.OptionalNumericPointerParam(&timerCreateRequest.TaskID, "task_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timerCreateRequest.Description = input.Description
+			timerCreateRequest.Billable = input.Billable
+			timerCreateRequest.Running = input.Running
+			timerCreateRequest.Seconds = input.Seconds
+			timerCreateRequest.StopRunningTimers = input.StopRunningTimers
+			timerCreateRequest.ProjectID = input.ProjectID
+			timerCreateRequest.TaskID = input.TaskID
 
 			timerResponse, err := projects.TimerCreate(ctx, engine, timerCreateRequest)
 			if err != nil {
@@ -134,284 +101,173 @@ func TimerCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("Timer created successfully with ID %d", timerResponse.Timer.ID), nil
 		},
-	}
+	)
+}
+
+type timerUpdateInput struct {
+	ID          int64   `json:"id" jsonschema:"The ID of the timer to update."`
+	Description *string `json:"description,omitempty" jsonschema:"A description of the timer."`
+	Billable    *bool   `json:"billable,omitempty" jsonschema:"If true, the timer is billable."`
+	Running     *bool   `json:"running,omitempty" jsonschema:"If true, the timer will start running immediately."`
+	ProjectID   *int64  `json:"project_id,omitempty" jsonschema:"The ID of the project to associate the timer with."`
+	TaskID      *int64  `json:"task_id,omitempty" jsonschema:"The ID of the task to associate the timer with."`
 }
 
 // TimerUpdate updates a timer in Teamwork.com.
 func TimerUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerUpdate),
 			Description: "Update an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to update.",
-					},
-					"description": {
-						Type:        "string",
-						Description: "A description of the timer.",
-					},
-					"billable": {
-						Type:        "boolean",
-						Description: "If true, the timer is billable.",
-					},
-					"running": {
-						Type:        "boolean",
-						Description: "If true, the timer will start running immediately.",
-					},
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project to associate the timer with.",
-					},
-					"task_id": {
-						Type:        "integer",
-						Description: "The ID of the task to associate the timer with.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerUpdateInput) (*mcp.CallToolResult, error) {
 			var timerUpdateRequest projects.TimerUpdateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&timerUpdateRequest.Description, "description"),
-				helpers.OptionalPointerParam(&timerUpdateRequest.Billable, "billable"),
-				helpers.OptionalPointerParam(&timerUpdateRequest.Running, "running"),
-				helpers.OptionalNumericPointerParam(&timerUpdateRequest.ProjectID, "project_id"),
-				helpers.OptionalNumericPointerParam(&timerUpdateRequest.TaskID, "task_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimerUpdate(ctx, engine, timerUpdateRequest)
+			timerUpdateRequest.Path.ID = input.ID
+			timerUpdateRequest.Description = input.Description
+			timerUpdateRequest.Billable = input.Billable
+			timerUpdateRequest.Running = input.Running
+			timerUpdateRequest.ProjectID = input.ProjectID
+			timerUpdateRequest.TaskID = input.TaskID
+
+			_, err := projects.TimerUpdate(ctx, engine, timerUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update timer")
 			}
 			return helpers.NewToolResultText("Timer updated successfully"), nil
 		},
-	}
+	)
+}
+
+type timerPauseInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timer to pause."`
 }
 
 // TimerPause pauses a timer in Teamwork.com.
 func TimerPause(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerPause),
 			Description: "Pause an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Pause Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to pause.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerPauseInput) (*mcp.CallToolResult, error) {
 			var timerPauseRequest projects.TimerPauseRequest
+			timerPauseRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerPauseRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimerPause(ctx, engine, timerPauseRequest)
+			_, err := projects.TimerPause(ctx, engine, timerPauseRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to pause timer")
 			}
 			return helpers.NewToolResultText("Timer paused successfully"), nil
 		},
-	}
+	)
+}
+
+type timerResumeInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timer to resume."`
 }
 
 // TimerResume resumes a timer in Teamwork.com.
 func TimerResume(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerResume),
 			Description: "Resume an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Resume Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to resume.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerResumeInput) (*mcp.CallToolResult, error) {
 			var timerResumeRequest projects.TimerResumeRequest
+			timerResumeRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerResumeRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimerResume(ctx, engine, timerResumeRequest)
+			_, err := projects.TimerResume(ctx, engine, timerResumeRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to resume timer")
 			}
 			return helpers.NewToolResultText("Timer resumed successfully"), nil
 		},
-	}
+	)
+}
+
+type timerCompleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timer to complete."`
 }
 
 // TimerComplete completes a timer in Teamwork.com.
 func TimerComplete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerComplete),
 			Description: "Complete an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Complete Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to complete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerCompleteInput) (*mcp.CallToolResult, error) {
 			var timerCompleteRequest projects.TimerCompleteRequest
+			timerCompleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerCompleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimerComplete(ctx, engine, timerCompleteRequest)
+			_, err := projects.TimerComplete(ctx, engine, timerCompleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to complete timer")
 			}
 			return helpers.NewToolResultText("Timer completed successfully"), nil
 		},
-	}
+	)
+}
+
+type timerDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timer to delete."`
 }
 
 // TimerDelete deletes a timer in Teamwork.com.
 func TimerDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerDelete),
 			Description: "Delete an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete Timer",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerDeleteInput) (*mcp.CallToolResult, error) {
 			var timerDeleteRequest projects.TimerDeleteRequest
+			timerDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.TimerDelete(ctx, engine, timerDeleteRequest)
+			_, err := projects.TimerDelete(ctx, engine, timerDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete timer")
 			}
 			return helpers.NewToolResultText("Timer deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type timerGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the timer to get."`
 }
 
 // TimerGet retrieves a timer in Teamwork.com.
 func TimerGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerGet),
 			Description: "Get an existing timer in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Timer",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the timer to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: timerGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerGetInput) (*mcp.CallToolResult, error) {
 			var timerGetRequest projects.TimerGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&timerGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timerGetRequest.Path.ID = input.ID
 
 			timer, err := projects.TimerGet(ctx, engine, timerGetRequest)
 			if err != nil {
@@ -433,72 +289,39 @@ func TimerGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: timer,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type timerListInput struct {
+	paginationInput
+
+	UserID            int64 `json:"user_id,omitempty" jsonschema:"The ID of the user to filter timers by. Only timers associated with this user will be returned."`
+	TaskID            int64 `json:"task_id,omitempty" jsonschema:"The ID of the task to filter timers by. Only timers associated with this task will be returned."`
+	ProjectID         int64 `json:"project_id,omitempty" jsonschema:"The ID of the project to filter timers by. Only timers associated with this project will be returned."`
+	RunningTimersOnly bool  `json:"running_timers_only,omitempty" jsonschema:"If true, only running timers will be returned. Defaults to false, which returns all timers."`
 }
 
 // TimerList lists timers in Teamwork.com.
 func TimerList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodTimerList),
 			Description: "List timers in Teamwork.com. " + timerDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Timers",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"user_id": {
-						Type: "integer",
-						Description: "The ID of the user to filter timers by. " +
-							"Only timers associated with this user will be returned.",
-					},
-					"task_id": {
-						Type: "integer",
-						Description: "The ID of the task to filter timers by. " +
-							"Only timers associated with this task will be returned.",
-					},
-					"project_id": {
-						Type: "integer",
-						Description: "The ID of the project to filter timers by. " +
-							"Only timers associated with this project will be returned.",
-					},
-					"running_timers_only": {
-						Type: "boolean",
-						Description: "If true, only running timers will be returned. " +
-							"Defaults to false, which returns all timers.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: timerListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input timerListInput) (*mcp.CallToolResult, error) {
 			var timerListRequest projects.TimerListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalNumericParam(&timerListRequest.Filters.UserID, "user_id"),
-				helpers.OptionalNumericParam(&timerListRequest.Filters.TaskID, "task_id"),
-				helpers.OptionalNumericParam(&timerListRequest.Filters.ProjectID, "project_id"),
-				helpers.OptionalParam(&timerListRequest.Filters.RunningTimersOnly, "running_timers_only"),
-				helpers.OptionalNumericParam(&timerListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&timerListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			timerListRequest.Filters.UserID = input.UserID
+			timerListRequest.Filters.TaskID = input.TaskID
+			timerListRequest.Filters.ProjectID = input.ProjectID
+			timerListRequest.Filters.RunningTimersOnly = input.RunningTimersOnly
+			timerListRequest.Filters.Page = input.Page
+			timerListRequest.Filters.PageSize = input.PageSize
 
 			timerList, err := projects.TimerList(ctx, engine, timerListRequest)
 			if err != nil {
@@ -520,5 +343,5 @@ func TimerList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: timerList,
 			}, nil
 		},
-	}
+	)
 }
@@ -1,105 +1,286 @@
 package twprojects
 
 import (
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/teamwork/mcp/internal/toolsets"
 	twapi "github.com/teamwork/twapi-go-sdk"
+	"github.com/teamwork/twapi-go-sdk/projects"
+)
+
+// List of toolsets available in the Teamwork.com MCP service. Tools are grouped
+// by domain, so agents can enable only the surface they need for a task.
+const (
+	ToolsetProjects  toolsets.Method = "projects"
+	ToolsetTasks     toolsets.Method = "tasks"
+	ToolsetTime      toolsets.Method = "time"
+	ToolsetPeople    toolsets.Method = "people"
+	ToolsetKnowledge toolsets.Method = "knowledge"
+	ToolsetPlanning  toolsets.Method = "planning"
+	ToolsetActivity  toolsets.Method = "activity"
 )
 
+const (
+	projectsToolsetDescription = "Manage projects, their members and tags. " + projectDescription
+	tasksToolsetDescription    = "Manage tasklists and tasks, the units of work inside projects. " +
+		taskDescription
+	timeToolsetDescription = "Log and review the time spent on projects and tasks, using timelogs and " +
+		"timers. " + timelogDescription
+	peopleToolsetDescription = "Manage the users, teams and companies of the Teamwork.com site. " +
+		userDescription
+	knowledgeToolsetDescription = "Manage notebooks and comments, where teams write and discuss " +
+		"content. " + notebookDescription
+	planningToolsetDescription = "Plan the work with milestones and check the workload of the team. " +
+		milestoneDescription
+	activityToolsetDescription = "Review the latest activity across projects. " + activityDescription
+)
+
+func init() {
+	// register the toolset methods
+	toolsets.RegisterMethod(ToolsetProjects)
+	toolsets.RegisterMethod(ToolsetTasks)
+	toolsets.RegisterMethod(ToolsetTime)
+	toolsets.RegisterMethod(ToolsetPeople)
+	toolsets.RegisterMethod(ToolsetKnowledge)
+	toolsets.RegisterMethod(ToolsetPlanning)
+	toolsets.RegisterMethod(ToolsetActivity)
+
+	// dates and times are encoded as strings in the tool arguments
+	toolsets.RegisterTypeSchema[projects.LegacyDate](&jsonschema.Schema{Type: "string"})
+	toolsets.RegisterTypeSchema[twapi.Date](&jsonschema.Schema{Type: "string", Format: "date"})
+	toolsets.RegisterTypeSchema[twapi.Time](&jsonschema.Schema{
+		Type:    "string",
+		Pattern: `^(?:[01]\d|2[0-3]):[0-5]\d:[0-5]\d$`,
+	})
+	toolsets.RegisterTypeSchema[time.Time](&jsonschema.Schema{Type: "string", Format: "date-time"})
+}
+
+// paginationInput contains the pagination arguments shared by the tools that
+// list entities.
+type paginationInput struct {
+	Page     int64 `json:"page,omitempty" jsonschema:"Page number for pagination of results."`
+	PageSize int64 `json:"page_size,omitempty" jsonschema:"Number of results per page for pagination."`
+}
+
+// userGroupsInput contains the users, companies and teams assigned to an
+// entity.
+type userGroupsInput struct {
+	UserIDs    []int64 `json:"user_ids,omitempty" jsonschema:"List of user IDs assigned."`
+	CompanyIDs []int64 `json:"company_ids,omitempty" jsonschema:"List of company IDs assigned."`
+	TeamIDs    []int64 `json:"team_ids,omitempty" jsonschema:"List of team IDs assigned."`
+}
+
+func (u userGroupsInput) userGroups() projects.UserGroups {
+	return projects.UserGroups{
+		UserIDs:    u.UserIDs,
+		CompanyIDs: u.CompanyIDs,
+		TeamIDs:    u.TeamIDs,
+	}
+}
+
+func (u userGroupsInput) legacyUserGroups() projects.LegacyUserGroups {
+	return projects.LegacyUserGroups{
+		UserIDs:    u.UserIDs,
+		CompanyIDs: u.CompanyIDs,
+		TeamIDs:    u.TeamIDs,
+	}
+}
+
+// withUserGroupsSchema describes the user groups property of an entity,
+// requiring at least one non-empty list of assignees.
+func withUserGroupsSchema(property, entity string) toolsets.InputSchemaOption {
+	return toolsets.WithInputSchemaProperty(property, func(schema *jsonschema.Schema) {
+		for name, description := range map[string]string{
+			"user_ids":    "List of user IDs assigned to the " + entity + ".",
+			"company_ids": "List of company IDs assigned to the " + entity + ".",
+			"team_ids":    "List of team IDs assigned to the " + entity + ".",
+		} {
+			schema.Properties[name].Description = description
+			schema.Properties[name].MinItems = twapi.Ptr(1)
+		}
+		schema.MinProperties = twapi.Ptr(1)
+		schema.MaxProperties = twapi.Ptr(3)
+		schema.AnyOf = []*jsonschema.Schema{
+			{Required: []string{"user_ids"}},
+			{Required: []string{"company_ids"}},
+			{Required: []string{"team_ids"}},
+		}
+	})
+}
+
+// toolsetDefinition describes the tools of a domain toolset. Delete tools are
+// kept apart, as they are only added when explicitly allowed.
+type toolsetDefinition struct {
+	method      toolsets.Method
+	description string
+	readTools   []toolsets.ToolWrapper
+	writeTools  []toolsets.ToolWrapper
+	deleteTools []toolsets.ToolWrapper
+}
+
 // DefaultToolsetGroup creates a default ToolsetGroup for Teamwork Projects.
 func DefaultToolsetGroup(readOnly, allowDelete bool, engine *twapi.Engine) *toolsets.ToolsetGroup {
-	writeTools := []toolsets.ToolWrapper{
-		ProjectCreate(engine),
-		ProjectUpdate(engine),
-		ProjectMemberAdd(engine),
-		TasklistCreate(engine),
-		TasklistUpdate(engine),
-		TaskCreate(engine),
-		TaskUpdate(engine),
-		UserCreate(engine),
-		UserUpdate(engine),
-		MilestoneCreate(engine),
-		MilestoneUpdate(engine),
-		CompanyCreate(engine),
-		CompanyUpdate(engine),
-		TagCreate(engine),
-		TagUpdate(engine),
-		TeamCreate(engine),
-		TeamUpdate(engine),
-		CommentCreate(engine),
-		CommentUpdate(engine),
-		TimelogCreate(engine),
-		TimelogUpdate(engine),
-		TimerCreate(engine),
-		TimerUpdate(engine),
-		TimerPause(engine),
-		TimerResume(engine),
-		TimerComplete(engine),
-		NotebookCreate(engine),
-		NotebookUpdate(engine),
-	}
-	if allowDelete {
-		writeTools = append(writeTools, []toolsets.ToolWrapper{
-			ProjectDelete(engine),
-			TasklistDelete(engine),
-			TaskDelete(engine),
-			UserDelete(engine),
-			MilestoneDelete(engine),
-			CompanyDelete(engine),
-			TagDelete(engine),
-			TeamDelete(engine),
-			CommentDelete(engine),
-			TimelogDelete(engine),
-			TimerDelete(engine),
-			NotebookDelete(engine),
-		}...)
+	definitions := []toolsetDefinition{
+		{
+			method:      ToolsetProjects,
+			description: projectsToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				ProjectGet(engine),
+				ProjectList(engine),
+				TagGet(engine),
+				TagList(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				ProjectCreate(engine),
+				ProjectUpdate(engine),
+				ProjectMemberAdd(engine),
+				TagCreate(engine),
+				TagUpdate(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				ProjectDelete(engine),
+				TagDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetTasks,
+			description: tasksToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				TasklistGet(engine),
+				TasklistList(engine),
+				TasklistListByProject(engine),
+				TaskGet(engine),
+				TaskList(engine),
+				TaskListByTasklist(engine),
+				TaskListByProject(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				TasklistCreate(engine),
+				TasklistUpdate(engine),
+				TaskCreate(engine),
+				TaskUpdate(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				TasklistDelete(engine),
+				TaskDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetTime,
+			description: timeToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				TimelogGet(engine),
+				TimelogList(engine),
+				TimelogListByProject(engine),
+				TimelogListByTask(engine),
+				TimerGet(engine),
+				TimerList(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				TimelogCreate(engine),
+				TimelogUpdate(engine),
+				TimerCreate(engine),
+				TimerUpdate(engine),
+				TimerPause(engine),
+				TimerResume(engine),
+				TimerComplete(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				TimelogDelete(engine),
+				TimerDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetPeople,
+			description: peopleToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				UserGet(engine),
+				UserGetMe(engine),
+				UserList(engine),
+				UserListByProject(engine),
+				CompanyGet(engine),
+				CompanyList(engine),
+				TeamGet(engine),
+				TeamList(engine),
+				TeamListByCompany(engine),
+				TeamListByProject(engine),
+				IndustryList(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				UserCreate(engine),
+				UserUpdate(engine),
+				CompanyCreate(engine),
+				CompanyUpdate(engine),
+				TeamCreate(engine),
+				TeamUpdate(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				UserDelete(engine),
+				CompanyDelete(engine),
+				TeamDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetKnowledge,
+			description: knowledgeToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				NotebookGet(engine),
+				NotebookList(engine),
+				CommentGet(engine),
+				CommentList(engine),
+				CommentListByFileVersion(engine),
+				CommentListByMilestone(engine),
+				CommentListByNotebook(engine),
+				CommentListByTask(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				NotebookCreate(engine),
+				NotebookUpdate(engine),
+				CommentCreate(engine),
+				CommentUpdate(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				NotebookDelete(engine),
+				CommentDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetPlanning,
+			description: planningToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				MilestoneGet(engine),
+				MilestoneList(engine),
+				MilestoneListByProject(engine),
+				UsersWorkload(engine),
+			},
+			writeTools: []toolsets.ToolWrapper{
+				MilestoneCreate(engine),
+				MilestoneUpdate(engine),
+			},
+			deleteTools: []toolsets.ToolWrapper{
+				MilestoneDelete(engine),
+			},
+		},
+		{
+			method:      ToolsetActivity,
+			description: activityToolsetDescription,
+			readTools: []toolsets.ToolWrapper{
+				ActivityList(engine),
+				ActivityListByProject(engine),
+			},
+		},
 	}
 
 	group := toolsets.NewToolsetGroup(readOnly)
-	group.AddToolset(toolsets.NewToolset("projects", projectDescription).
-		AddWriteTools(writeTools...).
-		AddReadTools(
-			ProjectGet(engine),
-			ProjectList(engine),
-			TasklistGet(engine),
-			TasklistList(engine),
-			TasklistListByProject(engine),
-			TaskGet(engine),
-			TaskList(engine),
-			TaskListByTasklist(engine),
-			TaskListByProject(engine),
-			UserGet(engine),
-			UserGetMe(engine),
-			UserList(engine),
-			UserListByProject(engine),
-			UsersWorkload(engine),
-			MilestoneGet(engine),
-			MilestoneList(engine),
-			MilestoneListByProject(engine),
-			CompanyGet(engine),
-			CompanyList(engine),
-			TagGet(engine),
-			TagList(engine),
-			TeamGet(engine),
-			TeamList(engine),
-			TeamListByCompany(engine),
-			TeamListByProject(engine),
-			CommentGet(engine),
-			CommentList(engine),
-			CommentListByFileVersion(engine),
-			CommentListByMilestone(engine),
-			CommentListByNotebook(engine),
-			CommentListByTask(engine),
-			TimelogGet(engine),
-			TimelogList(engine),
-			TimelogListByProject(engine),
-			TimelogListByTask(engine),
-			TimerGet(engine),
-			TimerList(engine),
-			ActivityList(engine),
-			ActivityListByProject(engine),
-			NotebookGet(engine),
-			NotebookList(engine),
-			IndustryList(engine),
-		))
+	for _, definition := range definitions {
+		writeTools := definition.writeTools
+		if allowDelete {
+			writeTools = append(writeTools, definition.deleteTools...)
+		}
+		group.AddToolset(toolsets.NewToolset(definition.method, definition.description).
+			AddWriteTools(writeTools...).
+			AddReadTools(definition.readTools...))
+	}
 	return group
 }
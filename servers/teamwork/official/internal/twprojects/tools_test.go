@@ -0,0 +1,71 @@
+package twprojects_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/mcp/internal/testutil"
+	"github.com/teamwork/mcp/internal/toolsets"
+	"github.com/teamwork/mcp/internal/twprojects"
+)
+
+func TestDefaultToolsetGroup(t *testing.T) {
+	engine := testutil.ProjectsEngineMock(http.StatusOK, []byte(`{}`))
+
+	tests := []struct {
+		name        string
+		readOnly    bool
+		allowDelete bool
+	}{{
+		name: "default",
+	}, {
+		name:     "read-only",
+		readOnly: true,
+	}, {
+		name:        "allow delete",
+		allowDelete: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := twprojects.DefaultToolsetGroup(tt.readOnly, tt.allowDelete, engine)
+
+			methods := []toolsets.Method{
+				twprojects.ToolsetProjects,
+				twprojects.ToolsetTasks,
+				twprojects.ToolsetTime,
+				twprojects.ToolsetPeople,
+				twprojects.ToolsetKnowledge,
+				twprojects.ToolsetPlanning,
+				twprojects.ToolsetActivity,
+			}
+			if len(group.Toolsets) != len(methods) {
+				t.Errorf("expected %d toolsets, got %d", len(methods), len(group.Toolsets))
+			}
+
+			seen := make(map[string]toolsets.Method)
+			for _, method := range methods {
+				if !method.IsRegistered() {
+					t.Errorf("toolset %q is not registered", method)
+				}
+				toolset, err := group.GetToolset(method)
+				if err != nil {
+					t.Fatalf("failed to get toolset %q: %v", method, err)
+				}
+				for _, tool := range toolset.GetAvailableTools() {
+					if other, ok := seen[tool.Tool.Name]; ok {
+						t.Errorf("tool %q is in toolsets %q and %q", tool.Tool.Name, other, method)
+					}
+					seen[tool.Tool.Name] = method
+					if tt.readOnly && !tool.Tool.Annotations.ReadOnlyHint {
+						t.Errorf("tool %q is not read-only", tool.Tool.Name)
+					}
+				}
+			}
+
+			if _, ok := seen[twprojects.MethodTaskDelete.String()]; ok != tt.allowDelete {
+				t.Errorf("expected delete tools to be available only when allowed (found: %t)", ok)
+			}
+		})
+	}
+}
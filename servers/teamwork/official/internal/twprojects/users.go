@@ -67,71 +67,36 @@ func init() {
 	}
 }
 
+//nolint:lll
+type userCreateInput struct {
+	FirstName string  `json:"first_name" jsonschema:"The first name of the user."`
+	LastName  string  `json:"last_name" jsonschema:"The last name of the user."`
+	Title     *string `json:"title,omitempty" jsonschema:"The job title of the user, such as 'Project Manager' or 'Senior Software Developer'."`
+	Email     string  `json:"email" jsonschema:"The email address of the user."`
+	Admin     *bool   `json:"admin,omitempty" jsonschema:"Indicates whether the user is an administrator."`
+	Type      *string `json:"type,omitempty" jsonschema:"The type of user, such as 'account', 'collaborator', or 'contact'."`
+	CompanyID *int64  `json:"company_id,omitempty" jsonschema:"The ID of the client/company to which the user belongs."`
+}
+
 // UserCreate creates a user in Teamwork.com.
 func UserCreate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserCreate),
 			Description: "Create a new user in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Create User",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"first_name": {
-						Type:        "string",
-						Description: "The first name of the user.",
-					},
-					"last_name": {
-						Type:        "string",
-						Description: "The last name of the user.",
-					},
-					"title": {
-						Type:        "string",
-						Description: "The job title of the user, such as 'Project Manager' or 'Senior Software Developer'.",
-					},
-					"email": {
-						Type:        "string",
-						Description: "The email address of the user.",
-					},
-					"admin": {
-						Type:        "boolean",
-						Description: "Indicates whether the user is an administrator.",
-					},
-					"type": {
-						Type:        "string",
-						Description: "The type of user, such as 'account', 'collaborator', or 'contact'.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the client/company to which the user belongs.",
-					},
-				},
-				Required: []string{"first_name", "last_name", "email"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userCreateInput) (*mcp.CallToolResult, error) {
 			var userCreateRequest projects.UserCreateRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredParam(&userCreateRequest.FirstName, "first_name"),
-				helpers.RequiredParam(&userCreateRequest.LastName, "last_name"),
-				helpers.OptionalPointerParam(&userCreateRequest.Title, "title"),
-				helpers.RequiredParam(&userCreateRequest.Email, "email"),
-				helpers.OptionalPointerParam(&userCreateRequest.Admin, "admin"),
-				helpers.OptionalPointerParam(&userCreateRequest.Type, "type",
-					helpers.RestrictValues("account", "collaborator", "contact"),
-				),
-				helpers.OptionalNumericPointerParam(&userCreateRequest.CompanyID, "company_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			userCreateRequest.FirstName = input.FirstName
+			userCreateRequest.LastName = input.LastName
+			userCreateRequest.Title = input.Title
+			userCreateRequest.Email = input.Email
+			userCreateRequest.Admin = input.Admin
+			userCreateRequest.Type = input.Type
+			userCreateRequest.CompanyID = input.CompanyID
 
 			user, err := projects.UserCreate(ctx, engine, userCreateRequest)
 			if err != nil {
@@ -139,167 +104,99 @@ func UserCreate(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultText("User created successfully with ID %d", user.ID), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", "account", "collaborator", "contact"),
+	)
+}
+
+//nolint:lll
+type userUpdateInput struct {
+	ID        int64   `json:"id" jsonschema:"The ID of the user to update."`
+	FirstName *string `json:"first_name,omitempty" jsonschema:"The first name of the user."`
+	LastName  *string `json:"last_name,omitempty" jsonschema:"The last name of the user."`
+	Title     *string `json:"title,omitempty" jsonschema:"The job title of the user, such as 'Project Manager' or 'Senior Software Developer'."`
+	Email     *string `json:"email,omitempty" jsonschema:"The email address of the user."`
+	Admin     *bool   `json:"admin,omitempty" jsonschema:"Indicates whether the user is an administrator."`
+	Type      *string `json:"type,omitempty" jsonschema:"The type of user, such as 'account', 'collaborator', or 'contact'."`
+	CompanyID *int64  `json:"company_id,omitempty" jsonschema:"The ID of the client/company to which the user belongs."`
 }
 
 // UserUpdate updates a user in Teamwork.com.
 func UserUpdate(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserUpdate),
 			Description: "Update an existing user in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Update User",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the user to update.",
-					},
-					"first_name": {
-						Type:        "string",
-						Description: "The first name of the user.",
-					},
-					"last_name": {
-						Type:        "string",
-						Description: "The last name of the user.",
-					},
-					"title": {
-						Type:        "string",
-						Description: "The job title of the user, such as 'Project Manager' or 'Senior Software Developer'.",
-					},
-					"email": {
-						Type:        "string",
-						Description: "The email address of the user.",
-					},
-					"admin": {
-						Type:        "boolean",
-						Description: "Indicates whether the user is an administrator.",
-					},
-					"type": {
-						Type:        "string",
-						Description: "The type of user, such as 'account', 'collaborator', or 'contact'.",
-					},
-					"company_id": {
-						Type:        "integer",
-						Description: "The ID of the client/company to which the user belongs.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userUpdateInput) (*mcp.CallToolResult, error) {
 			var userUpdateRequest projects.UserUpdateRequest
+			userUpdateRequest.Path.ID = input.ID
+			userUpdateRequest.FirstName = input.FirstName
+			userUpdateRequest.LastName = input.LastName
+			userUpdateRequest.Title = input.Title
+			userUpdateRequest.Email = input.Email
+			userUpdateRequest.Admin = input.Admin
+			userUpdateRequest.Type = input.Type
+			userUpdateRequest.CompanyID = input.CompanyID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&userUpdateRequest.Path.ID, "id"),
-				helpers.OptionalPointerParam(&userUpdateRequest.FirstName, "first_name"),
-				helpers.OptionalPointerParam(&userUpdateRequest.LastName, "last_name"),
-				helpers.OptionalPointerParam(&userUpdateRequest.Title, "title"),
-				helpers.OptionalPointerParam(&userUpdateRequest.Email, "email"),
-				helpers.OptionalPointerParam(&userUpdateRequest.Admin, "admin"),
-				helpers.OptionalPointerParam(&userUpdateRequest.Type, "type",
-					helpers.RestrictValues("account", "collaborator", "contact"),
-				),
-				helpers.OptionalNumericPointerParam(&userUpdateRequest.CompanyID, "company_id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.UserUpdate(ctx, engine, userUpdateRequest)
+			_, err := projects.UserUpdate(ctx, engine, userUpdateRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to update user")
 			}
 			return helpers.NewToolResultText("User updated successfully"), nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", "account", "collaborator", "contact"),
+	)
+}
+
+type userDeleteInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the user to delete."`
 }
 
 // UserDelete deletes a user in Teamwork.com.
 func UserDelete(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserDelete),
 			Description: "Delete an existing user in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title: "Delete User",
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the user to delete.",
-					},
-				},
-				Required: []string{"id"},
-			},
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userDeleteInput) (*mcp.CallToolResult, error) {
 			var userDeleteRequest projects.UserDeleteRequest
+			userDeleteRequest.Path.ID = input.ID
 
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&userDeleteRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
-
-			_, err = projects.UserDelete(ctx, engine, userDeleteRequest)
+			_, err := projects.UserDelete(ctx, engine, userDeleteRequest)
 			if err != nil {
 				return helpers.HandleAPIError(err, "failed to delete user")
 			}
 			return helpers.NewToolResultText("User deleted successfully"), nil
 		},
-	}
+	)
+}
+
+type userGetInput struct {
+	ID int64 `json:"id" jsonschema:"The ID of the user to get."`
 }
 
 // UserGet retrieves a user in Teamwork.com.
 func UserGet(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserGet),
 			Description: "Get an existing user in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get User",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"id": {
-						Type:        "integer",
-						Description: "The ID of the user to get.",
-					},
-				},
-				Required: []string{"id"},
-			},
 			OutputSchema: userGetOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userGetInput) (*mcp.CallToolResult, error) {
 			var userGetRequest projects.UserGetRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&userGetRequest.Path.ID, "id"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			userGetRequest.Path.ID = input.ID
 
 			user, err := projects.UserGet(ctx, engine, userGetRequest)
 			if err != nil {
@@ -321,23 +218,22 @@ func UserGet(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: user,
 			}, nil
 		},
-	}
+	)
 }
 
 // UserGetMe retrieves the logged user in Teamwork.com.
 func UserGetMe(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserGetMe),
 			Description: "Get the logged user in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Logged User",
 				ReadOnlyHint: true,
 			},
-			InputSchema:  &jsonschema.Schema{Type: "object"},
 			OutputSchema: userGetMeOutputSchema,
 		},
-		Handler: func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, error) {
 			var userGetMeRequest projects.UserGetMeRequest
 			user, err := projects.UserGetMe(ctx, engine, userGetMeRequest)
 			if err != nil {
@@ -359,62 +255,35 @@ func UserGetMe(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: user,
 			}, nil
 		},
-	}
+	)
+}
+
+//nolint:lll
+type userListInput struct {
+	paginationInput
+
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter users by first or last names, or e-mail. The user will be selected if each word of the term matches the first or last name, or e-mail, not requiring that the word matches are in the same field."`
+	Type       string `json:"type,omitempty" jsonschema:"Type of user to filter by. The available options are account, collaborator or contact."`
 }
 
 // UserList lists users in Teamwork.com.
 func UserList(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserList),
 			Description: "List users in Teamwork.com. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Users",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter users by first or last names, or e-mail. " +
-							"The user will be selected if each word of the term matches the first or last name, or e-mail, not " +
-							"requiring that the word matches are in the same field.",
-					},
-					"type": {
-						Type:        "integer",
-						Description: "Type of user to filter by. The available options are account, collaborator or contact.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-			},
 			OutputSchema: userListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userListInput) (*mcp.CallToolResult, error) {
 			var userListRequest projects.UserListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.OptionalParam(&userListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalParam(&userListRequest.Filters.Type, "type",
-					helpers.RestrictValues("account", "collaborator", "contact"),
-				),
-				helpers.OptionalNumericParam(&userListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&userListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			userListRequest.Filters.SearchTerm = input.SearchTerm
+			userListRequest.Filters.Type = input.Type
+			userListRequest.Filters.Page = input.Page
+			userListRequest.Filters.PageSize = input.PageSize
 
 			userList, err := projects.UserList(ctx, engine, userListRequest)
 			if err != nil {
@@ -436,68 +305,38 @@ func UserList(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: userList,
 			}, nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", "account", "collaborator", "contact"),
+	)
+}
+
+//nolint:lll
+type userListByProjectInput struct {
+	paginationInput
+
+	ProjectID  int64  `json:"project_id" jsonschema:"The ID of the project from which to retrieve users."`
+	SearchTerm string `json:"search_term,omitempty" jsonschema:"A search term to filter users by first or last names, or e-mail. The user will be selected if each word of the term matches the first or last name, or e-mail, not requiring that the word matches are in the same field."`
+	Type       string `json:"type,omitempty" jsonschema:"Type of user to filter by. The available options are account, collaborator or contact."`
 }
 
 // UserListByProject lists users in Teamwork.com by project.
 func UserListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUserListByProject),
 			Description: "List users in Teamwork.com by project. " + userDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "List Users By Project",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"project_id": {
-						Type:        "integer",
-						Description: "The ID of the project from which to retrieve users.",
-					},
-					"search_term": {
-						Type: "string",
-						Description: "A search term to filter users by first or last names, or e-mail. " +
-							"The user will be selected if each word of the term matches the first or last name, or e-mail, not " +
-							"requiring that the word matches are in the same field.",
-					},
-					"type": {
-						Type:        "integer",
-						Description: "Type of user to filter by. The available options are account, collaborator or contact.",
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"project_id"},
-			},
 			OutputSchema: userListOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input userListByProjectInput) (*mcp.CallToolResult, error) {
 			var userListRequest projects.UserListRequest
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
-			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredNumericParam(&userListRequest.Path.ProjectID, "project_id"),
-				helpers.OptionalParam(&userListRequest.Filters.SearchTerm, "search_term"),
-				helpers.OptionalParam(&userListRequest.Filters.Type, "type",
-					helpers.RestrictValues("account", "collaborator", "contact"),
-				),
-				helpers.OptionalNumericParam(&userListRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&userListRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
-			}
+			userListRequest.Path.ProjectID = input.ProjectID
+			userListRequest.Filters.SearchTerm = input.SearchTerm
+			userListRequest.Filters.Type = input.Type
+			userListRequest.Filters.Page = input.Page
+			userListRequest.Filters.PageSize = input.PageSize
 
 			userList, err := projects.UserList(ctx, engine, userListRequest)
 			if err != nil {
@@ -519,5 +358,6 @@ func UserListByProject(engine *twapi.Engine) toolsets.ToolWrapper {
 				StructuredContent: userList,
 			}, nil
 		},
-	}
+		toolsets.WithInputSchemaEnum("type", "account", "collaborator", "contact"),
+	)
 }
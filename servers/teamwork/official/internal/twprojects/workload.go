@@ -2,12 +2,10 @@ package twprojects
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-
 	"github.com/teamwork/mcp/internal/helpers"
 	"github.com/teamwork/mcp/internal/toolsets"
 	"github.com/teamwork/twapi-go-sdk"
@@ -47,92 +45,54 @@ func init() {
 	}
 }
 
+//nolint:lll
+type usersWorkloadInput struct {
+	paginationInput
+
+	StartDate      twapi.Date `json:"start_date" jsonschema:"The start date of the workload period. The date must be in the format YYYY-MM-DD."`
+	EndDate        twapi.Date `json:"end_date" jsonschema:"The end date of the workload period. The date must be in the format YYYY-MM-DD."`
+	UserIDs        []int64    `json:"user_ids,omitempty" jsonschema:"List of user IDs to filter the workload by."`
+	UserCompanyIDs []int64    `json:"user_company_ids,omitempty" jsonschema:"List of users' client/company IDs to filter the workload by."`
+	UserTeamIDs    []int64    `json:"user_team_ids,omitempty" jsonschema:"List of users' team IDs to filter the workload by."`
+	ProjectIDs     []int64    `json:"project_ids,omitempty" jsonschema:"List of project IDs to filter the workload by."`
+}
+
 // UsersWorkload retrieves the workload of users in Teamwork.com.
 func UsersWorkload(engine *twapi.Engine) toolsets.ToolWrapper {
-	return toolsets.ToolWrapper{
-		Tool: &mcp.Tool{
+	return toolsets.NewTypedToolWrapper(
+		&mcp.Tool{
 			Name:        string(MethodUsersWorkload),
 			Description: "Get the workload of users in Teamwork.com. " + workloadDescription,
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Get Users Workload",
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
-				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
-					"start_date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The start date of the workload period. The date must be in the format YYYY-MM-DD.",
-					},
-					"end_date": {
-						Type:        "string",
-						Format:      "date",
-						Description: "The end date of the workload period. The date must be in the format YYYY-MM-DD.",
-					},
-					"user_ids": {
-						Type:        "array",
-						Description: "List of user IDs to filter the workload by.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"user_company_ids": {
-						Type:        "array",
-						Description: "List of users' client/company IDs to filter the workload by.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"user_team_ids": {
-						Type:        "array",
-						Description: "List of users' team IDs to filter the workload by.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"project_ids": {
-						Type:        "array",
-						Description: "List of project IDs to filter the workload by.",
-						Items: &jsonschema.Schema{
-							Type: "integer",
-						},
-					},
-					"page": {
-						Type:        "integer",
-						Description: "Page number for pagination of results.",
-					},
-					"page_size": {
-						Type:        "integer",
-						Description: "Number of results per page for pagination.",
-					},
-				},
-				Required: []string{"start_date", "end_date"},
-			},
 			OutputSchema: userWorkloadOutputSchema,
 		},
-		Handler: func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, input usersWorkloadInput) (*mcp.CallToolResult, error) {
 			var workloadRequest projects.WorkloadRequest
 			workloadRequest.Filters.Include = []projects.WorkloadGetRequestSideload{
 				projects.WorkloadGetRequestSideloadWorkingHourEntries,
 			}
-
-			var arguments map[string]any
-			if err := json.Unmarshal(request.Params.Arguments, &arguments); err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("failed to decode request: %s", err.Error())), nil
+			workloadRequest.Filters.StartDate = input.StartDate
+			workloadRequest.Filters.EndDate = input.EndDate
+			if input.UserIDs != nil {
+				workloadRequest.Filters.UserIDs = input.UserIDs
 			}
-			err := helpers.ParamGroup(arguments,
-				helpers.RequiredDateParam(&workloadRequest.Filters.StartDate, "start_date"),
-				helpers.RequiredDateParam(&workloadRequest.Filters.EndDate, "end_date"),
-				helpers.OptionalNumericListParam(&workloadRequest.Filters.UserIDs, "user_ids"),
-				helpers.OptionalNumericListParam(&workloadRequest.Filters.UserCompanyIDs, "user_company_ids"),
-				helpers.OptionalNumericListParam(&workloadRequest.Filters.UserTeamIDs, "user_team_ids"),
-				helpers.OptionalNumericListParam(&workloadRequest.Filters.ProjectIDs, "project_ids"),
-				helpers.OptionalNumericParam(&workloadRequest.Filters.Page, "page"),
-				helpers.OptionalNumericParam(&workloadRequest.Filters.PageSize, "page_size"),
-			)
-			if err != nil {
-				return helpers.NewToolResultTextError(fmt.Sprintf("invalid parameters: %s", err.Error())), nil
+			if input.UserCompanyIDs != nil {
+				workloadRequest.Filters.UserCompanyIDs = input.UserCompanyIDs
+			}
+			if input.UserTeamIDs != nil {
+				workloadRequest.Filters.UserTeamIDs = input.UserTeamIDs
+			}
+			if input.ProjectIDs != nil {
+				workloadRequest.Filters.ProjectIDs = input.ProjectIDs
+			}
+			if input.Page > 0 {
+				workloadRequest.Filters.Page = input.Page
+			}
+			if input.PageSize > 0 {
+				workloadRequest.Filters.PageSize = input.PageSize
 			}
 
 			workload, err := projects.WorkloadGet(ctx, engine, workloadRequest)
@@ -141,5 +101,5 @@ func UsersWorkload(engine *twapi.Engine) toolsets.ToolWrapper {
 			}
 			return helpers.NewToolResultJSON(workload)
 		},
-	}
+	)
 }